@@ -0,0 +1,116 @@
+package audit
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// ErrAuditFileDisabled is returned when the events are queried but they are not written to a file
+var ErrAuditFileDisabled = errors.New("audit file is not enabled")
+
+// Sink receives the audit events
+type Sink interface {
+	Write(event models.AuditEvent) error
+	Close() error
+}
+
+// Query filters the audit events. Empty fields do not filter.
+type Query struct {
+	// Namespaces the events are read from, all when nil
+	Namespaces map[string]bool
+	User       string
+	Since      time.Time
+	// Maximum number of events returned, the most recent ones
+	Limit int
+}
+
+var sinks struct {
+	sync.RWMutex
+	file    *FileSink
+	webhook *WebhookSink
+}
+
+// Start opens the sinks configured for the audit events
+func Start(cfg config.AuditConfig) error {
+	sinks.Lock()
+	defer sinks.Unlock()
+	if cfg.FilePath != "" {
+		file, err := NewFileSink(cfg.FilePath, cfg.MaxSizeMB, cfg.MaxBackups)
+		if err != nil {
+			return err
+		}
+		sinks.file = file
+		log.Infof("Audit events written in [%s]", cfg.FilePath)
+	}
+	if cfg.Webhook.URL != "" {
+		sinks.webhook = NewWebhookSink(cfg.Webhook.URL, time.Duration(cfg.Webhook.Timeout)*time.Second)
+		log.Infof("Audit events posted to [%s]", cfg.Webhook.URL)
+	}
+	return nil
+}
+
+// Stop closes the sinks of the audit events
+func Stop() {
+	sinks.Lock()
+	defer sinks.Unlock()
+	if sinks.file != nil {
+		closeSink(sinks.file)
+	}
+	if sinks.webhook != nil {
+		closeSink(sinks.webhook)
+	}
+	sinks.file, sinks.webhook = nil, nil
+}
+
+func closeSink(sink Sink) {
+	if err := sink.Close(); err != nil {
+		log.Errorf("Error closing the audit sink: %v", err)
+	}
+}
+
+// Record writes an audit event in the log and in the started sinks. It does nothing when the audit is disabled.
+func Record(event models.AuditEvent) {
+	if !config.Get().Server.AuditLog {
+		return
+	}
+	log.Infof("AUDIT User [%s] Msg [%s on Namespace: %s Type: %s Subtype: %s Name: %s Result: %s]", event.User, event.Operation,
+		event.Namespace, event.ObjectType, event.ObjectSubtype, event.Name, event.Result)
+
+	sinks.RLock()
+	defer sinks.RUnlock()
+	if sinks.file != nil {
+		if err := sinks.file.Write(event); err != nil {
+			log.Errorf("Audit event could not be written: %v", err)
+		}
+	}
+	if sinks.webhook != nil {
+		if err := sinks.webhook.Write(event); err != nil {
+			log.Errorf("Audit event could not be posted: %v", err)
+		}
+	}
+}
+
+// Events returns the events of the audit file matching the query, the most recent first
+func Events(q Query) ([]models.AuditEvent, error) {
+	sinks.RLock()
+	defer sinks.RUnlock()
+	if sinks.file == nil {
+		return nil, ErrAuditFileDisabled
+	}
+	return sinks.file.Read(q)
+}
+
+func (q Query) matches(event models.AuditEvent) bool {
+	if q.Namespaces != nil && !q.Namespaces[event.Namespace] {
+		return false
+	}
+	if q.User != "" && q.User != event.User {
+		return false
+	}
+	return !event.Time.Before(q.Since)
+}
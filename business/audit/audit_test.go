@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/models"
+)
+
+func event(namespace, user, name string, at time.Time) models.AuditEvent {
+	return models.AuditEvent{Time: at, User: user, Operation: models.AuditCreate, Namespace: namespace, ObjectType: "virtualservices",
+		Name: name, Diff: json.RawMessage(`{"metadata":{"name":"` + name + `"}}`), Result: models.AuditSuccess}
+}
+
+func TestFileSinkRotationAndRead(t *testing.T) {
+	assert := assert.New(t)
+	dir, err := ioutil.TempDir("", "audit")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "logs", "audit.log")
+
+	sink, err := NewFileSink(path, 1, 2)
+	assert.NoError(err)
+	// rotate after a few events
+	sink.maxSize = 600
+
+	t0 := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 12; i++ {
+		ns, user := "bookinfo", "alice"
+		if i%3 == 0 {
+			ns, user = "travels", "bob"
+		}
+		assert.NoError(sink.Write(event(ns, user, string(rune('a'+i)), t0.Add(time.Duration(i)*time.Minute))))
+	}
+	_, err = os.Stat(path + ".2")
+	assert.NoError(err)
+	_, err = os.Stat(path + ".3")
+	assert.True(os.IsNotExist(err))
+
+	// the events are read back most recent first, from the file then the backups
+	events, err := sink.Read(Query{})
+	assert.NoError(err)
+	assert.True(len(events) > 3 && len(events) < 12)
+	assert.Equal("l", events[0].Name)
+	for i := 1; i < len(events); i++ {
+		assert.True(events[i].Time.Before(events[i-1].Time))
+	}
+
+	events, err = sink.Read(Query{Namespaces: map[string]bool{"travels": true}, Limit: 2})
+	assert.NoError(err)
+	assert.Len(events, 2)
+	assert.Equal("j", events[0].Name)
+	assert.Equal("g", events[1].Name)
+
+	events, err = sink.Read(Query{User: "bob", Since: t0.Add(6 * time.Minute)})
+	assert.NoError(err)
+	assert.Len(events, 2)
+	assert.Equal("j", events[0].Name)
+	assert.Equal("g", events[1].Name)
+
+	// the events are appended to the file when it is opened again
+	assert.NoError(sink.Close())
+	sink, err = NewFileSink(path, 1, 2)
+	assert.NoError(err)
+	assert.NoError(sink.Write(event("bookinfo", "alice", "m", t0.Add(time.Hour))))
+	events, err = sink.Read(Query{Limit: 2})
+	assert.NoError(err)
+	assert.Equal("m", events[0].Name)
+	assert.Equal("l", events[1].Name)
+	assert.NoError(sink.Close())
+}
+
+func TestWebhookSink(t *testing.T) {
+	assert := assert.New(t)
+
+	received := make(chan models.AuditEvent, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e := models.AuditEvent{}
+		assert.Equal("application/json", r.Header.Get("Content-Type"))
+		assert.NoError(json.NewDecoder(r.Body).Decode(&e))
+		received <- e
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, time.Second)
+	assert.NoError(sink.Write(event("bookinfo", "alice", "reviews", time.Now())))
+	assert.NoError(sink.Write(event("bookinfo", "alice", "ratings", time.Now())))
+	// the queued events are posted before closing
+	assert.NoError(sink.Close())
+	assert.Equal("reviews", (<-received).Name)
+	assert.Equal("ratings", (<-received).Name)
+}
@@ -0,0 +1,153 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// FileSink writes the audit events as JSON lines in a file. The file is rotated when it reaches the maximum size:
+// it is renamed with the .1 suffix, the previous backups are shifted and the oldest above maxBackups is removed.
+type FileSink struct {
+	mutex      sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// NewFileSink opens, or creates, the audit file. A maxSizeMB not positive disables the rotation.
+func NewFileSink(path string, maxSizeMB, maxBackups int) (*FileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	sink := &FileSink{path: path, maxSize: int64(maxSizeMB) * 1024 * 1024, maxBackups: maxBackups}
+	if err := sink.open(); err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
+
+func (s *FileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	s.file, s.size = file, info.Size()
+	return nil
+}
+
+// Write appends the event to the file
+func (s *FileSink) Write(event models.AuditEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	return err
+}
+
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	if s.maxBackups > 0 {
+		os.Remove(s.backup(s.maxBackups))
+		for i := s.maxBackups - 1; i > 0; i-- {
+			if err := os.Rename(s.backup(i), s.backup(i+1)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		if err := os.Rename(s.path, s.backup(1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(s.path); err != nil {
+		return err
+	}
+	return s.open()
+}
+
+func (s *FileSink) backup(i int) string {
+	return fmt.Sprintf("%s.%d", s.path, i)
+}
+
+// Read returns the events of the file and its backups matching the query, the most recent first
+func (s *FileSink) Read(q Query) ([]models.AuditEvent, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	events := []models.AuditEvent{}
+	// the current file holds the most recent events, then the backups by number
+	for i := 0; i <= s.maxBackups; i++ {
+		path := s.path
+		if i > 0 {
+			path = s.backup(i)
+		}
+		fileEvents, err := readEvents(path, q)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for j := len(fileEvents) - 1; j >= 0; j-- {
+			events = append(events, fileEvents[j])
+			if q.Limit > 0 && len(events) == q.Limit {
+				return events, nil
+			}
+		}
+	}
+	return events, nil
+}
+
+func readEvents(path string, q Query) ([]models.AuditEvent, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	events := []models.AuditEvent{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		event := models.AuditEvent{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			log.Warningf("Skipping a malformed audit event of [%s]: %v", path, err)
+			continue
+		}
+		if q.matches(event) {
+			events = append(events, event)
+		}
+	}
+	return events, scanner.Err()
+}
+
+// Close closes the file
+func (s *FileSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.file.Close()
+}
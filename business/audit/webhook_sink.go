@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// webhookQueueSize is the number of events waiting to be posted, the events above are dropped
+const webhookQueueSize = 100
+
+// WebhookSink posts the audit events as JSON to an URL. The events are posted in the background, in order,
+// so a slow webhook does not slow down the write operations.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+	queue  chan models.AuditEvent
+	done   chan struct{}
+}
+
+// NewWebhookSink starts posting the events written to the URL
+func NewWebhookSink(url string, timeout time.Duration) *WebhookSink {
+	sink := &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+		queue:  make(chan models.AuditEvent, webhookQueueSize),
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(sink.done)
+		for event := range sink.queue {
+			if err := sink.post(event); err != nil {
+				log.Errorf("Audit event could not be posted to [%s]: %v", sink.url, err)
+			}
+		}
+	}()
+	return sink
+}
+
+// Write queues the event to be posted
+func (s *WebhookSink) Write(event models.AuditEvent) error {
+	select {
+	case s.queue <- event:
+		return nil
+	default:
+		return errors.New("audit webhook queue is full, event dropped")
+	}
+}
+
+func (s *WebhookSink) post(event models.AuditEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
+
+// Close posts the queued events and stops
+func (s *WebhookSink) Close() error {
+	close(s.queue)
+	<-s.done
+	return nil
+}
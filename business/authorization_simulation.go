@@ -0,0 +1,125 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kiali/kiali/business/checkers/authorization"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// SimulateAuthorization evaluates a request to a workload against the AuthorizationPolicies applying to it, the ones
+// of the workload namespace and of the root namespace selecting the workload, and returns the decision of Istio
+func (in *IstioConfigService) SimulateAuthorization(namespace, workload string, simulation models.AuthorizationSimulation) (models.AuthorizationSimulationResult, error) {
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err := in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return models.AuthorizationSimulationResult{}, err
+	}
+
+	w, err := fetchWorkload(in.businessLayer, namespace, workload)
+	if err != nil {
+		return models.AuthorizationSimulationResult{}, err
+	}
+
+	aps, err := in.k8s.GetAuthorizationPolicies(namespace)
+	if err != nil {
+		return models.AuthorizationSimulationResult{}, err
+	}
+	if rootNamespace := config.Get().IstioNamespace; rootNamespace != namespace {
+		rootAps, err := in.k8s.GetAuthorizationPolicies(rootNamespace)
+		if err != nil {
+			// The mesh-wide policies are not evaluated when the user can't access to the root namespace
+			log.Warningf("GetAuthorizationPolicies failed during an authorization simulation. Probably user can't access to %s namespace. Error: %s", rootNamespace, err)
+		}
+		aps = append(rootAps, aps...)
+	}
+
+	return simulateAuthorization(aps, w.Labels, simulation), nil
+}
+
+// simulateAuthorization evaluates the policies selecting the workload in the Istio order: the CUSTOM policies
+// delegate the request to their provider, then the request is denied when any DENY policy matches, then it is
+// allowed when no ALLOW policy applies or any ALLOW policy matches. The AUDIT policies do not decide.
+func simulateAuthorization(aps []kubernetes.IstioObject, workloadLabels map[string]string, simulation models.AuthorizationSimulation) models.AuthorizationSimulationResult {
+	source := authorization.NewPolicySource(simulation.Source.Principal)
+	if simulation.Source.Namespace != "" {
+		source.Namespace = simulation.Source.Namespace
+	}
+	request := authorization.PolicyRequest{
+		Source:           source,
+		SourceIP:         simulation.Source.IP,
+		RequestPrincipal: simulation.Source.RequestPrincipal,
+		Claims:           simulation.Source.Claims,
+		Host:             simulation.Request.Host,
+		Method:           simulation.Request.Method,
+		Path:             simulation.Request.Path,
+		Port:             simulation.Request.Port,
+		Headers:          simulation.Request.Headers,
+	}
+
+	result := models.AuthorizationSimulationResult{
+		Custom:   []models.AuthorizationPolicyRule{},
+		Policies: []string{},
+	}
+	byAction := map[string][]kubernetes.IstioObject{}
+	for _, ap := range aps {
+		if !authorization.SelectorMatches(ap.GetSpec()["selector"], workloadLabels) {
+			continue
+		}
+		action, _ := ap.GetSpec()["action"].(string)
+		if action == "" {
+			action = "ALLOW"
+		}
+		byAction[action] = append(byAction[action], ap)
+		result.Policies = append(result.Policies, ap.GetObjectMeta().Namespace+"/"+ap.GetObjectMeta().Name)
+	}
+	sort.Strings(result.Policies)
+
+	for _, ap := range byAction["CUSTOM"] {
+		if rule := matchingRule(ap, "CUSTOM", request); rule != nil {
+			if provider, ok := ap.GetSpec()["provider"].(map[string]interface{}); ok {
+				rule.Provider, _ = provider["name"].(string)
+			}
+			result.Custom = append(result.Custom, *rule)
+		}
+	}
+
+	for _, ap := range byAction["DENY"] {
+		if rule := matchingRule(ap, "DENY", request); rule != nil {
+			result.Decision, result.Rule = "DENY", rule
+			result.Reason = fmt.Sprintf("Denied by the rule %d of the DENY policy %s/%s", rule.Rule, rule.Namespace, rule.Name)
+			return result
+		}
+	}
+
+	if len(byAction["ALLOW"]) == 0 {
+		result.Decision, result.Reason = "ALLOW", "No ALLOW policy applies to the workload"
+		return result
+	}
+	for _, ap := range byAction["ALLOW"] {
+		if rule := matchingRule(ap, "ALLOW", request); rule != nil {
+			result.Decision, result.Rule = "ALLOW", rule
+			result.Reason = fmt.Sprintf("Allowed by the rule %d of the ALLOW policy %s/%s", rule.Rule, rule.Namespace, rule.Name)
+			return result
+		}
+	}
+	result.Decision, result.Reason = "DENY", "No ALLOW policy matches the request"
+	return result
+}
+
+func matchingRule(ap kubernetes.IstioObject, action string, request authorization.PolicyRequest) *models.AuthorizationPolicyRule {
+	index := authorization.RuleMatchingRequest(ap.GetSpec()["rules"], request, action)
+	if index < 0 {
+		return nil
+	}
+	return &models.AuthorizationPolicyRule{
+		Namespace: ap.GetObjectMeta().Namespace,
+		Name:      ap.GetObjectMeta().Name,
+		Action:    action,
+		Rule:      index,
+	}
+}
@@ -0,0 +1,110 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+func fakeAuthorizationPolicy(namespace, name, action string, selector map[string]interface{}, rules []interface{}) kubernetes.IstioObject {
+	spec := map[string]interface{}{}
+	if action != "" {
+		spec["action"] = action
+	}
+	if selector != nil {
+		spec["selector"] = map[string]interface{}{"matchLabels": selector}
+	}
+	if rules != nil {
+		spec["rules"] = rules
+	}
+	return &kubernetes.GenericIstioObject{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       spec,
+	}
+}
+
+func methodRule(methods ...interface{}) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"to": []interface{}{map[string]interface{}{"operation": map[string]interface{}{"methods": methods}}},
+		},
+	}
+}
+
+func TestSimulateAuthorization(t *testing.T) {
+	assert := assert.New(t)
+
+	reviews := map[string]string{"app": "reviews", "version": "v1"}
+	get := models.AuthorizationSimulation{
+		Source:  models.AuthorizationSimulationSource{Principal: "cluster.local/ns/bookinfo/sa/bookinfo-productpage"},
+		Request: models.AuthorizationSimulationRequest{Method: "GET", Path: "/reviews/1", Port: 9080},
+	}
+	post := get
+	post.Request.Method = "POST"
+
+	// Without policy everything is allowed
+	result := simulateAuthorization(nil, reviews, get)
+	assert.Equal("ALLOW", result.Decision)
+	assert.Nil(result.Rule)
+
+	aps := []kubernetes.IstioObject{
+		fakeAuthorizationPolicy("istio-system", "deny-delete", "DENY", nil, methodRule("DELETE")),
+		fakeAuthorizationPolicy("bookinfo", "allow-get", "", map[string]interface{}{"app": "reviews"}, methodRule("HEAD", "GET")),
+		fakeAuthorizationPolicy("bookinfo", "ratings", "", map[string]interface{}{"app": "ratings"}, methodRule("POST")),
+		fakeAuthorizationPolicy("bookinfo", "ext-authz", "CUSTOM", nil, []interface{}{map[string]interface{}{}}),
+		fakeAuthorizationPolicy("bookinfo", "audit", "AUDIT", nil, []interface{}{map[string]interface{}{}}),
+	}
+	aps[3].GetSpec()["provider"] = map[string]interface{}{"name": "opa"}
+
+	result = simulateAuthorization(aps, reviews, get)
+	assert.Equal("ALLOW", result.Decision)
+	assert.Equal(&models.AuthorizationPolicyRule{Namespace: "bookinfo", Name: "allow-get", Action: "ALLOW", Rule: 0}, result.Rule)
+	assert.Equal([]models.AuthorizationPolicyRule{{Namespace: "bookinfo", Name: "ext-authz", Action: "CUSTOM", Provider: "opa"}}, result.Custom)
+	assert.Equal([]string{"bookinfo/allow-get", "bookinfo/audit", "bookinfo/ext-authz", "istio-system/deny-delete"}, result.Policies)
+
+	// An ALLOW policy applies but none matches
+	result = simulateAuthorization(aps, reviews, post)
+	assert.Equal("DENY", result.Decision)
+	assert.Nil(result.Rule)
+
+	// DENY policies are evaluated before ALLOW policies
+	aps = append(aps, fakeAuthorizationPolicy("bookinfo", "deny-get", "DENY", nil, methodRule("GET")))
+	result = simulateAuthorization(aps, reviews, get)
+	assert.Equal("DENY", result.Decision)
+	assert.Equal("deny-get", result.Rule.Name)
+
+	// An ALLOW policy without rules denies everything
+	aps = []kubernetes.IstioObject{fakeAuthorizationPolicy("bookinfo", "deny-all", "", nil, nil)}
+	result = simulateAuthorization(aps, reviews, get)
+	assert.Equal("DENY", result.Decision)
+}
+
+func TestSimulateAuthorizationUnknownCondition(t *testing.T) {
+	assert := assert.New(t)
+
+	reviews := map[string]string{"app": "reviews"}
+	get := models.AuthorizationSimulation{
+		Request: models.AuthorizationSimulationRequest{Method: "GET", Path: "/reviews/1", Port: 9080},
+	}
+	sni := []interface{}{
+		map[string]interface{}{
+			"when": []interface{}{map[string]interface{}{"key": "connection.sni", "values": []interface{}{"*.example.com"}}},
+		},
+	}
+
+	// A DENY rule with a condition the simulator can't evaluate is assumed to match
+	aps := []kubernetes.IstioObject{fakeAuthorizationPolicy("bookinfo", "deny-sni", "DENY", nil, sni)}
+	result := simulateAuthorization(aps, reviews, get)
+	assert.Equal("DENY", result.Decision)
+	assert.Equal("deny-sni", result.Rule.Name)
+
+	// An ALLOW rule with such a condition is assumed not to match
+	aps = []kubernetes.IstioObject{fakeAuthorizationPolicy("bookinfo", "allow-sni", "ALLOW", nil, sni)}
+	result = simulateAuthorization(aps, reviews, get)
+	assert.Equal("DENY", result.Decision)
+	assert.Nil(result.Rule)
+}
@@ -0,0 +1,99 @@
+package business
+
+import (
+	"strings"
+
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/business/checkers"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// IgnoreChecksAnnotation lists the codes of the checks suppressed on an object, i.e.
+// kiali.io/ignore-checks: KIA1106,KIA1107. A "*" suppresses every check of the object.
+const IgnoreChecksAnnotation = "kiali.io/ignore-checks"
+
+// checkSuppressions are the check codes suppressed globally, per namespace and per object
+type checkSuppressions struct {
+	global     map[string]bool
+	namespaces map[string]map[string]bool
+	objects    map[models.IstioValidationKey]map[string]bool
+}
+
+// suppressChecks moves the suppressed checks of the validations to their suppressed list. The objects
+// are the ones given to the checkers, their ignore annotation is read.
+func suppressChecks(validations models.IstioValidations, istioDetails kubernetes.IstioDetails, mtlsDetails kubernetes.MTLSDetails,
+	rbacDetails kubernetes.RBACDetails, gatewaysPerNamespace [][]kubernetes.IstioObject, services []core_v1.Service) models.IstioValidations {
+	s := newCheckSuppressions(config.Get().Validations)
+	s.addObjects(checkers.VirtualCheckerType, istioDetails.VirtualServices)
+	s.addObjects(checkers.DestinationRuleCheckerType, istioDetails.DestinationRules)
+	s.addObjects(checkers.DestinationRuleCheckerType, mtlsDetails.DestinationRules)
+	s.addObjects(checkers.ServiceEntryCheckerType, istioDetails.ServiceEntries)
+	s.addObjects(checkers.GatewayCheckerType, istioDetails.Gateways)
+	for _, gateways := range gatewaysPerNamespace {
+		s.addObjects(checkers.GatewayCheckerType, gateways)
+	}
+	s.addObjects(checkers.SidecarCheckerType, istioDetails.Sidecars)
+	s.addObjects(checkers.EnvoyFilterCheckerType, istioDetails.EnvoyFilters)
+	s.addObjects(checkers.PeerAuthenticationCheckerType, mtlsDetails.PeerAuthentications)
+	s.addObjects(checkers.PeerAuthenticationCheckerType, mtlsDetails.MeshPeerAuthentications)
+	s.addObjects(checkers.ServiceMeshPolicyCheckerType, mtlsDetails.ServiceMeshPolicies)
+	s.addObjects(checkers.AuthorizationPolicyCheckerType, rbacDetails.AuthorizationPolicies)
+	s.addObjects(checkers.ServiceRoleCheckerType, rbacDetails.ServiceRoles)
+	s.addObjects(checkers.ServiceRoleBindingCheckerType, rbacDetails.ServiceRoleBindings)
+	s.addObjects(checkers.RequestAuthenticationCheckerType, rbacDetails.RequestAuthentications)
+	for _, svc := range services {
+		s.addObject(checkers.ServiceCheckerType, svc.Namespace, svc.Name, svc.Annotations)
+	}
+	return validations.SuppressChecks(s.ignored)
+}
+
+func newCheckSuppressions(cfg config.ValidationsConfig) checkSuppressions {
+	s := checkSuppressions{
+		global:     codeSet(cfg.Ignore),
+		namespaces: make(map[string]map[string]bool, len(cfg.IgnoreNamespaces)),
+		objects:    make(map[models.IstioValidationKey]map[string]bool),
+	}
+	for ns, codes := range cfg.IgnoreNamespaces {
+		s.namespaces[ns] = codeSet(codes)
+	}
+	return s
+}
+
+func (s checkSuppressions) addObjects(objectType string, objects []kubernetes.IstioObject) {
+	for _, o := range objects {
+		meta := o.GetObjectMeta()
+		s.addObject(objectType, meta.Namespace, meta.Name, meta.Annotations)
+	}
+}
+
+func (s checkSuppressions) addObject(objectType, namespace, name string, annotations map[string]string) {
+	value, ok := annotations[IgnoreChecksAnnotation]
+	if !ok {
+		return
+	}
+	s.objects[models.BuildKey(objectType, name, namespace)] = codeSet(strings.Split(value, ","))
+}
+
+func (s checkSuppressions) ignored(key models.IstioValidationKey, check *models.IstioCheck) bool {
+	if check.Code == "" {
+		return false
+	}
+	if s.global[check.Code] || s.namespaces[key.Namespace][check.Code] {
+		return true
+	}
+	codes := s.objects[key]
+	return codes["*"] || codes[check.Code]
+}
+
+func codeSet(codes []string) map[string]bool {
+	set := make(map[string]bool, len(codes))
+	for _, c := range codes {
+		if c = strings.TrimSpace(c); c != "" {
+			set[strings.ToUpper(c)] = true
+		}
+	}
+	return set
+}
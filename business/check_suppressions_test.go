@@ -0,0 +1,68 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+func TestSuppressChecks(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	conf.Validations.Ignore = []string{"KIA1106"}
+	conf.Validations.IgnoreNamespaces = map[string][]string{"legacy": {"kia0202"}}
+	config.Set(conf)
+
+	vs := &kubernetes.GenericIstioObject{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo", Annotations: map[string]string{IgnoreChecksAnnotation: "KIA1101, KIA1102"}},
+	}
+	svc := core_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "ratings", Namespace: "bookinfo", Annotations: map[string]string{IgnoreChecksAnnotation: "*"}},
+	}
+	validations := models.IstioValidations{}
+	addCheck := func(objectType, namespace, name, checkId string) {
+		check := models.Build(checkId, "")
+		validations.MergeValidations(models.IstioValidations{
+			models.BuildKey(objectType, name, namespace): &models.IstioValidation{
+				Name: name, ObjectType: objectType, Valid: check.Severity != models.ErrorSeverity, Checks: []*models.IstioCheck{&check},
+			},
+		})
+	}
+	addCheck("virtualservice", "bookinfo", "reviews", "virtualservices.nohost.hostnotfound")
+	addCheck("virtualservice", "bookinfo", "reviews", "virtualservices.singlehost")
+	addCheck("virtualservice", "bookinfo", "reviews", "virtualservices.subsetpresent.subsetnotfound")
+	addCheck("virtualservice", "bookinfo", "details", "virtualservices.nohost.hostnotfound")
+	addCheck("destinationrule", "legacy", "reviews", "destinationrules.nodest.matchingregistry")
+	addCheck("service", "bookinfo", "ratings", "port.name.mismatch")
+
+	suppressChecks(validations, kubernetes.IstioDetails{VirtualServices: []kubernetes.IstioObject{vs}},
+		kubernetes.MTLSDetails{}, kubernetes.RBACDetails{}, nil, []core_v1.Service{svc})
+
+	// annotation and global config
+	reviews := validations[models.BuildKey("virtualservice", "reviews", "bookinfo")]
+	assert.True(reviews.Valid)
+	assert.Len(reviews.Checks, 1)
+	assert.Equal("KIA1107", reviews.Checks[0].Code)
+	assert.Len(reviews.SuppressedChecks, 2)
+
+	// the annotation applies to its object only
+	details := validations[models.BuildKey("virtualservice", "details", "bookinfo")]
+	assert.False(details.Valid)
+	assert.Empty(details.SuppressedChecks)
+
+	// namespace config
+	dr := validations[models.BuildKey("destinationrule", "reviews", "legacy")]
+	assert.True(dr.Valid)
+	assert.Empty(dr.Checks)
+
+	// wildcard annotation
+	ratings := validations[models.BuildKey("service", "ratings", "bookinfo")]
+	assert.True(ratings.Valid)
+	assert.Len(ratings.SuppressedChecks, 1)
+}
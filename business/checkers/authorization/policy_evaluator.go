@@ -0,0 +1,270 @@
+package authorization
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// PolicyRequest is a request as seen by the destination proxy, with the attributes the AuthorizationPolicies
+// can match on
+type PolicyRequest struct {
+	Source PolicySource
+	// IP of the source
+	SourceIP string
+	// Principal of the request JWT, as <iss>/<sub>. Empty without JWT.
+	RequestPrincipal string
+	// Claims of the request JWT
+	Claims  map[string][]string
+	Host    string
+	Method  string
+	Path    string
+	Port    uint32
+	Headers map[string]string
+}
+
+// RuleMatchingRequest returns the index of the first rule of an AuthorizationPolicy matching the request, -1 when
+// none does. A policy without rules matches no request, an empty rule matches every request.
+// Conditions on keys not known by the evaluator never match for ALLOW policies and always match for DENY and
+// CUSTOM policies, so that an unknown condition never turns a request Istio denies into an allowed one.
+func RuleMatchingRequest(rulesSpec interface{}, request PolicyRequest, action string) int {
+	rules, ok := rulesSpec.([]interface{})
+	if !ok {
+		return -1
+	}
+	for i, ruleSpec := range rules {
+		if ruleSpec == nil {
+			return i
+		}
+		rule, ok := ruleSpec.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if requestFromMatches(rule["from"], request) && toMatches(rule["to"], request) && whenMatches(rule["when"], request, action) {
+			return i
+		}
+	}
+	return -1
+}
+
+// requestFromMatches returns true when any source of the from field matches. A missing from field matches
+// every source.
+func requestFromMatches(fromSpec interface{}, request PolicyRequest) bool {
+	if fromSpec == nil {
+		return true
+	}
+	from, ok := fromSpec.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, f := range from {
+		fromMap, ok := f.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		sourceMap, ok := fromMap["source"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if sourceMatches(sourceMap, request.Source) && requestSourceMatches(sourceMap, request) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestSourceMatches evaluates the source fields not known from the principal only
+func requestSourceMatches(sourceMap map[string]interface{}, request PolicyRequest) bool {
+	if values, found := sourceMap["requestPrincipals"]; found && !valueMatches(values, request.RequestPrincipal) {
+		return false
+	}
+	if values, found := sourceMap["notRequestPrincipals"]; found && valueMatches(values, request.RequestPrincipal) {
+		return false
+	}
+	for _, field := range []string{"ipBlocks", "remoteIpBlocks"} {
+		if values, found := sourceMap[field]; found && !ipMatches(values, request.SourceIP) {
+			return false
+		}
+	}
+	for _, field := range []string{"notIpBlocks", "notRemoteIpBlocks"} {
+		if values, found := sourceMap[field]; found && ipMatches(values, request.SourceIP) {
+			return false
+		}
+	}
+	return true
+}
+
+// toMatches returns true when any operation of the to field matches. A missing to field matches every operation.
+func toMatches(toSpec interface{}, request PolicyRequest) bool {
+	if toSpec == nil {
+		return true
+	}
+	to, ok := toSpec.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, t := range to {
+		toMap, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		operation, ok := toMap["operation"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if operationMatches(operation, request) {
+			return true
+		}
+	}
+	return false
+}
+
+func operationMatches(operation map[string]interface{}, request PolicyRequest) bool {
+	port := ""
+	if request.Port != 0 {
+		port = fmt.Sprintf("%d", request.Port)
+	}
+	// hosts are case insensitive
+	host := strings.ToLower(request.Host)
+	fields := []struct {
+		values, notValues string
+		value             string
+	}{
+		{"hosts", "notHosts", host},
+		{"ports", "notPorts", port},
+		{"methods", "notMethods", request.Method},
+		{"paths", "notPaths", request.Path},
+	}
+	for _, f := range fields {
+		if values, found := operation[f.values]; found && !valueMatches(lowerHosts(f.values, values), f.value) {
+			return false
+		}
+		if values, found := operation[f.notValues]; found && valueMatches(lowerHosts(f.values, values), f.value) {
+			return false
+		}
+	}
+	return true
+}
+
+func lowerHosts(field string, valuesSpec interface{}) interface{} {
+	values, ok := valuesSpec.([]interface{})
+	if field != "hosts" || !ok {
+		return valuesSpec
+	}
+	lowered := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			lowered = append(lowered, strings.ToLower(s))
+		}
+	}
+	return lowered
+}
+
+// whenMatches returns true when every condition matches
+func whenMatches(whenSpec interface{}, request PolicyRequest, action string) bool {
+	if whenSpec == nil {
+		return true
+	}
+	when, ok := whenSpec.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, w := range when {
+		condition, ok := w.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key, _ := condition["key"].(string)
+		values, isIP, known := conditionValues(key, request)
+		if !known {
+			if action == "DENY" || action == "CUSTOM" {
+				continue
+			}
+			return false
+		}
+		match := valuesMatch
+		if isIP {
+			match = ipsMatch
+		}
+		if spec, found := condition["values"]; found && !match(spec, values) {
+			return false
+		}
+		if spec, found := condition["notValues"]; found && match(spec, values) {
+			return false
+		}
+	}
+	return true
+}
+
+// conditionValues returns the values of the request for a condition key, whether they are IPs and whether the
+// key is known
+func conditionValues(key string, request PolicyRequest) ([]string, bool, bool) {
+	switch {
+	case key == "source.ip" || key == "remote.ip":
+		return []string{request.SourceIP}, true, true
+	case key == "source.namespace":
+		return []string{request.Source.Namespace}, false, true
+	case key == "source.principal":
+		return []string{request.Source.Principal}, false, true
+	case key == "request.auth.principal":
+		return []string{request.RequestPrincipal}, false, true
+	case key == "request.auth.audiences":
+		return request.Claims["aud"], false, true
+	case key == "request.auth.presenter":
+		return request.Claims["azp"], false, true
+	case key == "destination.port":
+		return []string{fmt.Sprintf("%d", request.Port)}, false, true
+	case strings.HasPrefix(key, "request.auth.claims[") && strings.HasSuffix(key, "]"):
+		return request.Claims[key[len("request.auth.claims["):len(key)-1]], false, true
+	case strings.HasPrefix(key, "request.headers[") && strings.HasSuffix(key, "]"):
+		name := strings.ToLower(key[len("request.headers[") : len(key)-1])
+		for header, value := range request.Headers {
+			if strings.ToLower(header) == name {
+				return []string{value}, false, true
+			}
+		}
+		return nil, false, true
+	}
+	return nil, false, false
+}
+
+func valuesMatch(spec interface{}, values []string) bool {
+	for _, value := range values {
+		if valueMatches(spec, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func ipsMatch(spec interface{}, values []string) bool {
+	for _, value := range values {
+		if ipMatches(spec, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ipMatches returns true when the IP is any of the policy IPs or within any of the policy CIDR blocks
+func ipMatches(valuesSpec interface{}, ip string) bool {
+	values, ok := valuesSpec.([]interface{})
+	parsed := net.ParseIP(ip)
+	if !ok || parsed == nil {
+		return false
+	}
+	for _, v := range values {
+		block, ok := v.(string)
+		if !ok {
+			continue
+		}
+		if strings.Contains(block, "/") {
+			if _, cidr, err := net.ParseCIDR(block); err == nil && cidr.Contains(parsed) {
+				return true
+			}
+		} else if blockIP := net.ParseIP(block); blockIP != nil && blockIP.Equal(parsed) {
+			return true
+		}
+	}
+	return false
+}
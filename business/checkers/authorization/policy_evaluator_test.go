@@ -0,0 +1,100 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleMatchingRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	request := PolicyRequest{
+		Source:   NewPolicySource("spiffe://cluster.local/ns/bookinfo/sa/bookinfo-productpage"),
+		SourceIP: "10.1.0.12",
+		Host:     "Reviews.bookinfo.svc.cluster.local",
+		Method:   "GET",
+		Path:     "/reviews/1",
+		Port:     9080,
+		Headers:  map[string]string{"X-Version": "v2"},
+	}
+
+	rules := []interface{}{
+		map[string]interface{}{
+			"from": []interface{}{
+				map[string]interface{}{"source": map[string]interface{}{"namespaces": []interface{}{"foo"}}},
+			},
+		},
+		map[string]interface{}{
+			"from": []interface{}{
+				map[string]interface{}{"source": map[string]interface{}{
+					"principals": []interface{}{"cluster.local/ns/bookinfo/*"},
+					"ipBlocks":   []interface{}{"10.1.0.0/16"},
+				}},
+			},
+			"to": []interface{}{
+				map[string]interface{}{"operation": map[string]interface{}{
+					"hosts":    []interface{}{"reviews.bookinfo.svc.cluster.local"},
+					"methods":  []interface{}{"GET"},
+					"paths":    []interface{}{"/reviews/*"},
+					"notPorts": []interface{}{"9090"},
+				}},
+			},
+			"when": []interface{}{
+				map[string]interface{}{"key": "request.headers[x-version]", "values": []interface{}{"v2"}},
+			},
+		},
+	}
+	assert.Equal(1, RuleMatchingRequest(rules, request, "ALLOW"))
+
+	// Every condition must match
+	post := request
+	post.Method = "POST"
+	assert.Equal(-1, RuleMatchingRequest(rules, post, "ALLOW"))
+
+	other := request
+	other.SourceIP = "192.168.0.1"
+	assert.Equal(-1, RuleMatchingRequest(rules, other, "ALLOW"))
+
+	other = request
+	other.Headers = nil
+	assert.Equal(-1, RuleMatchingRequest(rules, other, "ALLOW"))
+
+	// An empty rule matches every request, no rule matches none
+	assert.Equal(0, RuleMatchingRequest([]interface{}{map[string]interface{}{}}, request, "ALLOW"))
+	assert.Equal(-1, RuleMatchingRequest([]interface{}{}, request, "ALLOW"))
+	assert.Equal(-1, RuleMatchingRequest(nil, request, "ALLOW"))
+}
+
+func TestRuleMatchingRequestConditions(t *testing.T) {
+	assert := assert.New(t)
+
+	request := PolicyRequest{
+		SourceIP:         "10.1.0.12",
+		RequestPrincipal: "issuer.example.com/subject",
+		Claims:           map[string][]string{"groups": {"dev", "admin"}},
+	}
+	when := func(key string, values ...interface{}) []interface{} {
+		return []interface{}{
+			map[string]interface{}{"when": []interface{}{map[string]interface{}{"key": key, "values": values}}},
+		}
+	}
+
+	assert.Equal(0, RuleMatchingRequest(when("request.auth.claims[groups]", "admin"), request, "ALLOW"))
+	assert.Equal(-1, RuleMatchingRequest(when("request.auth.claims[groups]", "ops"), request, "ALLOW"))
+	assert.Equal(0, RuleMatchingRequest(when("request.auth.principal", "issuer.example.com/*"), request, "ALLOW"))
+	assert.Equal(0, RuleMatchingRequest(when("source.ip", "10.1.0.12"), request, "ALLOW"))
+	assert.Equal(-1, RuleMatchingRequest(when("source.ip", "10.2.0.0/16"), request, "ALLOW"))
+	// Unknown keys never match for ALLOW policies and always match for DENY and CUSTOM ones
+	assert.Equal(-1, RuleMatchingRequest(when("connection.sni", "*"), request, "ALLOW"))
+	assert.Equal(0, RuleMatchingRequest(when("connection.sni", "*"), request, "DENY"))
+	assert.Equal(0, RuleMatchingRequest(when("connection.sni", "*"), request, "CUSTOM"))
+	// The known conditions are still evaluated
+	unknownAndKnown := []interface{}{
+		map[string]interface{}{"when": []interface{}{
+			map[string]interface{}{"key": "connection.sni", "values": []interface{}{"*"}},
+			map[string]interface{}{"key": "source.ip", "values": []interface{}{"10.2.0.0/16"}},
+		}},
+	}
+	assert.Equal(-1, RuleMatchingRequest(unknownAndKnown, request, "DENY"))
+}
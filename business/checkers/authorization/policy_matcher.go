@@ -0,0 +1,177 @@
+package authorization
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// PolicySource is the source of a request as seen by the destination proxy
+type PolicySource struct {
+	// Principal of the source, without the spiffe:// prefix. Empty for plaintext traffic.
+	Principal string
+	// Namespace of the source, taken from the principal. Empty for plaintext traffic.
+	Namespace string
+}
+
+// NewPolicySource builds the source of a request from a principal reported by telemetry,
+// i.e. spiffe://cluster.local/ns/bookinfo/sa/bookinfo-productpage
+func NewPolicySource(principal string) PolicySource {
+	source := PolicySource{}
+	principal = strings.TrimPrefix(principal, "spiffe://")
+	if principal == "" || principal == "unknown" {
+		return source
+	}
+	source.Principal = principal
+	parts := strings.Split(principal, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == "ns" {
+			source.Namespace = parts[i+1]
+			break
+		}
+	}
+	return source
+}
+
+// SelectorMatches returns true when the AuthorizationPolicy selector applies to a workload with the given labels.
+// A policy without selector applies to every workload of its namespace.
+func SelectorMatches(selectorSpec interface{}, workloadLabels map[string]string) bool {
+	matchLabels := selectorLabels(selectorSpec)
+	if len(matchLabels) == 0 {
+		return true
+	}
+	return labels.SelectorFromSet(labels.Set(matchLabels)).Matches(labels.Set(workloadLabels))
+}
+
+// RulesMatchSource returns whether any rule of an AuthorizationPolicy matches the source. Only the principal and
+// namespace of the source are evaluated, so conditional is true when every matching rule also restricts the
+// request source (IPs, request principals), operations (to) or conditions (when) and the match depends on the request.
+func RulesMatchSource(rulesSpec interface{}, source PolicySource) (matches bool, conditional bool) {
+	rules, ok := rulesSpec.([]interface{})
+	if !ok {
+		return false, false
+	}
+	conditional = true
+	for _, ruleSpec := range rules {
+		rule, ok := ruleSpec.(map[string]interface{})
+		if !ok {
+			// An empty rule matches everything
+			if ruleSpec == nil {
+				return true, false
+			}
+			continue
+		}
+		fromMatch, fromConditional := fromMatches(rule["from"], source)
+		if !fromMatch {
+			continue
+		}
+		matches = true
+		if !fromConditional && rule["to"] == nil && rule["when"] == nil {
+			conditional = false
+		}
+	}
+	if !matches {
+		conditional = false
+	}
+	return matches, conditional
+}
+
+// fromMatches returns true when any source of the from field matches. A missing from field matches every source.
+// conditional is true when every matching source also restricts fields only known from the request.
+func fromMatches(fromSpec interface{}, source PolicySource) (matches bool, conditional bool) {
+	if fromSpec == nil {
+		return true, false
+	}
+	from, ok := fromSpec.([]interface{})
+	if !ok {
+		return false, false
+	}
+	for _, f := range from {
+		fromMap, ok := f.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		sourceMap, ok := fromMap["source"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if !sourceMatches(sourceMap, source) {
+			continue
+		}
+		if !requestSourceRestricted(sourceMap) {
+			return true, false
+		}
+		matches, conditional = true, true
+	}
+	return matches, conditional
+}
+
+func sourceMatches(sourceMap map[string]interface{}, source PolicySource) bool {
+	if values, found := sourceMap["principals"]; found && !valueMatches(values, source.Principal) {
+		return false
+	}
+	if values, found := sourceMap["notPrincipals"]; found && valueMatches(values, source.Principal) {
+		return false
+	}
+	if values, found := sourceMap["namespaces"]; found && !valueMatches(values, source.Namespace) {
+		return false
+	}
+	if values, found := sourceMap["notNamespaces"]; found && valueMatches(values, source.Namespace) {
+		return false
+	}
+	return true
+}
+
+// requestSourceRestricted returns true when the source restricts fields not known from the principal
+func requestSourceRestricted(sourceMap map[string]interface{}) bool {
+	for _, field := range []string{"requestPrincipals", "notRequestPrincipals", "ipBlocks", "notIpBlocks", "remoteIpBlocks", "notRemoteIpBlocks"} {
+		if _, found := sourceMap[field]; found {
+			return true
+		}
+	}
+	return false
+}
+
+// valueMatches returns true when the value matches any of the policy values. Policy values support
+// exact, prefix (abc*), suffix (*abc) and presence (*) matching.
+func valueMatches(valuesSpec interface{}, value string) bool {
+	values, ok := valuesSpec.([]interface{})
+	if !ok || value == "" {
+		return false
+	}
+	for _, v := range values {
+		pattern, ok := v.(string)
+		if !ok {
+			continue
+		}
+		switch {
+		case pattern == "*":
+			return true
+		case strings.HasPrefix(pattern, "*"):
+			if strings.HasSuffix(value, pattern[1:]) {
+				return true
+			}
+		case strings.HasSuffix(pattern, "*"):
+			if strings.HasPrefix(value, pattern[:len(pattern)-1]) {
+				return true
+			}
+		case pattern == value:
+			return true
+		}
+	}
+	return false
+}
+
+func selectorLabels(selectorSpec interface{}) map[string]string {
+	result := map[string]string{}
+	if ml, ok := selectorSpec.(map[string]interface{}); ok {
+		if selectors, ok := ml["matchLabels"].(map[string]interface{}); ok {
+			for k, v := range selectors {
+				if s, ok := v.(string); ok {
+					result[k] = s
+				}
+			}
+		}
+	}
+	return result
+}
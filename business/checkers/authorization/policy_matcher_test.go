@@ -0,0 +1,87 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestNewPolicySource(t *testing.T) {
+	assert := assert.New(t)
+
+	source := NewPolicySource("spiffe://cluster.local/ns/bookinfo/sa/bookinfo-productpage")
+	assert.Equal("cluster.local/ns/bookinfo/sa/bookinfo-productpage", source.Principal)
+	assert.Equal("bookinfo", source.Namespace)
+
+	assert.Equal(PolicySource{}, NewPolicySource("unknown"))
+	assert.Equal(PolicySource{}, NewPolicySource(""))
+}
+
+func TestSelectorMatches(t *testing.T) {
+	assert := assert.New(t)
+
+	selector := map[string]interface{}{"matchLabels": map[string]interface{}{"app": "details"}}
+	assert.True(SelectorMatches(selector, map[string]string{"app": "details", "version": "v1"}))
+	assert.False(SelectorMatches(selector, map[string]string{"app": "reviews"}))
+	assert.True(SelectorMatches(nil, map[string]string{"app": "reviews"}))
+}
+
+func TestRulesMatchSource(t *testing.T) {
+	assert := assert.New(t)
+
+	productpage := NewPolicySource("spiffe://cluster.local/ns/bookinfo/sa/bookinfo-productpage")
+	sleep := NewPolicySource("spiffe://cluster.local/ns/foo/sa/sleep")
+	plaintext := NewPolicySource("unknown")
+
+	// namespaces source with operations: matching sources are conditional
+	ap := data.CreateAuthorizationPolicy([]interface{}{"bookinfo"}, []interface{}{"GET"}, []interface{}{"details"}, nil)
+	matches, conditional := RulesMatchSource(ap.GetSpec()["rules"], productpage)
+	assert.True(matches)
+	assert.True(conditional)
+	matches, conditional = RulesMatchSource(ap.GetSpec()["rules"], sleep)
+	assert.False(matches)
+	assert.False(conditional)
+
+	rules := []interface{}{
+		map[string]interface{}{
+			"from": []interface{}{
+				map[string]interface{}{
+					"source": map[string]interface{}{
+						"principals":    []interface{}{"cluster.local/ns/foo/*"},
+						"notNamespaces": []interface{}{"bookinfo"},
+					},
+				},
+			},
+		},
+	}
+	matches, conditional = RulesMatchSource(rules, sleep)
+	assert.True(matches)
+	assert.False(conditional)
+	matches, _ = RulesMatchSource(rules, productpage)
+	assert.False(matches)
+	matches, _ = RulesMatchSource(rules, plaintext)
+	assert.False(matches)
+
+	// sources restricting IPs or request principals only match depending on the request
+	for _, field := range []string{"ipBlocks", "remoteIpBlocks", "requestPrincipals", "notRequestPrincipals"} {
+		rules = []interface{}{
+			map[string]interface{}{
+				"from": []interface{}{
+					map[string]interface{}{"source": map[string]interface{}{field: []interface{}{"10.0.0.0/8"}}},
+				},
+			},
+		}
+		matches, conditional = RulesMatchSource(rules, sleep)
+		assert.True(matches, field)
+		assert.True(conditional, field)
+	}
+
+	// an empty rule matches every source, no rules match none
+	matches, conditional = RulesMatchSource([]interface{}{map[string]interface{}{}}, plaintext)
+	assert.True(matches)
+	assert.False(conditional)
+	matches, _ = RulesMatchSource(nil, plaintext)
+	assert.False(matches)
+}
@@ -0,0 +1,89 @@
+package authorization
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kiali/kiali/business/checkers/requestauthentications"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// RequestAuthChecker flags the fields of an AuthorizationPolicy requiring a JWT, i.e. the request.auth.claims
+// conditions, when a selected workload has no RequestAuthentication: those fields never match.
+type RequestAuthChecker struct {
+	AuthorizationPolicy kubernetes.IstioObject
+	// RequestAuthentications of the namespace and of the mesh
+	RequestAuthentications []kubernetes.IstioObject
+	WorkloadList           models.WorkloadList
+}
+
+func (rac RequestAuthChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	paths := rac.requestAuthPaths()
+	if len(paths) == 0 || !rac.hasWorkloadWithoutRequestAuth() {
+		return checks, valid
+	}
+	for _, path := range paths {
+		check := models.Build("authorizationpolicy.requestauth.notfound", path)
+		checks = append(checks, &check)
+	}
+	return checks, valid
+}
+
+// requestAuthPaths returns the paths of the request principals and of the conditions on request.auth attributes
+func (rac RequestAuthChecker) requestAuthPaths() []string {
+	paths := []string{}
+	rules, ok := rac.AuthorizationPolicy.GetSpec()["rules"].([]interface{})
+	if !ok {
+		return paths
+	}
+	for i, r := range rules {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if from, ok := rule["from"].([]interface{}); ok {
+			for j, f := range from {
+				fromMap, _ := f.(map[string]interface{})
+				source, _ := fromMap["source"].(map[string]interface{})
+				for _, field := range []string{"requestPrincipals", "notRequestPrincipals"} {
+					if _, found := source[field]; found {
+						paths = append(paths, fmt.Sprintf("spec/rules[%d]/from[%d]/source/%s", i, j, field))
+					}
+				}
+			}
+		}
+		if when, ok := rule["when"].([]interface{}); ok {
+			for j, w := range when {
+				condition, _ := w.(map[string]interface{})
+				if key, _ := condition["key"].(string); strings.HasPrefix(key, "request.auth.") {
+					paths = append(paths, fmt.Sprintf("spec/rules[%d]/when[%d]/key", i, j))
+				}
+			}
+		}
+	}
+	return paths
+}
+
+// hasWorkloadWithoutRequestAuth returns whether a workload selected by the policy has no RequestAuthentication.
+// The RequestAuthentications of the mesh apply to the workloads of every namespace.
+func (rac RequestAuthChecker) hasWorkloadWithoutRequestAuth() bool {
+	for _, w := range rac.WorkloadList.Workloads {
+		if !SelectorMatches(rac.AuthorizationPolicy.GetSpec()["selector"], w.Labels) {
+			continue
+		}
+		found := false
+		for _, ra := range rac.RequestAuthentications {
+			if requestauthentications.Applies(ra, w.Labels) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return true
+		}
+	}
+	return false
+}
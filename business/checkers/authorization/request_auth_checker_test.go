@@ -0,0 +1,96 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestRequestAuthPresent(t *testing.T) {
+	assert := assert.New(t)
+
+	validations, valid := RequestAuthChecker{
+		AuthorizationPolicy: requestAuthPolicy(),
+		RequestAuthentications: []kubernetes.IstioObject{
+			data.AddSelectorToRequestAuthentication(map[string]interface{}{"app": "details"},
+				data.CreateRequestAuthentication("jwt", "bookinfo")),
+		},
+		WorkloadList: workloadList(),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(validations)
+}
+
+func TestRequestAuthFromMesh(t *testing.T) {
+	assert := assert.New(t)
+
+	validations, valid := RequestAuthChecker{
+		AuthorizationPolicy:    requestAuthPolicy(),
+		RequestAuthentications: []kubernetes.IstioObject{data.CreateRequestAuthentication("jwt", "istio-system")},
+		WorkloadList:           workloadList(),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(validations)
+}
+
+func TestRequestAuthNotFound(t *testing.T) {
+	assert := assert.New(t)
+
+	validations, valid := RequestAuthChecker{
+		AuthorizationPolicy: requestAuthPolicy(),
+		RequestAuthentications: []kubernetes.IstioObject{
+			data.AddSelectorToRequestAuthentication(map[string]interface{}{"app": "reviews"},
+				data.CreateRequestAuthentication("jwt", "bookinfo")),
+		},
+		WorkloadList: workloadList(),
+	}.Check()
+
+	assert.True(valid)
+	assert.Len(validations, 2)
+	for _, check := range validations {
+		assert.Equal(models.WarningSeverity, check.Severity)
+		assert.Equal(models.CheckMessage("authorizationpolicy.requestauth.notfound"), check.Message)
+	}
+	assert.Equal("spec/rules[0]/from[0]/source/requestPrincipals", validations[0].Path)
+	assert.Equal("spec/rules[0]/when[0]/key", validations[1].Path)
+}
+
+func TestRequestAuthNotRequired(t *testing.T) {
+	assert := assert.New(t)
+
+	validations, valid := RequestAuthChecker{
+		AuthorizationPolicy: workloadSelectorAuthPolicy(map[string]interface{}{"app": "details"}),
+		WorkloadList:        workloadList(),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(validations)
+}
+
+func requestAuthPolicy() kubernetes.IstioObject {
+	ap := workloadSelectorAuthPolicy(map[string]interface{}{"app": "details"})
+	ap.GetSpec()["rules"] = []interface{}{
+		map[string]interface{}{
+			"from": []interface{}{
+				map[string]interface{}{
+					"source": map[string]interface{}{
+						"requestPrincipals": []interface{}{"issuer.example.com/*"},
+					},
+				},
+			},
+			"when": []interface{}{
+				map[string]interface{}{
+					"key":    "request.auth.claims[groups]",
+					"values": []interface{}{"admin"},
+				},
+			},
+		},
+	}
+	return ap
+}
@@ -14,9 +14,11 @@ type AuthorizationPolicyChecker struct {
 	AuthorizationPolicies []kubernetes.IstioObject
 	Namespace             string
 	Namespaces            models.Namespaces
-	ServiceEntries        []kubernetes.IstioObject
-	Services              []core_v1.Service
-	WorkloadList          models.WorkloadList
+	// RequestAuthentications of the namespace and of the mesh
+	RequestAuthentications []kubernetes.IstioObject
+	ServiceEntries         []kubernetes.IstioObject
+	Services               []core_v1.Service
+	WorkloadList           models.WorkloadList
 }
 
 func (a AuthorizationPolicyChecker) Check() models.IstioValidations {
@@ -40,6 +42,7 @@ func (a AuthorizationPolicyChecker) runChecks(authPolicy kubernetes.IstioObject)
 		authorization.WorkloadSelectorChecker{AuthorizationPolicy: authPolicy, WorkloadList: a.WorkloadList},
 		authorization.NoHostChecker{AuthorizationPolicy: authPolicy, Namespace: a.Namespace, Namespaces: a.Namespaces,
 			ServiceEntries: serviceHosts, Services: a.Services},
+		authorization.RequestAuthChecker{AuthorizationPolicy: authPolicy, RequestAuthentications: a.RequestAuthentications, WorkloadList: a.WorkloadList},
 	}
 
 	for _, checker := range enabledCheckers {
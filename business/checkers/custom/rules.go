@@ -0,0 +1,287 @@
+// Package custom runs the house rules of an organization, written in CEL, against the Istio config.
+// Rules are read from a ConfigMap and get the same inputs as the built-in checkers.
+//
+// 自定义校验规则: 用 CEL 表达式编写, 从 ConfigMap 加载
+package custom
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	yaml "gopkg.in/yaml.v2"
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const (
+	// LanguageCEL is the only supported rule language for now
+	LanguageCEL = "cel"
+	// LanguageRego is recognized, but rejected until an OPA evaluator is added
+	LanguageRego = "rego"
+)
+
+// ruleIdRegexp matches rule ids like HOUSE0001, which prefix the check messages the same way KIA codes do
+var ruleIdRegexp = regexp.MustCompile(`^[A-Z]+[0-9]+$`)
+
+// Rule is a user defined check. The expression is evaluated for every object of ObjectType and must
+// return true when the object is compliant; otherwise a check with Message and Severity is reported.
+//
+// The expression gets the variables:
+//
+//	object     the object being checked, as in its yaml (metadata, spec...)
+//	namespace  the namespace being validated
+//	objects    all the objects of ObjectType in the namespace, for rules comparing objects
+//	services   the Services of the namespace
+//	workloads  the workloads of the namespace (name, type, labels...)
+type Rule struct {
+	Id         string               `yaml:"id"`
+	ObjectType string               `yaml:"object_type"`          // i.e. virtualservice, as in validations
+	Namespaces []string             `yaml:"namespaces,omitempty"` // regexps matching the whole name, empty applies to all namespaces
+	Severity   models.SeverityLevel `yaml:"severity,omitempty"`   // error or warning, defaults to warning
+	Message    string               `yaml:"message"`
+	Path       string               `yaml:"path,omitempty"` // path of the check, i.e. spec/http
+	Language   string               `yaml:"language,omitempty"`
+	Expression string               `yaml:"expression"`
+
+	program    cel.Program
+	namespaces []*regexp.Regexp
+}
+
+// RuleSet is the content of each key of the rules ConfigMap
+type RuleSet struct {
+	Rules []*Rule `yaml:"rules"`
+}
+
+// Input holds the objects a rule is evaluated with, already converted with ToValue
+type Input struct {
+	Namespace string
+	Objects   []interface{}
+	Services  []interface{}
+	Workloads []interface{}
+}
+
+// NewInput returns the input of the rules of a namespace, Objects are set per object type
+func NewInput(namespace string, services []core_v1.Service, workloads models.WorkloadList) Input {
+	return Input{
+		Namespace: namespace,
+		Objects:   []interface{}{},
+		Services:  ToValues(services),
+		Workloads: ToValues(workloads.Workloads),
+	}
+}
+
+// compiledRules caches the rules of the last ConfigMap version read, so they are compiled once
+var compiledRules struct {
+	sync.Mutex
+	uid     string
+	version string
+	rules   []*Rule
+}
+
+var celEnv *cel.Env
+
+func init() {
+	var err error
+	celEnv, err = cel.NewEnv(
+		cel.Variable("object", cel.DynType),
+		cel.Variable("namespace", cel.StringType),
+		cel.Variable("objects", cel.ListType(cel.DynType)),
+		cel.Variable("services", cel.ListType(cel.DynType)),
+		cel.Variable("workloads", cel.ListType(cel.DynType)),
+		ext.Strings(),
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// RulesFromConfigMap returns the rules of every key of the ConfigMap. Invalid rules are skipped and
+// reported in the error, the valid ones are still returned. Rules are compiled again only when the
+// ConfigMap changes, the error is returned only the first time a version is read.
+func RulesFromConfigMap(cm *core_v1.ConfigMap) ([]*Rule, error) {
+	compiledRules.Lock()
+	defer compiledRules.Unlock()
+
+	if cm.ResourceVersion != "" && string(cm.UID) == compiledRules.uid && cm.ResourceVersion == compiledRules.version {
+		return compiledRules.rules, nil
+	}
+
+	keys := make([]string, 0, len(cm.Data))
+	for k := range cm.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	rules := []*Rule{}
+	errs := []string{}
+	for _, k := range keys {
+		parsed, err := ParseRules([]byte(cm.Data[k]))
+		rules = append(rules, parsed...)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", k, err))
+		}
+	}
+
+	compiledRules.uid = string(cm.UID)
+	compiledRules.version = cm.ResourceVersion
+	compiledRules.rules = rules
+	if len(errs) > 0 {
+		return rules, fmt.Errorf("invalid custom rules in ConfigMap [%s/%s]: %s", cm.Namespace, cm.Name, strings.Join(errs, "; "))
+	}
+	return rules, nil
+}
+
+// ParseRules reads and compiles a RuleSet. Invalid rules are skipped and reported in the error.
+func ParseRules(content []byte) ([]*Rule, error) {
+	ruleSet := RuleSet{}
+	if err := yaml.Unmarshal(content, &ruleSet); err != nil {
+		return nil, err
+	}
+
+	rules := make([]*Rule, 0, len(ruleSet.Rules))
+	errs := []string{}
+	for _, r := range ruleSet.Rules {
+		if err := r.compile(); err != nil {
+			errs = append(errs, fmt.Sprintf("rule [%s]: %v", r.Id, err))
+			continue
+		}
+		rules = append(rules, r)
+	}
+	if len(errs) > 0 {
+		return rules, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return rules, nil
+}
+
+func (r *Rule) compile() error {
+	if !ruleIdRegexp.MatchString(r.Id) {
+		return fmt.Errorf("id must be uppercase letters followed by digits, i.e. HOUSE0001")
+	}
+	if r.ObjectType == "" {
+		return fmt.Errorf("object_type is mandatory")
+	}
+	r.ObjectType = strings.ToLower(r.ObjectType)
+
+	switch r.Severity {
+	case "":
+		r.Severity = models.WarningSeverity
+	case models.ErrorSeverity, models.WarningSeverity:
+	default:
+		return fmt.Errorf("invalid severity [%s], supported values are error and warning", r.Severity)
+	}
+
+	switch strings.ToLower(r.Language) {
+	case "", LanguageCEL:
+		r.Language = LanguageCEL
+	case LanguageRego:
+		return fmt.Errorf("rego rules are not supported yet")
+	default:
+		return fmt.Errorf("invalid language [%s]", r.Language)
+	}
+
+	for _, ns := range r.Namespaces {
+		// the pattern must match the whole namespace, so prod doesn't apply to preprod
+		re, err := regexp.Compile("^(?:" + ns + ")$")
+		if err != nil {
+			return fmt.Errorf("invalid namespace pattern [%s]: %v", ns, err)
+		}
+		r.namespaces = append(r.namespaces, re)
+	}
+
+	ast, issues := celEnv.Compile(r.Expression)
+	if issues != nil && issues.Err() != nil {
+		return issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return fmt.Errorf("expression must return a bool, not %v", ast.OutputType())
+	}
+	program, err := celEnv.Program(ast)
+	if err != nil {
+		return err
+	}
+	r.program = program
+	return nil
+}
+
+// AppliesTo returns true when the rule checks the objects of the type in the namespace
+func (r *Rule) AppliesTo(objectType, namespace string) bool {
+	if r.ObjectType != objectType {
+		return false
+	}
+	if len(r.namespaces) == 0 {
+		return true
+	}
+	for _, re := range r.namespaces {
+		if re.MatchString(namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check evaluates the rule against one object, the object must be one of input.Objects.
+// It returns the check to report, nil when the object is compliant.
+func (r *Rule) Check(object interface{}, input Input) (*models.IstioCheck, error) {
+	out, _, err := r.program.Eval(map[string]interface{}{
+		"object":    object,
+		"namespace": input.Namespace,
+		"objects":   input.Objects,
+		"services":  input.Services,
+		"workloads": input.Workloads,
+	})
+	if err != nil {
+		return nil, err
+	}
+	compliant, ok := out.Value().(bool)
+	if !ok {
+		return nil, fmt.Errorf("expression returned %v instead of a bool", out.Value())
+	}
+	if compliant {
+		return nil, nil
+	}
+	return &models.IstioCheck{
+		Code:     r.Id,
+		Message:  fmt.Sprintf("%s %s", r.Id, r.Message),
+		Severity: r.Severity,
+		Path:     r.Path,
+	}, nil
+}
+
+// ToValue converts an object to the generic maps and lists the expressions work with, the same shape
+// the object has in its yaml
+func ToValue(object interface{}) interface{} {
+	if o, ok := object.(kubernetes.IstioObject); ok {
+		object = map[string]interface{}{
+			"kind":       o.GetTypeMeta().Kind,
+			"apiVersion": o.GetTypeMeta().APIVersion,
+			"metadata":   o.GetObjectMeta(),
+			"spec":       o.GetSpec(),
+		}
+	}
+	content, err := json.Marshal(object)
+	if err != nil {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(content, &value); err != nil {
+		return nil
+	}
+	return value
+}
+
+// ToValues converts a slice of objects with ToValue
+func ToValues(objects interface{}) []interface{} {
+	values := []interface{}{}
+	if list, ok := ToValue(objects).([]interface{}); ok {
+		values = list
+	}
+	return values
+}
@@ -0,0 +1,135 @@
+package custom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const testRules = `
+rules:
+- id: HOUSE0001
+  object_type: VirtualService
+  severity: error
+  message: Every HTTP route must set a timeout
+  path: spec/http
+  expression: "!has(object.spec.http) || object.spec.http.all(r, has(r.timeout))"
+- id: HOUSE0002
+  object_type: virtualservice
+  namespaces: ["^prod-.*"]
+  message: Hosts must be fully qualified
+  expression: "object.spec.hosts.all(h, h.endsWith('.svc.cluster.local'))"
+- id: house3
+  object_type: virtualservice
+  expression: "true"
+- id: HOUSE0004
+  object_type: gateway
+  language: rego
+  expression: "allow"
+- id: HOUSE0005
+  object_type: gateway
+  expression: "size(objects)"
+`
+
+func TestParseRules(t *testing.T) {
+	assert := assert.New(t)
+
+	rules, err := ParseRules([]byte(testRules))
+	assert.Error(err)
+	assert.Contains(err.Error(), "rule [house3]")
+	assert.Contains(err.Error(), "rule [HOUSE0004]: rego rules are not supported yet")
+	assert.Contains(err.Error(), "rule [HOUSE0005]: expression must return a bool")
+
+	// invalid rules are skipped, the valid ones are kept
+	assert.Len(rules, 2)
+	assert.Equal("virtualservice", rules[0].ObjectType)
+	assert.Equal(models.ErrorSeverity, rules[0].Severity)
+	assert.Equal(models.WarningSeverity, rules[1].Severity)
+	assert.Equal(LanguageCEL, rules[1].Language)
+
+	assert.True(rules[0].AppliesTo("virtualservice", "bookinfo"))
+	assert.False(rules[0].AppliesTo("gateway", "bookinfo"))
+	assert.False(rules[1].AppliesTo("virtualservice", "bookinfo"))
+	assert.True(rules[1].AppliesTo("virtualservice", "prod-bookinfo"))
+}
+
+func TestRuleNamespacesMatchWholeName(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := Rule{Id: "HOUSE0006", ObjectType: "virtualservice", Namespaces: []string{"prod", "team-a|team-b"}, Expression: "true"}
+	assert.NoError(rule.compile())
+
+	assert.True(rule.AppliesTo("virtualservice", "prod"))
+	assert.False(rule.AppliesTo("virtualservice", "preprod"))
+	assert.False(rule.AppliesTo("virtualservice", "production-tools"))
+	assert.True(rule.AppliesTo("virtualservice", "team-a"))
+	assert.True(rule.AppliesTo("virtualservice", "team-b"))
+	assert.False(rule.AppliesTo("virtualservice", "team-a-tools"))
+}
+
+func TestRuleCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	rules, _ := ParseRules([]byte(testRules))
+	input := NewInput("bookinfo", nil, models.WorkloadList{})
+
+	vs := ToValue(&kubernetes.GenericIstioObject{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"},
+		Spec: map[string]interface{}{
+			"hosts": []interface{}{"reviews"},
+			"http":  []interface{}{map[string]interface{}{"timeout": "1s"}, map[string]interface{}{}},
+		},
+	})
+	check, err := rules[0].Check(vs, input)
+	assert.NoError(err)
+	assert.Equal(&models.IstioCheck{
+		Code:     "HOUSE0001",
+		Message:  "HOUSE0001 Every HTTP route must set a timeout",
+		Severity: models.ErrorSeverity,
+		Path:     "spec/http",
+	}, check)
+
+	check, err = rules[1].Check(vs, input)
+	assert.NoError(err)
+	assert.Equal("HOUSE0002 Hosts must be fully qualified", check.Message)
+
+	// an object without http routes is compliant with the first rule
+	tcp := ToValue(&kubernetes.GenericIstioObject{Spec: map[string]interface{}{}})
+	check, err = rules[0].Check(tcp, input)
+	assert.NoError(err)
+	assert.Nil(check)
+
+	// a missing field fails the evaluation
+	_, err = rules[1].Check(tcp, input)
+	assert.Error(err)
+}
+
+func TestRulesFromConfigMap(t *testing.T) {
+	assert := assert.New(t)
+
+	cm := &core_v1.ConfigMap{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "kiali-validation-rules", Namespace: "istio-system", UID: "1", ResourceVersion: "1"},
+		Data: map[string]string{
+			"rules.yaml": testRules,
+		},
+	}
+	rules, err := RulesFromConfigMap(cm)
+	assert.Error(err)
+	assert.Len(rules, 2)
+
+	// the same version is not compiled again
+	cached, err := RulesFromConfigMap(cm)
+	assert.NoError(err)
+	assert.Equal(rules, cached)
+
+	cm.ResourceVersion = "2"
+	cm.Data["rules.yaml"] = "rules: []"
+	rules, err = RulesFromConfigMap(cm)
+	assert.NoError(err)
+	assert.Empty(rules)
+}
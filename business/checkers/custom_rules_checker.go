@@ -0,0 +1,104 @@
+package checkers
+
+import (
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/business/checkers/custom"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// CustomRulesChecker runs the user defined rules against the objects of a namespace
+type CustomRulesChecker struct {
+	Rules        []*custom.Rule
+	Namespace    string
+	IstioDetails kubernetes.IstioDetails
+	MTLSDetails  kubernetes.MTLSDetails
+	RBACDetails  kubernetes.RBACDetails
+	Services     []core_v1.Service
+	WorkloadList models.WorkloadList
+}
+
+func (c CustomRulesChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+	if len(c.Rules) == 0 {
+		return validations
+	}
+
+	input := custom.NewInput(c.Namespace, c.Services, c.WorkloadList)
+	for objectType, objects := range c.objectsByType() {
+		rules := make([]*custom.Rule, 0, len(c.Rules))
+		for _, r := range c.Rules {
+			if r.AppliesTo(objectType, c.Namespace) {
+				rules = append(rules, r)
+			}
+		}
+		if len(rules) == 0 {
+			continue
+		}
+
+		input.Objects = objectValues(objects)
+		for i, object := range input.Objects {
+			name, namespace := objectName(objects, i)
+			if namespace != c.Namespace {
+				continue
+			}
+			key, validation := EmptyValidValidation(name, namespace, objectType)
+			for _, r := range rules {
+				check, err := r.Check(object, input)
+				if err != nil {
+					log.Warningf("Custom rule [%s] could not be evaluated on %s [%s/%s]: %v", r.Id, objectType, namespace, name, err)
+					continue
+				}
+				if check != nil {
+					validation.Checks = append(validation.Checks, check)
+					validation.Valid = validation.Valid && check.Severity != models.ErrorSeverity
+				}
+			}
+			validations.MergeValidations(models.IstioValidations{key: validation})
+		}
+	}
+	return validations
+}
+
+// objectsByType returns the objects checked by the rules, keyed by the object type of validations
+func (c CustomRulesChecker) objectsByType() map[string]interface{} {
+	return map[string]interface{}{
+		AuthorizationPolicyCheckerType: c.RBACDetails.AuthorizationPolicies,
+		DestinationRuleCheckerType:     c.IstioDetails.DestinationRules,
+		EnvoyFilterCheckerType:         c.IstioDetails.EnvoyFilters,
+		GatewayCheckerType:             c.IstioDetails.Gateways,
+		PeerAuthenticationCheckerType:  c.MTLSDetails.PeerAuthentications,
+		ServiceCheckerType:             c.Services,
+		ServiceEntryCheckerType:        c.IstioDetails.ServiceEntries,
+		ServiceMeshPolicyCheckerType:   c.MTLSDetails.ServiceMeshPolicies,
+		ServiceRoleBindingCheckerType:  c.RBACDetails.ServiceRoleBindings,
+		ServiceRoleCheckerType:         c.RBACDetails.ServiceRoles,
+		SidecarCheckerType:             c.IstioDetails.Sidecars,
+		VirtualCheckerType:             c.IstioDetails.VirtualServices,
+	}
+}
+
+func objectValues(objects interface{}) []interface{} {
+	values := []interface{}{}
+	switch list := objects.(type) {
+	case []kubernetes.IstioObject:
+		for _, o := range list {
+			values = append(values, custom.ToValue(o))
+		}
+	case []core_v1.Service:
+		values = custom.ToValues(list)
+	}
+	return values
+}
+
+func objectName(objects interface{}, i int) (string, string) {
+	switch list := objects.(type) {
+	case []kubernetes.IstioObject:
+		return list[i].GetObjectMeta().Name, list[i].GetObjectMeta().Namespace
+	case []core_v1.Service:
+		return list[i].Name, list[i].Namespace
+	}
+	return "", ""
+}
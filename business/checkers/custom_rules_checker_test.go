@@ -0,0 +1,65 @@
+package checkers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/business/checkers/custom"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestCustomRulesChecker(t *testing.T) {
+	assert := assert.New(t)
+
+	rules, err := custom.ParseRules([]byte(`
+rules:
+- id: HOUSE0001
+  object_type: virtualservice
+  severity: error
+  message: Routes must point to a workload of the namespace
+  expression: "workloads.exists(w, w.name == object.metadata.name)"
+- id: HOUSE0002
+  object_type: virtualservice
+  message: Only one VirtualService per namespace
+  expression: "size(objects) == 1"
+`))
+	assert.NoError(err)
+
+	validations := CustomRulesChecker{
+		Rules:     rules,
+		Namespace: "bookinfo",
+		IstioDetails: kubernetes.IstioDetails{
+			VirtualServices: []kubernetes.IstioObject{
+				data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"}),
+				data.CreateEmptyVirtualService("ratings", "bookinfo", []string{"ratings"}),
+			},
+		},
+		WorkloadList: data.CreateWorkloadList("bookinfo", data.CreateWorkloadListItem("reviews", map[string]string{"app": "reviews"})),
+	}.Check()
+
+	reviews := validations[models.IstioValidationKey{ObjectType: "virtualservice", Namespace: "bookinfo", Name: "reviews"}]
+	assert.NotNil(reviews)
+	assert.True(reviews.Valid)
+	assert.Len(reviews.Checks, 1)
+	assert.Equal("HOUSE0002 Only one VirtualService per namespace", reviews.Checks[0].Message)
+	assert.Equal(models.WarningSeverity, reviews.Checks[0].Severity)
+
+	ratings := validations[models.IstioValidationKey{ObjectType: "virtualservice", Namespace: "bookinfo", Name: "ratings"}]
+	assert.NotNil(ratings)
+	assert.False(ratings.Valid)
+	assert.Len(ratings.Checks, 2)
+	assert.Equal("HOUSE0001 Routes must point to a workload of the namespace", ratings.Checks[0].Message)
+}
+
+func TestCustomRulesCheckerNoRules(t *testing.T) {
+	validations := CustomRulesChecker{
+		Namespace: "bookinfo",
+		IstioDetails: kubernetes.IstioDetails{
+			VirtualServices: []kubernetes.IstioObject{data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})},
+		},
+	}.Check()
+	assert.Empty(t, validations)
+}
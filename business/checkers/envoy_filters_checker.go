@@ -0,0 +1,75 @@
+package checkers
+
+import (
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/business/checkers/envoyfilters"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const EnvoyFilterCheckerType = "envoyfilter"
+
+type EnvoyFilterChecker struct {
+	EnvoyFilters         []kubernetes.IstioObject
+	ServiceEntries       []kubernetes.IstioObject
+	Services             []core_v1.Service
+	GatewaysPerNamespace [][]kubernetes.IstioObject
+	WorkloadList         models.WorkloadList
+}
+
+func (e EnvoyFilterChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	validations = validations.MergeValidations(e.runIndividualChecks())
+	validations = validations.MergeValidations(e.runGroupChecks())
+
+	return validations
+}
+
+func (e EnvoyFilterChecker) runGroupChecks() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	enabledCheckers := []GroupChecker{
+		envoyfilters.MultiMatchChecker{EnvoyFilters: e.EnvoyFilters},
+	}
+
+	for _, checker := range enabledCheckers {
+		validations = validations.MergeValidations(checker.Check())
+	}
+
+	return validations
+}
+
+func (e EnvoyFilterChecker) runIndividualChecks() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for _, envoyFilter := range e.EnvoyFilters {
+		validations.MergeValidations(e.runChecks(envoyFilter))
+	}
+
+	return validations
+}
+
+func (e EnvoyFilterChecker) runChecks(envoyFilter kubernetes.IstioObject) models.IstioValidations {
+	key, rrValidation := EmptyValidValidation(envoyFilter.GetObjectMeta().Name, envoyFilter.GetObjectMeta().Namespace, EnvoyFilterCheckerType)
+
+	gateways := make([]kubernetes.IstioObject, 0)
+	for _, gws := range e.GatewaysPerNamespace {
+		gateways = append(gateways, gws...)
+	}
+
+	enabledCheckers := []Checker{
+		envoyfilters.WorkloadSelectorChecker{EnvoyFilter: envoyFilter, WorkloadList: e.WorkloadList},
+		envoyfilters.PatchTargetChecker{EnvoyFilter: envoyFilter, EnvoyFilters: e.EnvoyFilters, Services: e.Services,
+			ServiceEntries: e.ServiceEntries, Gateways: gateways},
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		rrValidation.Checks = append(rrValidation.Checks, checks...)
+		rrValidation.Valid = rrValidation.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: rrValidation}
+}
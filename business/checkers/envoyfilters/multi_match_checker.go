@@ -0,0 +1,74 @@
+package envoyfilters
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const EnvoyFilterCheckerType = "envoyfilter"
+
+// MultiMatchChecker looks for EnvoyFilters of a namespace with the same priority that patch the same
+// config of the same workloads. Istio applies them by creation time, which is easily changed by a
+// redeploy, so the final config is not predictable from the filters.
+type MultiMatchChecker struct {
+	EnvoyFilters []kubernetes.IstioObject
+}
+
+func (m MultiMatchChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for i, ef := range m.EnvoyFilters {
+		for j, other := range m.EnvoyFilters {
+			if i == j || ef.GetObjectMeta().Namespace != other.GetObjectMeta().Namespace {
+				continue
+			}
+			if getPriority(ef) != getPriority(other) || !reflect.DeepEqual(getWorkloadSelectorLabels(ef), getWorkloadSelectorLabels(other)) {
+				continue
+			}
+
+			patchIdx, found := conflictingPatch(ef, other)
+			if !found {
+				continue
+			}
+
+			key := models.BuildKey(EnvoyFilterCheckerType, ef.GetObjectMeta().Name, ef.GetObjectMeta().Namespace)
+			check := models.Build("envoyfilter.multimatch.priority", fmt.Sprintf("spec/configPatches[%d]", patchIdx))
+			validations.MergeValidations(models.IstioValidations{
+				key: &models.IstioValidation{
+					Name:       key.Name,
+					ObjectType: EnvoyFilterCheckerType,
+					Valid:      true,
+					References: []models.IstioValidationKey{
+						models.BuildKey(EnvoyFilterCheckerType, other.GetObjectMeta().Name, other.GetObjectMeta().Namespace),
+					},
+					Checks: []*models.IstioCheck{&check},
+				},
+			})
+		}
+	}
+
+	return validations
+}
+
+// conflictingPatch returns the index of the first patch of ef applied to the same config as a patch of other
+func conflictingPatch(ef, other kubernetes.IstioObject) (int, bool) {
+	otherPatches := getConfigPatches(other)
+	for i, cp := range getConfigPatches(ef) {
+		for _, ocp := range otherPatches {
+			if cp["applyTo"] == ocp["applyTo"] && reflect.DeepEqual(cp["match"], ocp["match"]) {
+				return i, true
+			}
+		}
+	}
+	return -1, false
+}
+
+func getPriority(ef kubernetes.IstioObject) int {
+	if priority, found := ef.GetSpec()["priority"]; found {
+		return toInt(priority)
+	}
+	return 0
+}
@@ -0,0 +1,61 @@
+package envoyfilters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestSamePriorityConflict(t *testing.T) {
+	assert := assert.New(t)
+
+	first := selectorFilter("first", "reviews")
+	second := data.AddSelectorToEnvoyFilter(map[string]interface{}{"app": "reviews"}, data.CreateEnvoyFilter("second", "bookinfo"))
+	listenerPatch(second, "0.0.0.0_8080")
+	listenerPatch(second, "virtualInbound")
+
+	validations := MultiMatchChecker{EnvoyFilters: []kubernetes.IstioObject{first, second}}.Check()
+	assert.Len(validations, 2)
+
+	validation := validations[models.BuildKey(EnvoyFilterCheckerType, "first", "bookinfo")]
+	assert.NotNil(validation)
+	assert.True(validation.Valid)
+	assert.Len(validation.Checks, 1)
+	assert.Equal(models.CheckMessage("envoyfilter.multimatch.priority"), validation.Checks[0].Message)
+	assert.Equal("spec/configPatches[0]", validation.Checks[0].Path)
+	assert.Equal([]models.IstioValidationKey{models.BuildKey(EnvoyFilterCheckerType, "second", "bookinfo")}, validation.References)
+
+	validation = validations[models.BuildKey(EnvoyFilterCheckerType, "second", "bookinfo")]
+	assert.NotNil(validation)
+	assert.Equal("spec/configPatches[1]", validation.Checks[0].Path)
+}
+
+func TestNoPriorityConflict(t *testing.T) {
+	assert := assert.New(t)
+
+	// different priority
+	prioritized := selectorFilter("prioritized", "reviews")
+	prioritized.GetSpec()["priority"] = 10
+	validations := MultiMatchChecker{EnvoyFilters: []kubernetes.IstioObject{selectorFilter("first", "reviews"), prioritized}}.Check()
+	assert.Empty(validations)
+
+	// different workloads
+	validations = MultiMatchChecker{EnvoyFilters: []kubernetes.IstioObject{selectorFilter("first", "reviews"), selectorFilter("second", "ratings")}}.Check()
+	assert.Empty(validations)
+
+	// different config patched
+	other := data.AddSelectorToEnvoyFilter(map[string]interface{}{"app": "reviews"}, data.CreateEnvoyFilter("other", "bookinfo"))
+	listenerPatch(other, "0.0.0.0_9080")
+	validations = MultiMatchChecker{EnvoyFilters: []kubernetes.IstioObject{selectorFilter("first", "reviews"), other}}.Check()
+	assert.Empty(validations)
+}
+
+func selectorFilter(name, app string) kubernetes.IstioObject {
+	ef := data.AddSelectorToEnvoyFilter(map[string]interface{}{"app": app}, data.CreateEnvoyFilter(name, "bookinfo"))
+	listenerPatch(ef, "virtualInbound")
+	return ef
+}
@@ -0,0 +1,222 @@
+package envoyfilters
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// builtinListeners and builtinClusters are the names Envoy gets from Istio for every proxy
+var builtinListeners = map[string]bool{
+	"virtualInbound":  true,
+	"virtualOutbound": true,
+}
+
+var builtinClusters = map[string]bool{
+	"BlackHoleCluster":              true,
+	"PassthroughCluster":            true,
+	"InboundPassthroughClusterIpv4": true,
+	"InboundPassthroughClusterIpv6": true,
+	"prometheus_stats":              true,
+	"agent":                         true,
+	"sds-grpc":                      true,
+	"xds-grpc":                      true,
+	"zipkin":                        true,
+}
+
+// PatchTargetChecker looks for the listeners and clusters matched by name in the patches of an EnvoyFilter.
+// Only the objects of the namespace are known, so hosts of other namespaces are not checked and neither are
+// the filters of the root namespace, which apply to the whole mesh.
+type PatchTargetChecker struct {
+	EnvoyFilter    kubernetes.IstioObject
+	EnvoyFilters   []kubernetes.IstioObject
+	Services       []core_v1.Service
+	ServiceEntries []kubernetes.IstioObject
+	Gateways       []kubernetes.IstioObject
+}
+
+func (ptc PatchTargetChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	namespace := ptc.EnvoyFilter.GetObjectMeta().Namespace
+	if config.IsIstioNamespace(namespace) {
+		return checks, valid
+	}
+
+	addedListeners, addedClusters := ptc.addedNames()
+	for i, cp := range getConfigPatches(ptc.EnvoyFilter) {
+		match, ok := cp["match"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if listener, ok := match["listener"].(map[string]interface{}); ok {
+			if name, ok := listener["name"].(string); ok && !addedListeners[name] && !ptc.hasListener(name) {
+				check := models.Build("envoyfilter.patch.listenernotfound", fmt.Sprintf("spec/configPatches[%d]/match/listener/name", i))
+				checks = append(checks, &check)
+			}
+		}
+
+		if cluster, ok := match["cluster"].(map[string]interface{}); ok {
+			for _, field := range []string{"name", "service"} {
+				value, ok := cluster[field].(string)
+				if !ok || addedClusters[value] || ptc.hasCluster(field, value, namespace) {
+					continue
+				}
+				check := models.Build("envoyfilter.patch.clusternotfound", fmt.Sprintf("spec/configPatches[%d]/match/cluster/%s", i, field))
+				checks = append(checks, &check)
+			}
+		}
+	}
+
+	return checks, valid
+}
+
+// addedNames returns the listeners and clusters added by the patches of the EnvoyFilters of the namespace
+func (ptc PatchTargetChecker) addedNames() (map[string]bool, map[string]bool) {
+	listeners, clusters := map[string]bool{}, map[string]bool{}
+	for _, ef := range ptc.EnvoyFilters {
+		for _, cp := range getConfigPatches(ef) {
+			patch, ok := cp["patch"].(map[string]interface{})
+			if !ok || patch["operation"] != "ADD" {
+				continue
+			}
+			value, ok := patch["value"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, ok := value["name"].(string)
+			if !ok {
+				continue
+			}
+			switch cp["applyTo"] {
+			case "LISTENER":
+				listeners[name] = true
+			case "CLUSTER":
+				clusters[name] = true
+			}
+		}
+	}
+	return listeners, clusters
+}
+
+// hasListener checks the listener names generated by Istio: <address>_<port>, where the port is exposed
+// by a Service, a ServiceEntry or a Gateway
+func (ptc PatchTargetChecker) hasListener(name string) bool {
+	if builtinListeners[name] {
+		return true
+	}
+
+	i := strings.LastIndex(name, "_")
+	if i < 0 {
+		return false
+	}
+	port, err := strconv.Atoi(name[i+1:])
+	if err != nil {
+		return false
+	}
+
+	for _, s := range ptc.Services {
+		for _, p := range s.Spec.Ports {
+			if int(p.Port) == port || p.TargetPort.IntValue() == port {
+				return true
+			}
+		}
+	}
+	for _, se := range ptc.ServiceEntries {
+		if ports, ok := se.GetSpec()["ports"].([]interface{}); ok {
+			for _, p := range ports {
+				if pm, ok := p.(map[string]interface{}); ok && toInt(pm["number"]) == port {
+					return true
+				}
+			}
+		}
+	}
+	for _, gw := range ptc.Gateways {
+		if servers, ok := gw.GetSpec()["servers"].([]interface{}); ok {
+			for _, s := range servers {
+				if sm, ok := s.(map[string]interface{}); ok {
+					if pm, ok := sm["port"].(map[string]interface{}); ok && toInt(pm["number"]) == port {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// hasCluster checks the cluster names generated by Istio: <direction>|<port>|<subset>|<host>, and the
+// service field, which is the host of the cluster
+func (ptc PatchTargetChecker) hasCluster(field, value, namespace string) bool {
+	host := value
+	if field == "name" {
+		if builtinClusters[value] {
+			return true
+		}
+		parts := strings.Split(value, "|")
+		if len(parts) != 4 {
+			return false
+		}
+		host = parts[3]
+	}
+
+	if host == "*" || strings.HasPrefix(host, "*.") {
+		return true
+	}
+
+	fqdn := kubernetes.ParseHost(host, namespace, ptc.EnvoyFilter.GetObjectMeta().ClusterName)
+	if fqdn.CompleteInput {
+		if fqdn.Namespace != namespace {
+			// Unable to validate hosts of other namespaces
+			return true
+		}
+		if kubernetes.HasMatchingServices(fqdn.Service, ptc.Services) {
+			return true
+		}
+	}
+	return kubernetes.HasMatchingServiceEntries(host, kubernetes.ServiceEntryHostnames(ptc.ServiceEntries))
+}
+
+func getConfigPatches(ef kubernetes.IstioObject) []map[string]interface{} {
+	cps, ok := ef.GetSpec()["configPatches"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	patches := make([]map[string]interface{}, 0, len(cps))
+	for _, cp := range cps {
+		patch, ok := cp.(map[string]interface{})
+		if !ok {
+			patch = map[string]interface{}{}
+		}
+		// keep the position of every patch, it is used in the check paths
+		patches = append(patches, patch)
+	}
+	return patches
+}
+
+func toInt(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case uint32:
+		return int(v)
+	case float64:
+		return int(v)
+	case string:
+		i, _ := strconv.Atoi(v)
+		return i
+	}
+	return -1
+}
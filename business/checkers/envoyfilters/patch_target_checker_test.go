@@ -0,0 +1,95 @@
+package envoyfilters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestPatchTargetsFound(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	ef := data.CreateEnvoyFilter("ef", "bookinfo")
+	listenerPatch(ef, "virtualInbound")
+	listenerPatch(ef, "0.0.0.0_9080")
+	listenerPatch(ef, "0.0.0.0_8080")
+	listenerPatch(ef, "0.0.0.0_443")
+	clusterPatch(ef, "name", "outbound|9080||reviews.bookinfo.svc.cluster.local")
+	clusterPatch(ef, "name", "outbound|9080|v1|reviews")
+	clusterPatch(ef, "name", "PassthroughCluster")
+	clusterPatch(ef, "service", "www.google.com")
+	clusterPatch(ef, "service", "ratings.other.svc.cluster.local")
+	clusterPatch(ef, "name", "added_cluster")
+	data.AddPatchToEnvoyFilter("CLUSTER", map[string]interface{}{"context": "SIDECAR_OUTBOUND"},
+		map[string]interface{}{"operation": "ADD", "value": map[string]interface{}{"name": "added_cluster"}}, ef)
+
+	checks, valid := patchTargetChecker(ef).Check()
+	assert.True(valid)
+	assert.Empty(checks)
+}
+
+func TestPatchTargetsNotFound(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	ef := data.CreateEnvoyFilter("ef", "bookinfo")
+	listenerPatch(ef, "0.0.0.0_9999")
+	clusterPatch(ef, "name", "outbound|9080||details.bookinfo.svc.cluster.local")
+	clusterPatch(ef, "service", "www.wrong.com")
+
+	checks, valid := patchTargetChecker(ef).Check()
+	assert.True(valid)
+	assert.Len(checks, 3)
+	assert.Equal(models.CheckMessage("envoyfilter.patch.listenernotfound"), checks[0].Message)
+	assert.Equal("spec/configPatches[0]/match/listener/name", checks[0].Path)
+	assert.Equal(models.CheckMessage("envoyfilter.patch.clusternotfound"), checks[1].Message)
+	assert.Equal("spec/configPatches[1]/match/cluster/name", checks[1].Path)
+	assert.Equal("spec/configPatches[2]/match/cluster/service", checks[2].Path)
+
+	// The filters of the root namespace patch proxies of every namespace, their targets are unknown
+	meta := ef.GetObjectMeta()
+	meta.Namespace = "istio-system"
+	ef.SetObjectMeta(meta)
+	checks, valid = patchTargetChecker(ef).Check()
+	assert.True(valid)
+	assert.Empty(checks)
+}
+
+func patchTargetChecker(ef kubernetes.IstioObject) PatchTargetChecker {
+	gw := data.AddServerToGateway(data.CreateServer([]string{"*"}, 443, "https", "https"), data.CreateEmptyGateway("gw", "bookinfo", nil))
+	se := data.AddPortDefinitionToServiceEntry(data.CreateEmptyPortDefinition(8080, "http", "HTTP"),
+		data.CreateEmptyMeshExternalServiceEntry("google", "bookinfo", []string{"www.google.com"}))
+	return PatchTargetChecker{
+		EnvoyFilter:  ef,
+		EnvoyFilters: []kubernetes.IstioObject{ef},
+		Services: []core_v1.Service{
+			{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"},
+				Spec: core_v1.ServiceSpec{
+					Ports: []core_v1.ServicePort{{Port: 80, TargetPort: intstr.FromInt(9080)}},
+				},
+			},
+		},
+		ServiceEntries: []kubernetes.IstioObject{se},
+		Gateways:       []kubernetes.IstioObject{gw},
+	}
+}
+
+func listenerPatch(ef kubernetes.IstioObject, name string) {
+	data.AddPatchToEnvoyFilter("LISTENER", map[string]interface{}{"listener": map[string]interface{}{"name": name}},
+		map[string]interface{}{"operation": "MERGE"}, ef)
+}
+
+func clusterPatch(ef kubernetes.IstioObject, field, value string) {
+	data.AddPatchToEnvoyFilter("CLUSTER", map[string]interface{}{"cluster": map[string]interface{}{field: value}},
+		map[string]interface{}{"operation": "MERGE"}, ef)
+}
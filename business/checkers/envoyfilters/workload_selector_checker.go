@@ -0,0 +1,65 @@
+package envoyfilters
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type WorkloadSelectorChecker struct {
+	EnvoyFilter  kubernetes.IstioObject
+	WorkloadList models.WorkloadList
+}
+
+func (wsc WorkloadSelectorChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	// EnvoyFilters of the root namespace are applied to the workloads of every namespace
+	if config.IsIstioNamespace(wsc.EnvoyFilter.GetObjectMeta().Namespace) {
+		return checks, valid
+	}
+
+	labelSelectors := getWorkloadSelectorLabels(wsc.EnvoyFilter)
+	if len(labelSelectors) == 0 {
+		return checks, valid
+	}
+
+	if !wsc.hasMatchingWorkload(labelSelectors) {
+		check := models.Build("envoyfilter.selector.workloadnotfound", "spec/workloadSelector/labels")
+		checks = append(checks, &check)
+	}
+	return checks, valid
+}
+
+func (wsc WorkloadSelectorChecker) hasMatchingWorkload(labelSelector map[string]string) bool {
+	selector := labels.SelectorFromSet(labels.Set(labelSelector))
+
+	for _, wl := range wsc.WorkloadList.Workloads {
+		if selector.Matches(labels.Set(wl.Labels)) {
+			return true
+		}
+	}
+	return false
+}
+
+func getWorkloadSelectorLabels(ef kubernetes.IstioObject) map[string]string {
+	ws, ok := ef.GetSpec()["workloadSelector"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	selectors, ok := ws["labels"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	labelSelectors := make(map[string]string, len(selectors))
+	for k, v := range selectors {
+		if s, ok := v.(string); ok {
+			labelSelectors[k] = s
+		}
+	}
+	return labelSelectors
+}
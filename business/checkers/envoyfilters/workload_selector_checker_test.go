@@ -0,0 +1,61 @@
+package envoyfilters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestEnvoyFilterPresentWorkloads(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	checks, valid := WorkloadSelectorChecker{
+		WorkloadList: workloadList(),
+		EnvoyFilter:  data.AddSelectorToEnvoyFilter(map[string]interface{}{"app": "details", "version": "v1"}, data.CreateEnvoyFilter("ef", "bookinfo")),
+	}.Check()
+	assert.True(valid)
+	assert.Empty(checks)
+
+	// Without selector the filter applies to every workload of the namespace
+	checks, valid = WorkloadSelectorChecker{
+		WorkloadList: workloadList(),
+		EnvoyFilter:  data.CreateEnvoyFilter("ef", "bookinfo"),
+	}.Check()
+	assert.True(valid)
+	assert.Empty(checks)
+
+	// Filters of the root namespace select workloads of every namespace
+	checks, valid = WorkloadSelectorChecker{
+		WorkloadList: workloadList(),
+		EnvoyFilter:  data.AddSelectorToEnvoyFilter(map[string]interface{}{"app": "wrong"}, data.CreateEnvoyFilter("ef", "istio-system")),
+	}.Check()
+	assert.True(valid)
+	assert.Empty(checks)
+}
+
+func TestEnvoyFilterWorkloadNotFound(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	checks, valid := WorkloadSelectorChecker{
+		WorkloadList: workloadList(),
+		EnvoyFilter:  data.AddSelectorToEnvoyFilter(map[string]interface{}{"app": "details", "version": "wrong"}, data.CreateEnvoyFilter("ef", "bookinfo")),
+	}.Check()
+	assert.True(valid)
+	assert.Len(checks, 1)
+	assert.Equal(models.WarningSeverity, checks[0].Severity)
+	assert.Equal(models.CheckMessage("envoyfilter.selector.workloadnotfound"), checks[0].Message)
+	assert.Equal("spec/workloadSelector/labels", checks[0].Path)
+}
+
+func workloadList() models.WorkloadList {
+	return data.CreateWorkloadList("bookinfo",
+		data.CreateWorkloadListItem("details-v1", map[string]string{"app": "details", "version": "v1"}),
+		data.CreateWorkloadListItem("details-v2", map[string]string{"app": "details", "version": "v2"}),
+	)
+}
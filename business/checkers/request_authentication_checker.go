@@ -0,0 +1,47 @@
+package checkers
+
+import (
+	"github.com/kiali/kiali/business/checkers/requestauthentications"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const RequestAuthenticationCheckerType = "requestauthentication"
+
+type RequestAuthenticationChecker struct {
+	RequestAuthentications []kubernetes.IstioObject
+	WorkloadList           models.WorkloadList
+	// JwksClient fetches the jwksUri of the JWT rules, they are not fetched when nil
+	JwksClient requestauthentications.HTTPClient
+}
+
+func (r RequestAuthenticationChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	validations.MergeValidations(requestauthentications.MultiMatchChecker{RequestAuthentications: r.RequestAuthentications, WorkloadList: r.WorkloadList}.Check())
+
+	for _, requestAuthn := range r.RequestAuthentications {
+		validations.MergeValidations(r.runChecks(requestAuthn))
+	}
+
+	return validations
+}
+
+// runChecks runs all the individual checks for a single request authentication and appends the result into validations.
+func (r RequestAuthenticationChecker) runChecks(requestAuthn kubernetes.IstioObject) models.IstioValidations {
+	requestAuthnName := requestAuthn.GetObjectMeta().Name
+	key, rrValidation := EmptyValidValidation(requestAuthnName, requestAuthn.GetObjectMeta().Namespace, RequestAuthenticationCheckerType)
+
+	enabledCheckers := []Checker{
+		requestauthentications.WorkloadSelectorChecker{RequestAuthentication: requestAuthn, WorkloadList: r.WorkloadList},
+		requestauthentications.JwksUriChecker{RequestAuthentication: requestAuthn, Client: r.JwksClient},
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		rrValidation.Checks = append(rrValidation.Checks, checks...)
+		rrValidation.Valid = rrValidation.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: rrValidation}
+}
@@ -0,0 +1,134 @@
+package requestauthentications
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// HTTPClient fetches the JSON Web Key Sets. It is pluggable so the checks run offline in tests.
+type HTTPClient interface {
+	Get(url string) (*http.Response, error)
+}
+
+// jwksTTL is how long the result of a fetch is kept, the validations run on every request of the UI
+const jwksTTL = 5 * time.Minute
+
+var jwksCache = struct {
+	sync.Mutex
+	results map[string]*jwksResult
+}{results: map[string]*jwksResult{}}
+
+// jwksResult is the result of a fetch of a jwksUri, available once done is closed
+type jwksResult struct {
+	done    chan struct{}
+	err     error
+	fetched time.Time
+}
+
+// JwksUriChecker checks the jwksUri of the JWT rules are valid HTTPS URLs. When a client is given, it also checks
+// a JSON Web Key Set can be fetched from them.
+type JwksUriChecker struct {
+	RequestAuthentication kubernetes.IstioObject
+	Client                HTTPClient
+}
+
+func (jc JwksUriChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	rules, ok := jc.RequestAuthentication.GetSpec()["jwtRules"].([]interface{})
+	if !ok {
+		return checks, valid
+	}
+	for i, r := range rules {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		jwksUri, ok := rule["jwksUri"].(string)
+		if !ok {
+			continue
+		}
+		path := fmt.Sprintf("spec/jwtRules[%d]/jwksUri", i)
+
+		u, err := url.Parse(jwksUri)
+		if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+			check := models.Build("requestauthentication.jwks.invaliduri", path)
+			checks = append(checks, &check)
+			valid = false
+			continue
+		}
+		if u.Scheme != "https" {
+			check := models.Build("requestauthentication.jwks.nothttps", path)
+			checks = append(checks, &check)
+		}
+		if jc.Client != nil {
+			if err := fetchJwks(jc.Client, jwksUri); err != nil {
+				check := models.Build("requestauthentication.jwks.unreachable", path)
+				checks = append(checks, &check)
+			}
+		}
+	}
+	return checks, valid
+}
+
+// fetchJwks returns an error when the URL does not serve a JSON Web Key Set with keys. The cache is not locked
+// during the fetch, the concurrent checks of the same URL wait for the fetch in progress.
+func fetchJwks(client HTTPClient, jwksUri string) error {
+	jwksCache.Lock()
+	result, found := jwksCache.results[jwksUri]
+	if found {
+		select {
+		case <-result.done:
+			if time.Since(result.fetched) >= jwksTTL {
+				found = false
+			}
+		default:
+		}
+	}
+	if found {
+		jwksCache.Unlock()
+		<-result.done
+		return result.err
+	}
+	result = &jwksResult{done: make(chan struct{})}
+	jwksCache.results[jwksUri] = result
+	jwksCache.Unlock()
+
+	result.err = getJwks(client, jwksUri)
+	result.fetched = time.Now()
+	close(result.done)
+	return result.err
+}
+
+func getJwks(client HTTPClient, jwksUri string) error {
+	resp, err := client.Get(jwksUri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("jwksUri responded %s", resp.Status)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	jwks := struct {
+		Keys []interface{} `json:"keys"`
+	}{}
+	if err := json.Unmarshal(body, &jwks); err != nil {
+		return err
+	}
+	if len(jwks.Keys) == 0 {
+		return fmt.Errorf("jwksUri has no keys")
+	}
+	return nil
+}
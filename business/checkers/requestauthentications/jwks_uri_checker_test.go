@@ -0,0 +1,163 @@
+package requestauthentications
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+type fakeJwksClient struct {
+	responses map[string]string
+	calls     int
+}
+
+func (f *fakeJwksClient) Get(url string) (*http.Response, error) {
+	f.calls++
+	body, found := f.responses[url]
+	if !found {
+		return nil, fmt.Errorf("connection refused")
+	}
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}, nil
+}
+
+func TestJwksUriValid(t *testing.T) {
+	assert := assert.New(t)
+
+	validations, valid := JwksUriChecker{
+		RequestAuthentication: data.AddJwtRuleToRequestAuthentication("issuer.example.com", "https://issuer.example.com/jwks.json",
+			data.CreateRequestAuthentication("jwt", "bookinfo")),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(validations)
+}
+
+func TestJwksUriInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, uri := range []string{"issuer.example.com/jwks.json", "ftp://issuer.example.com/jwks.json", "https://", "https://issuer example.com"} {
+		validations, valid := JwksUriChecker{
+			RequestAuthentication: data.AddJwtRuleToRequestAuthentication("issuer.example.com", uri,
+				data.CreateRequestAuthentication("jwt", "bookinfo")),
+		}.Check()
+
+		assert.False(valid, uri)
+		assert.Len(validations, 1)
+		assert.Equal(models.ErrorSeverity, validations[0].Severity)
+		assert.Equal(models.CheckMessage("requestauthentication.jwks.invaliduri"), validations[0].Message)
+		assert.Equal("spec/jwtRules[0]/jwksUri", validations[0].Path)
+	}
+}
+
+func TestJwksUriNotHTTPS(t *testing.T) {
+	assert := assert.New(t)
+
+	ra := data.AddJwtRuleToRequestAuthentication("issuer.example.com", "https://issuer.example.com/jwks.json",
+		data.CreateRequestAuthentication("jwt", "bookinfo"))
+	ra = data.AddJwtRuleToRequestAuthentication("other.example.com", "http://other.example.com/jwks.json", ra)
+	validations, valid := JwksUriChecker{RequestAuthentication: ra}.Check()
+
+	assert.True(valid)
+	assert.Len(validations, 1)
+	assert.Equal(models.WarningSeverity, validations[0].Severity)
+	assert.Equal(models.CheckMessage("requestauthentication.jwks.nothttps"), validations[0].Message)
+	assert.Equal("spec/jwtRules[1]/jwksUri", validations[0].Path)
+}
+
+func TestJwksUriFetched(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &fakeJwksClient{responses: map[string]string{
+		"https://keys.example.com/jwks.json":  `{"keys":[{"kty":"RSA","kid":"1"}]}`,
+		"https://empty.example.com/jwks.json": `{"keys":[]}`,
+		"https://html.example.com/jwks.json":  `<html></html>`,
+	}}
+	ra := data.AddJwtRuleToRequestAuthentication("keys.example.com", "https://keys.example.com/jwks.json",
+		data.CreateRequestAuthentication("jwt", "bookinfo"))
+	ra = data.AddJwtRuleToRequestAuthentication("empty.example.com", "https://empty.example.com/jwks.json", ra)
+	ra = data.AddJwtRuleToRequestAuthentication("html.example.com", "https://html.example.com/jwks.json", ra)
+	ra = data.AddJwtRuleToRequestAuthentication("down.example.com", "https://down.example.com/jwks.json", ra)
+
+	validations, valid := JwksUriChecker{RequestAuthentication: ra, Client: client}.Check()
+
+	assert.True(valid)
+	assert.Len(validations, 3)
+	for i, check := range validations {
+		assert.Equal(models.CheckMessage("requestauthentication.jwks.unreachable"), check.Message)
+		assert.Equal(fmt.Sprintf("spec/jwtRules[%d]/jwksUri", i+1), check.Path)
+	}
+	assert.Equal(4, client.calls)
+
+	// The results are cached
+	_, _ = JwksUriChecker{RequestAuthentication: ra, Client: client}.Check()
+	assert.Equal(4, client.calls)
+}
+
+// slowJwksClient serves a JSON Web Key Set, blocking the requests of the slow URL until released
+type slowJwksClient struct {
+	slow     string
+	started  chan struct{}
+	released chan struct{}
+	calls    int32
+}
+
+func (f *slowJwksClient) Get(url string) (*http.Response, error) {
+	atomic.AddInt32(&f.calls, 1)
+	if url == f.slow {
+		close(f.started)
+		<-f.released
+	}
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"keys": [{"kty": "RSA"}]}`)),
+	}, nil
+}
+
+func TestJwksFetchDoesNotBlockOtherUris(t *testing.T) {
+	assert := assert.New(t)
+
+	jwksCache.Lock()
+	jwksCache.results = map[string]*jwksResult{}
+	jwksCache.Unlock()
+
+	client := &slowJwksClient{slow: "https://slow.example.com/jwks.json", started: make(chan struct{}), released: make(chan struct{})}
+	slowErrs := make(chan error, 2)
+	go func() {
+		slowErrs <- fetchJwks(client, client.slow)
+	}()
+	<-client.started
+	go func() {
+		slowErrs <- fetchJwks(client, client.slow)
+	}()
+
+	fast := make(chan error)
+	go func() {
+		fast <- fetchJwks(client, "https://fast.example.com/jwks.json")
+	}()
+	select {
+	case err := <-fast:
+		assert.NoError(err)
+	case <-time.After(time.Second):
+		assert.Fail("the fetch of a jwksUri is blocked by the fetch of another one")
+	}
+
+	close(client.released)
+	assert.NoError(<-slowErrs)
+	assert.NoError(<-slowErrs)
+	// the concurrent checks of the slow URL share the same fetch
+	assert.Equal(int32(2), atomic.LoadInt32(&client.calls))
+}
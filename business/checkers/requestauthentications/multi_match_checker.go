@@ -0,0 +1,77 @@
+package requestauthentications
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const RequestAuthenticationCheckerType = "requestauthentication"
+
+// MultiMatchChecker flags the RequestAuthentications applying to the same workload. Only the RequestAuthentications
+// of the same level are compared: the ones without selector, and the ones with a selector matching a workload.
+type MultiMatchChecker struct {
+	RequestAuthentications []kubernetes.IstioObject
+	WorkloadList           models.WorkloadList
+}
+
+func (m MultiMatchChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	selectorLess := make([]models.IstioValidationKey, 0)
+	byWorkload := map[string][]models.IstioValidationKey{}
+	for _, ra := range m.RequestAuthentications {
+		key := models.BuildKey(RequestAuthenticationCheckerType, ra.GetObjectMeta().Name, ra.GetObjectMeta().Namespace)
+		selector := SelectorLabels(ra)
+		if len(selector) == 0 {
+			selectorLess = append(selectorLess, key)
+			continue
+		}
+		labelSelector := labels.SelectorFromSet(labels.Set(selector))
+		for _, w := range m.WorkloadList.Workloads {
+			if labelSelector.Matches(labels.Set(w.Labels)) {
+				byWorkload[w.Name] = append(byWorkload[w.Name], key)
+			}
+		}
+	}
+
+	validations.MergeValidations(buildMultiMatchValidations(selectorLess, "spec"))
+	workloads := make([]string, 0, len(byWorkload))
+	for w := range byWorkload {
+		workloads = append(workloads, w)
+	}
+	sort.Strings(workloads)
+	for _, w := range workloads {
+		validations.MergeValidations(buildMultiMatchValidations(byWorkload[w], "spec/selector"))
+	}
+
+	return validations
+}
+
+func buildMultiMatchValidations(keys []models.IstioValidationKey, path string) models.IstioValidations {
+	validations := models.IstioValidations{}
+	if len(keys) < 2 {
+		return validations
+	}
+
+	for i, key := range keys {
+		refs := make([]models.IstioValidationKey, 0, len(keys)-1)
+		refs = append(refs, keys[:i]...)
+		refs = append(refs, keys[i+1:]...)
+
+		check := models.Build("requestauthentication.multimatch", path)
+		validations.MergeValidations(models.IstioValidations{
+			key: &models.IstioValidation{
+				Name:       key.Name,
+				ObjectType: RequestAuthenticationCheckerType,
+				Valid:      true,
+				References: refs,
+				Checks:     []*models.IstioCheck{&check},
+			},
+		})
+	}
+	return validations
+}
@@ -0,0 +1,72 @@
+package requestauthentications
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestMultiMatchNoOverlap(t *testing.T) {
+	assert := assert.New(t)
+
+	validations := MultiMatchChecker{
+		RequestAuthentications: []kubernetes.IstioObject{
+			data.CreateRequestAuthentication("namespace-jwt", "bookinfo"),
+			data.AddSelectorToRequestAuthentication(map[string]interface{}{"app": "details"},
+				data.CreateRequestAuthentication("details-jwt", "bookinfo")),
+			data.AddSelectorToRequestAuthentication(map[string]interface{}{"app": "reviews"},
+				data.CreateRequestAuthentication("reviews-jwt", "bookinfo")),
+		},
+		WorkloadList: workloadList(),
+	}.Check()
+
+	assert.Empty(validations)
+}
+
+func TestMultiMatchNamespaceWide(t *testing.T) {
+	assert := assert.New(t)
+
+	validations := MultiMatchChecker{
+		RequestAuthentications: []kubernetes.IstioObject{
+			data.CreateRequestAuthentication("jwt-1", "bookinfo"),
+			data.CreateRequestAuthentication("jwt-2", "bookinfo"),
+		},
+		WorkloadList: workloadList(),
+	}.Check()
+
+	assert.Len(validations, 2)
+	for _, name := range []string{"jwt-1", "jwt-2"} {
+		validation, ok := validations[models.BuildKey(RequestAuthenticationCheckerType, name, "bookinfo")]
+		assert.True(ok)
+		assert.True(validation.Valid)
+		assert.Len(validation.Checks, 1)
+		assert.Equal(models.CheckMessage("requestauthentication.multimatch"), validation.Checks[0].Message)
+		assert.Equal("spec", validation.Checks[0].Path)
+		assert.Len(validation.References, 1)
+	}
+}
+
+func TestMultiMatchSameWorkload(t *testing.T) {
+	assert := assert.New(t)
+
+	validations := MultiMatchChecker{
+		RequestAuthentications: []kubernetes.IstioObject{
+			data.AddSelectorToRequestAuthentication(map[string]interface{}{"app": "reviews"},
+				data.CreateRequestAuthentication("reviews-jwt", "bookinfo")),
+			data.AddSelectorToRequestAuthentication(map[string]interface{}{"app": "reviews", "version": "v2"},
+				data.CreateRequestAuthentication("reviews-v2-jwt", "bookinfo")),
+		},
+		WorkloadList: workloadList(),
+	}.Check()
+
+	assert.Len(validations, 2)
+	validation := validations[models.BuildKey(RequestAuthenticationCheckerType, "reviews-jwt", "bookinfo")]
+	assert.NotNil(validation)
+	assert.Len(validation.Checks, 1)
+	assert.Equal("spec/selector", validation.Checks[0].Path)
+	assert.Equal([]models.IstioValidationKey{models.BuildKey(RequestAuthenticationCheckerType, "reviews-v2-jwt", "bookinfo")}, validation.References)
+}
@@ -0,0 +1,54 @@
+package requestauthentications
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type WorkloadSelectorChecker struct {
+	RequestAuthentication kubernetes.IstioObject
+	WorkloadList          models.WorkloadList
+}
+
+func (wsc WorkloadSelectorChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+	selector := SelectorLabels(wsc.RequestAuthentication)
+	if len(selector) == 0 {
+		return checks, valid
+	}
+
+	labelSelector := labels.SelectorFromSet(labels.Set(selector))
+	for _, wl := range wsc.WorkloadList.Workloads {
+		if labelSelector.Matches(labels.Set(wl.Labels)) {
+			return checks, valid
+		}
+	}
+
+	check := models.Build("requestauthentication.selector.workloadnotfound", "spec/selector")
+	checks = append(checks, &check)
+	return checks, valid
+}
+
+// SelectorLabels returns the labels of the selector of a RequestAuthentication, empty when it applies to the
+// whole namespace
+func SelectorLabels(ra kubernetes.IstioObject) map[string]string {
+	result := map[string]string{}
+	if selector, ok := ra.GetSpec()["selector"].(map[string]interface{}); ok {
+		if matchLabels, ok := selector["matchLabels"].(map[string]interface{}); ok {
+			for k, v := range matchLabels {
+				if s, ok := v.(string); ok {
+					result[k] = s
+				}
+			}
+		}
+	}
+	return result
+}
+
+// Applies returns whether a RequestAuthentication applies to a workload with the given labels
+func Applies(ra kubernetes.IstioObject, workloadLabels map[string]string) bool {
+	selector := SelectorLabels(ra)
+	return len(selector) == 0 || labels.SelectorFromSet(labels.Set(selector)).Matches(labels.Set(workloadLabels))
+}
@@ -0,0 +1,59 @@
+package requestauthentications
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestWorkloadSelectorPresentWorkload(t *testing.T) {
+	assert := assert.New(t)
+
+	validations, valid := WorkloadSelectorChecker{
+		RequestAuthentication: data.AddSelectorToRequestAuthentication(map[string]interface{}{"app": "details"},
+			data.CreateRequestAuthentication("jwt", "bookinfo")),
+		WorkloadList: workloadList(),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(validations)
+}
+
+func TestWorkloadSelectorWithoutSelector(t *testing.T) {
+	assert := assert.New(t)
+
+	validations, valid := WorkloadSelectorChecker{
+		RequestAuthentication: data.CreateRequestAuthentication("jwt", "bookinfo"),
+		WorkloadList:          data.CreateWorkloadList("bookinfo"),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(validations)
+}
+
+func TestWorkloadSelectorWorkloadNotFound(t *testing.T) {
+	assert := assert.New(t)
+
+	validations, valid := WorkloadSelectorChecker{
+		RequestAuthentication: data.AddSelectorToRequestAuthentication(map[string]interface{}{"app": "wrong"},
+			data.CreateRequestAuthentication("jwt", "bookinfo")),
+		WorkloadList: workloadList(),
+	}.Check()
+
+	assert.True(valid)
+	assert.Len(validations, 1)
+	assert.Equal(models.WarningSeverity, validations[0].Severity)
+	assert.Equal(models.CheckMessage("requestauthentication.selector.workloadnotfound"), validations[0].Message)
+	assert.Equal("spec/selector", validations[0].Path)
+}
+
+func workloadList() models.WorkloadList {
+	return data.CreateWorkloadList("bookinfo",
+		data.CreateWorkloadListItem("details-v1", map[string]string{"app": "details", "version": "v1"}),
+		data.CreateWorkloadListItem("reviews-v1", map[string]string{"app": "reviews", "version": "v1"}),
+		data.CreateWorkloadListItem("reviews-v2", map[string]string{"app": "reviews", "version": "v2"}),
+	)
+}
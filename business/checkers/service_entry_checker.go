@@ -1,6 +1,8 @@
 package checkers
 
 import (
+	"github.com/kiali/kiali/business/checkers/serviceentries"
+	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/models"
 )
@@ -9,6 +11,8 @@ const ServiceEntryCheckerType = "serviceentry"
 
 type ServiceEntryChecker struct {
 	ServiceEntries []kubernetes.IstioObject
+	Clusters       []config.RemoteCluster
+	RemoteServices serviceentries.RemoteServices
 }
 
 func (s ServiceEntryChecker) Check() models.IstioValidations {
@@ -24,7 +28,9 @@ func (s ServiceEntryChecker) Check() models.IstioValidations {
 func (s ServiceEntryChecker) runSingleChecks(se kubernetes.IstioObject) models.IstioValidations {
 	key, validations := EmptyValidValidation(se.GetObjectMeta().Name, se.GetObjectMeta().Namespace, ServiceEntryCheckerType)
 
-	enabledCheckers := []Checker{}
+	enabledCheckers := []Checker{
+		serviceentries.GlobalChecker{ServiceEntry: se, Clusters: s.Clusters, RemoteServices: s.RemoteServices},
+	}
 
 	for _, checker := range enabledCheckers {
 		checks, validChecker := checker.Check()
@@ -0,0 +1,194 @@
+package serviceentries
+
+import (
+	"fmt"
+	"strings"
+
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// RemoteServices are the Services of the remote clusters, by cluster name and namespace.
+// The Services of a cluster missing from the map are unknown and they are not checked.
+type RemoteServices map[string]map[string][]core_v1.Service
+
+// GlobalHost is a svc.ns.global host of a ServiceEntry
+type GlobalHost struct {
+	Index     int
+	Service   string
+	Namespace string
+	Valid     bool
+}
+
+// GlobalChecker validates the ServiceEntries of the multi-cluster mesh: their .global hosts, the gateways
+// of their endpoints and the Services they point to in the remote clusters
+type GlobalChecker struct {
+	ServiceEntry   kubernetes.IstioObject
+	Clusters       []config.RemoteCluster
+	RemoteServices RemoteServices
+}
+
+func (g GlobalChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	hosts := GlobalHosts(g.ServiceEntry)
+	if len(hosts) == 0 {
+		return checks, valid
+	}
+
+	for _, h := range hosts {
+		if !h.Valid {
+			check := models.Build("serviceentry.global.invalidhost", fmt.Sprintf("spec/hosts[%d]", h.Index))
+			checks = append(checks, &check)
+			valid = false
+		}
+	}
+
+	// Without clusters configured the gateways and the remote services are unknown
+	if len(g.Clusters) == 0 {
+		return checks, valid
+	}
+
+	// Several endpoints can be gateways of the same cluster, its services are checked once
+	clusters := make([]config.RemoteCluster, 0, len(g.Clusters))
+	seen := map[string]bool{}
+	for i, address := range endpointAddresses(g.ServiceEntry) {
+		cluster, found := clusterOfGateway(address, g.Clusters)
+		if !found {
+			check := models.Build("serviceentry.global.unknowngateway", fmt.Sprintf("spec/endpoints[%d]/address", i))
+			checks = append(checks, &check)
+			continue
+		}
+		if !seen[cluster.Name] {
+			seen[cluster.Name] = true
+			clusters = append(clusters, cluster)
+		}
+	}
+
+	for _, cluster := range clusters {
+		services, known := g.RemoteServices[cluster.Name]
+		if !known {
+			continue
+		}
+		for _, h := range hosts {
+			if !h.Valid {
+				continue
+			}
+			svc, found := findService(h.Service, services[h.Namespace])
+			if !found {
+				check := models.Build("serviceentry.global.servicenotfound", fmt.Sprintf("spec/hosts[%d]", h.Index))
+				checks = append(checks, &check)
+				valid = false
+				continue
+			}
+			checks = append(checks, g.checkPorts(svc)...)
+		}
+	}
+
+	return checks, valid
+}
+
+func (g GlobalChecker) checkPorts(svc core_v1.Service) []*models.IstioCheck {
+	checks := make([]*models.IstioCheck, 0)
+	ports, ok := g.ServiceEntry.GetSpec()["ports"].([]interface{})
+	if !ok {
+		return checks
+	}
+
+	for i, p := range ports {
+		portDef, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		number := fmt.Sprintf("%v", portDef["number"])
+		found := false
+		for _, sp := range svc.Spec.Ports {
+			if fmt.Sprintf("%d", sp.Port) == number {
+				found = true
+				break
+			}
+		}
+		if !found {
+			check := models.Build("serviceentry.global.portnotfound", fmt.Sprintf("spec/ports[%d]/number", i))
+			checks = append(checks, &check)
+		}
+	}
+	return checks
+}
+
+// GlobalHosts returns the .global hosts of a ServiceEntry. Hosts not following svc.ns.global are returned as not valid.
+func GlobalHosts(se kubernetes.IstioObject) []GlobalHost {
+	hosts := make([]GlobalHost, 0)
+	hostList, ok := se.GetSpec()["hosts"].([]interface{})
+	if !ok {
+		return hosts
+	}
+
+	suffix := "." + config.IstioMultiClusterHostSuffix
+	for i, h := range hostList {
+		host, ok := h.(string)
+		if !ok || !strings.HasSuffix(host, suffix) {
+			continue
+		}
+		parts := strings.Split(host, ".")
+		gh := GlobalHost{Index: i}
+		if len(parts) == 3 && parts[0] != "" && parts[1] != "" {
+			gh.Service, gh.Namespace, gh.Valid = parts[0], parts[1], true
+		}
+		hosts = append(hosts, gh)
+	}
+	return hosts
+}
+
+// TargetClusters returns the clusters whose gateway is an endpoint of the ServiceEntry, each cluster once
+func TargetClusters(se kubernetes.IstioObject, clusters []config.RemoteCluster) []config.RemoteCluster {
+	targets := make([]config.RemoteCluster, 0)
+	seen := map[string]bool{}
+	for _, address := range endpointAddresses(se) {
+		if cluster, found := clusterOfGateway(address, clusters); found && !seen[cluster.Name] {
+			seen[cluster.Name] = true
+			targets = append(targets, cluster)
+		}
+	}
+	return targets
+}
+
+func endpointAddresses(se kubernetes.IstioObject) []string {
+	addresses := make([]string, 0)
+	endpoints, ok := se.GetSpec()["endpoints"].([]interface{})
+	if !ok {
+		return addresses
+	}
+	for _, e := range endpoints {
+		address := ""
+		if endpoint, ok := e.(map[string]interface{}); ok {
+			address, _ = endpoint["address"].(string)
+		}
+		// keep the position of every endpoint, it is used in the check paths
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+func clusterOfGateway(address string, clusters []config.RemoteCluster) (config.RemoteCluster, bool) {
+	for _, c := range clusters {
+		for _, gw := range c.GatewayAddresses {
+			if gw == address {
+				return c, true
+			}
+		}
+	}
+	return config.RemoteCluster{}, false
+}
+
+func findService(name string, services []core_v1.Service) (core_v1.Service, bool) {
+	for _, s := range services {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return core_v1.Service{}, false
+}
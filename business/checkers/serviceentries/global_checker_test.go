@@ -0,0 +1,127 @@
+package serviceentries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestValidGlobalServiceEntry(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	checks, valid := GlobalChecker{
+		ServiceEntry:   globalServiceEntry([]string{"reviews.bookinfo.global"}, []string{"10.0.0.1"}),
+		Clusters:       clusters(),
+		RemoteServices: remoteServices(),
+	}.Check()
+	assert.True(valid)
+	assert.Empty(checks)
+
+	// Hosts other than .global are not checked
+	checks, valid = GlobalChecker{
+		ServiceEntry: globalServiceEntry([]string{"www.google.com"}, []string{"8.8.8.8"}),
+		Clusters:     clusters(),
+	}.Check()
+	assert.True(valid)
+	assert.Empty(checks)
+
+	// Services of clusters without kubeconfig are unknown
+	checks, valid = GlobalChecker{
+		ServiceEntry: globalServiceEntry([]string{"details.bookinfo.global"}, []string{"10.0.0.2"}),
+		Clusters:     clusters(),
+	}.Check()
+	assert.True(valid)
+	assert.Empty(checks)
+}
+
+func TestInvalidGlobalHost(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	checks, valid := GlobalChecker{
+		ServiceEntry: globalServiceEntry([]string{"reviews.global", "reviews.bookinfo.svc.global"}, []string{"10.0.0.1"}),
+	}.Check()
+	assert.False(valid)
+	assert.Len(checks, 2)
+	assert.Equal(models.CheckMessage("serviceentry.global.invalidhost"), checks[0].Message)
+	assert.Equal(models.ErrorSeverity, checks[0].Severity)
+	assert.Equal("spec/hosts[0]", checks[0].Path)
+	assert.Equal("spec/hosts[1]", checks[1].Path)
+}
+
+func TestGlobalRemoteChecks(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	checks, valid := GlobalChecker{
+		ServiceEntry:   globalServiceEntry([]string{"details.bookinfo.global"}, []string{"10.0.0.9", "10.0.0.1"}),
+		Clusters:       clusters(),
+		RemoteServices: remoteServices(),
+	}.Check()
+	assert.False(valid)
+	assert.Len(checks, 2)
+	assert.Equal(models.CheckMessage("serviceentry.global.unknowngateway"), checks[0].Message)
+	assert.Equal(models.WarningSeverity, checks[0].Severity)
+	assert.Equal("spec/endpoints[0]/address", checks[0].Path)
+	assert.Equal(models.CheckMessage("serviceentry.global.servicenotfound"), checks[1].Message)
+	assert.Equal("spec/hosts[0]", checks[1].Path)
+
+	se := data.AddPortDefinitionToServiceEntry(data.CreateEmptyPortDefinition(9999, "http", "HTTP"),
+		globalServiceEntry([]string{"reviews.bookinfo.global"}, []string{"10.0.0.1"}))
+	checks, valid = GlobalChecker{ServiceEntry: se, Clusters: clusters(), RemoteServices: remoteServices()}.Check()
+	assert.True(valid)
+	assert.Len(checks, 1)
+	assert.Equal(models.CheckMessage("serviceentry.global.portnotfound"), checks[0].Message)
+	assert.Equal("spec/ports[1]/number", checks[0].Path)
+}
+
+func TestGlobalChecksOncePerCluster(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	// both endpoints are gateways of the east cluster
+	multiGateway := []config.RemoteCluster{{Name: "east", GatewayAddresses: []string{"10.0.0.1", "10.0.0.3"}}}
+	se := globalServiceEntry([]string{"details.bookinfo.global"}, []string{"10.0.0.1", "10.0.0.3"})
+	checks, valid := GlobalChecker{ServiceEntry: se, Clusters: multiGateway, RemoteServices: remoteServices()}.Check()
+	assert.False(valid)
+	assert.Len(checks, 1)
+	assert.Equal(models.CheckMessage("serviceentry.global.servicenotfound"), checks[0].Message)
+
+	targets := TargetClusters(se, multiGateway)
+	assert.Len(targets, 1)
+	assert.Equal("east", targets[0].Name)
+}
+
+func globalServiceEntry(hosts, endpoints []string) kubernetes.IstioObject {
+	se := data.CreateEmptyMeshExternalServiceEntry("global-se", "bookinfo", hosts)
+	se = data.AddPortDefinitionToServiceEntry(data.CreateEmptyPortDefinition(9080, "http", "HTTP"), se)
+	return data.AddEndpointsToServiceEntry(endpoints, se)
+}
+
+func clusters() []config.RemoteCluster {
+	return []config.RemoteCluster{
+		{Name: "east", GatewayAddresses: []string{"10.0.0.1"}, Kubeconfig: "/kiali/east/kubeconfig"},
+		{Name: "west", GatewayAddresses: []string{"10.0.0.2"}},
+	}
+}
+
+func remoteServices() RemoteServices {
+	return RemoteServices{
+		"east": {
+			"bookinfo": []core_v1.Service{
+				{
+					ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"},
+					Spec:       core_v1.ServiceSpec{Ports: []core_v1.ServicePort{{Name: "http", Port: 9080}}},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,274 @@
+package virtual_services
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// stringMatchFields are the fields of an HTTPMatchRequest holding a StringMatch (exact, prefix or regex)
+var stringMatchFields = []string{"uri", "scheme", "method", "authority"}
+
+// stringMatchMapFields are the fields of an HTTPMatchRequest holding a StringMatch per name
+var stringMatchMapFields = []string{"headers", "queryParams", "withoutHeaders"}
+
+// MatchChecker reasons over the ordered http routes of a VirtualService. Routes are evaluated in order and
+// the first one matching a request wins, so a route whose match conditions are all covered by earlier
+// routes is never used.
+type MatchChecker struct {
+	VirtualService kubernetes.IstioObject
+}
+
+// Check returns both an array of IstioCheck and a boolean indicating if the current virtual service is valid.
+// The array of IstioChecks contains the result of running the following validations:
+// 1. Regular expressions of the match conditions compile.
+// 2. A route without match conditions, which matches every request, is the last one.
+// 3. The routes, and each of their match conditions, match requests not matched by earlier routes.
+func (m MatchChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	routes, ok := m.VirtualService.GetSpec()["http"].([]interface{})
+	if !ok {
+		return checks, valid
+	}
+
+	catchAll := -1
+	earlier := make([]map[string]interface{}, 0)
+	for i, r := range routes {
+		route, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		matches, _ := route["match"].([]interface{})
+
+		requests := make([]map[string]interface{}, len(matches))
+		invalid := make([]bool, len(matches))
+		for k, mr := range matches {
+			if requests[k], ok = mr.(map[string]interface{}); !ok {
+				requests[k] = map[string]interface{}{}
+			}
+			if cs := regexChecks(requests[k], fmt.Sprintf("spec/http[%d]/match[%d]", i, k)); len(cs) > 0 {
+				checks = append(checks, cs...)
+				invalid[k], valid = true, false
+			}
+		}
+
+		if catchAll >= 0 {
+			checks = append(checks, buildMatchCheck("virtualservices.match.unreachable", routePath(i, len(matches) > 0)))
+			continue
+		}
+
+		if isCatchAll(requests) {
+			catchAll = i
+			if i < len(routes)-1 {
+				checks = append(checks, buildMatchCheck("virtualservices.match.catchallnotlast", fmt.Sprintf("spec/http[%d]", i)))
+			}
+			continue
+		}
+
+		shadowed := make([]int, 0, len(requests))
+		for k, request := range requests {
+			if !invalid[k] && isCovered(request, earlier) {
+				shadowed = append(shadowed, k)
+			}
+		}
+		if len(shadowed) == len(requests) {
+			checks = append(checks, buildMatchCheck("virtualservices.match.unreachable", routePath(i, true)))
+		} else {
+			for _, k := range shadowed {
+				checks = append(checks, buildMatchCheck("virtualservices.match.shadowed", fmt.Sprintf("spec/http[%d]/match[%d]", i, k)))
+			}
+		}
+
+		for k, request := range requests {
+			if !invalid[k] {
+				earlier = append(earlier, request)
+			}
+		}
+	}
+
+	return checks, valid
+}
+
+func routePath(routeIdx int, hasMatch bool) string {
+	if hasMatch {
+		return fmt.Sprintf("spec/http[%d]/match", routeIdx)
+	}
+	return fmt.Sprintf("spec/http[%d]", routeIdx)
+}
+
+func buildMatchCheck(code, path string) *models.IstioCheck {
+	check := models.Build(code, path)
+	return &check
+}
+
+// regexChecks returns a check per regular expression of the match request that does not compile
+func regexChecks(request map[string]interface{}, path string) []*models.IstioCheck {
+	checks := make([]*models.IstioCheck, 0)
+	for _, field := range stringMatchFields {
+		if !validRegex(request[field]) {
+			checks = append(checks, buildMatchCheck("virtualservices.match.invalidregex", fmt.Sprintf("%s/%s/regex", path, field)))
+		}
+	}
+	for _, field := range stringMatchMapFields {
+		values, ok := request[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if !validRegex(values[name]) {
+				checks = append(checks, buildMatchCheck("virtualservices.match.invalidregex", fmt.Sprintf("%s/%s/%s/regex", path, field, name)))
+			}
+		}
+	}
+	return checks
+}
+
+func validRegex(stringMatch interface{}) bool {
+	sm, ok := stringMatch.(map[string]interface{})
+	if !ok {
+		return true
+	}
+	regex, ok := sm["regex"].(string)
+	if !ok {
+		return true
+	}
+	_, err := regexp.Compile(regex)
+	return err == nil
+}
+
+// isCatchAll returns true when the route matches every request: it has no match requests or one of them
+// has only conditions matching any value, like a uri prefix "/"
+func isCatchAll(requests []map[string]interface{}) bool {
+	if len(requests) == 0 {
+		return true
+	}
+	for _, request := range requests {
+		if covers(request, map[string]interface{}{}) {
+			return true
+		}
+	}
+	return false
+}
+
+func isCovered(request map[string]interface{}, earlier []map[string]interface{}) bool {
+	for _, e := range earlier {
+		if covers(e, request) {
+			return true
+		}
+	}
+	return false
+}
+
+// covers returns true when every request matched by b is also matched by a, that is when every condition
+// of a is implied by the conditions of b. Conditions not analyzed must be equal in both.
+func covers(a, b map[string]interface{}) bool {
+	aFold, _ := a["ignoreUriCase"].(bool)
+	bFold, _ := b["ignoreUriCase"].(bool)
+	for field, av := range a {
+		switch field {
+		case "name", "ignoreUriCase":
+			continue
+		case "uri":
+			if !stringMatchCovers(av, b[field], aFold, bFold, true) {
+				return false
+			}
+		case "scheme", "method", "authority":
+			if !stringMatchCovers(av, b[field], false, false, false) {
+				return false
+			}
+		case "headers", "queryParams":
+			if !stringMatchMapCovers(av, b[field]) {
+				return false
+			}
+		default:
+			if !reflect.DeepEqual(av, b[field]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func stringMatchMapCovers(a, b interface{}) bool {
+	am, ok := a.(map[string]interface{})
+	if !ok {
+		return reflect.DeepEqual(a, b)
+	}
+	bm, _ := b.(map[string]interface{})
+	for name, av := range am {
+		bv, found := bm[name]
+		if !found || !stringMatchCovers(av, bv, false, false, false) {
+			return false
+		}
+	}
+	return true
+}
+
+// stringMatchCovers returns true when every value matched by the StringMatch b is matched by a. When b is
+// missing, any value is matched, which only a prefix "" or a regex ".*" cover, also a prefix "/" for uris.
+func stringMatchCovers(a, b interface{}, aFold, bFold, uri bool) bool {
+	am, ok := a.(map[string]interface{})
+	if !ok {
+		return reflect.DeepEqual(a, b)
+	}
+	ap, aIsPrefix := am["prefix"].(string)
+	ar, aIsRegex := am["regex"].(string)
+	if (aIsPrefix && (ap == "" || (uri && ap == "/"))) || (aIsRegex && ar == ".*") {
+		return true
+	}
+
+	bm, ok := b.(map[string]interface{})
+	if !ok || (bFold && !aFold) {
+		return false
+	}
+	if aFold {
+		am, bm = lowerStringMatch(am), lowerStringMatch(bm)
+		ap, _ = am["prefix"].(string)
+	}
+	be, bIsExact := bm["exact"].(string)
+	bp, bIsPrefix := bm["prefix"].(string)
+
+	if ae, ok := am["exact"].(string); ok {
+		return bIsExact && ae == be
+	}
+	if aIsPrefix {
+		return (bIsExact && strings.HasPrefix(be, ap)) || (bIsPrefix && strings.HasPrefix(bp, ap))
+	}
+	if aIsRegex {
+		if br, ok := bm["regex"].(string); ok {
+			return ar == br
+		}
+		if !bIsExact {
+			return false
+		}
+		if aFold {
+			ar = "(?i)" + ar
+		}
+		// Envoy matches the whole value against the regex
+		re, err := regexp.Compile("^(?:" + ar + ")$")
+		return err == nil && re.MatchString(be)
+	}
+	return false
+}
+
+func lowerStringMatch(sm map[string]interface{}) map[string]interface{} {
+	lower := make(map[string]interface{}, len(sm))
+	for k, v := range sm {
+		if s, ok := v.(string); ok && k != "regex" {
+			v = strings.ToLower(s)
+		}
+		lower[k] = v
+	}
+	return lower
+}
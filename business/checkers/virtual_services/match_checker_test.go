@@ -0,0 +1,153 @@
+package virtual_services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestReachableRoutes(t *testing.T) {
+	assert := assert.New(t)
+
+	checks, valid := MatchChecker{VirtualService: matchVirtualService(t, `
+  - match:
+    - uri:
+        exact: /api/v1
+    - uri:
+        prefix: /api/v2
+      method:
+        exact: GET
+    route:
+    - destination:
+        host: reviews
+        subset: v1
+  - match:
+    - uri:
+        prefix: /api
+      headers:
+        end-user:
+          exact: jason
+    route:
+    - destination:
+        host: reviews
+        subset: v2
+  - match:
+    - uri:
+        regex: /api/v[0-9]+
+    route:
+    - destination:
+        host: reviews
+        subset: v3
+  - route:
+    - destination:
+        host: reviews
+        subset: v1
+`)}.Check()
+	assert.True(valid)
+	assert.Empty(checks)
+
+	// A VirtualService without http routes
+	checks, valid = MatchChecker{VirtualService: data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})}.Check()
+	assert.True(valid)
+	assert.Empty(checks)
+}
+
+func TestShadowedRoutes(t *testing.T) {
+	assert := assert.New(t)
+
+	checks, valid := MatchChecker{VirtualService: matchVirtualService(t, `
+  - match:
+    - uri:
+        prefix: /API
+      ignoreUriCase: true
+    route:
+    - destination:
+        host: reviews
+        subset: v1
+  - match:
+    - uri:
+        prefix: /api/v2
+      headers:
+        end-user:
+          exact: jason
+    - uri:
+        exact: /api/v1
+    route:
+    - destination:
+        host: reviews
+        subset: v2
+  - match:
+    - uri:
+        exact: /login
+    - uri:
+        exact: /Api/v3
+    route:
+    - destination:
+        host: reviews
+        subset: v3
+`)}.Check()
+	assert.True(valid)
+	assert.Len(checks, 2)
+	assert.Equal(models.CheckMessage("virtualservices.match.unreachable"), checks[0].Message)
+	assert.Equal(models.WarningSeverity, checks[0].Severity)
+	assert.Equal("spec/http[1]/match", checks[0].Path)
+	assert.Equal(models.CheckMessage("virtualservices.match.shadowed"), checks[1].Message)
+	assert.Equal("spec/http[2]/match[1]", checks[1].Path)
+}
+
+func TestCatchAllNotLast(t *testing.T) {
+	assert := assert.New(t)
+
+	checks, valid := MatchChecker{VirtualService: matchVirtualService(t, `
+  - match:
+    - uri:
+        prefix: /
+    route:
+    - destination:
+        host: reviews
+        subset: v1
+  - route:
+    - destination:
+        host: reviews
+        subset: v2
+  - match:
+    - uri:
+        regex: "["
+    route:
+    - destination:
+        host: reviews
+        subset: v3
+`)}.Check()
+	assert.False(valid)
+	assert.Len(checks, 4)
+	assert.Equal(models.CheckMessage("virtualservices.match.catchallnotlast"), checks[0].Message)
+	assert.Equal("spec/http[0]", checks[0].Path)
+	assert.Equal(models.CheckMessage("virtualservices.match.unreachable"), checks[1].Message)
+	assert.Equal("spec/http[1]", checks[1].Path)
+	assert.Equal(models.CheckMessage("virtualservices.match.invalidregex"), checks[2].Message)
+	assert.Equal(models.ErrorSeverity, checks[2].Severity)
+	assert.Equal("spec/http[2]/match[0]/uri/regex", checks[2].Path)
+	assert.Equal(models.CheckMessage("virtualservices.match.unreachable"), checks[3].Message)
+	assert.Equal("spec/http[2]/match", checks[3].Path)
+}
+
+func matchVirtualService(t *testing.T, http string) kubernetes.IstioObject {
+	manifests := &kubernetes.Manifests{}
+	err := manifests.Parse(strings.NewReader(`
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: reviews
+  namespace: bookinfo
+spec:
+  hosts:
+  - reviews
+  http:` + http))
+	assert.NoError(t, err)
+	return manifests.IstioObjects[0]
+}
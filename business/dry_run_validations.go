@@ -0,0 +1,274 @@
+package business
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	core_v1 "k8s.io/api/core/v1"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/business/checkers"
+	"github.com/kiali/kiali/business/checkers/custom"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// dryRunObjects are the objects the checkers of a namespace run with, where the proposed object is placed
+type dryRunObjects struct {
+	istioDetails         kubernetes.IstioDetails
+	services             []core_v1.Service
+	namespaces           models.Namespaces
+	workloads            models.WorkloadList
+	gatewaysPerNamespace [][]kubernetes.IstioObject
+	mtlsDetails          kubernetes.MTLSDetails
+	rbacDetails          kubernetes.RBACDetails
+	customRules          []*custom.Rule
+}
+
+// DryRunCreateIstioConfigDetail validates the creation of an Istio object without applying it
+func (in *IstioConfigService) DryRunCreateIstioConfigDetail(namespace, resourceType, resourceSubtype string, body []byte) (models.IstioConfigDryRun, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "DryRunCreateIstioConfigDetail")
+	defer promtimer.ObserveNow(&err)
+
+	objectJson, err := in.ParseJsonForCreate(resourceType, resourceSubtype, body)
+	if err != nil {
+		return models.IstioConfigDryRun{}, errors2.NewBadRequest(err.Error())
+	}
+	proposed := &kubernetes.GenericIstioObject{}
+	if err = json.Unmarshal([]byte(objectJson), proposed); err != nil {
+		return models.IstioConfigDryRun{}, errors2.NewBadRequest(err.Error())
+	}
+	proposed.Namespace = namespace
+
+	return in.businessLayer.Validations.GetDryRunValidations(namespace, resourceType, proposed.Name,
+		func(current kubernetes.IstioObject) (kubernetes.IstioObject, error) {
+			if current != nil {
+				return nil, errors2.NewAlreadyExists(schema.GroupResource{Resource: resourceType}, proposed.Name)
+			}
+			return proposed, nil
+		})
+}
+
+// DryRunUpdateIstioConfigDetail validates the update of an Istio object, with a JSON merge patch, without applying it
+func (in *IstioConfigService) DryRunUpdateIstioConfigDetail(namespace, resourceType, name, jsonPatch string) (models.IstioConfigDryRun, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "DryRunUpdateIstioConfigDetail")
+	defer promtimer.ObserveNow(&err)
+
+	var patch interface{}
+	if err = json.Unmarshal([]byte(jsonPatch), &patch); err != nil {
+		return models.IstioConfigDryRun{}, errors2.NewBadRequest(err.Error())
+	}
+
+	return in.businessLayer.Validations.GetDryRunValidations(namespace, resourceType, name,
+		func(current kubernetes.IstioObject) (kubernetes.IstioObject, error) {
+			if current == nil {
+				return nil, errors2.NewNotFound(schema.GroupResource{Resource: resourceType}, name)
+			}
+			return applyMergePatch(current, patch)
+		})
+}
+
+// GetDryRunValidations validates a proposed Istio object against the current state of its namespace. The propose
+// function gets the current object of the given name, nil when it does not exist, and returns the object to validate.
+// The checkers run before and after placing the proposed object, so the changes on the checks of the other objects
+// of the namespace are reported as impacts.
+func (in *IstioValidationsService) GetDryRunValidations(namespace, resourceType, name string,
+	propose func(current kubernetes.IstioObject) (kubernetes.IstioObject, error)) (models.IstioConfigDryRun, error) {
+	dryRun := models.IstioConfigDryRun{Impacts: []models.IstioValidationImpact{}}
+
+	objectType, ok := models.ObjectTypeSingular[resourceType]
+	if !ok || !isDryRunSupported(resourceType) {
+		return dryRun, errors2.NewBadRequest(fmt.Sprintf("dry run is not supported for %s", resourceType))
+	}
+
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err := in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return dryRun, err
+	}
+
+	current, err := in.fetchDryRunObjects(namespace)
+	if err != nil {
+		return dryRun, err
+	}
+
+	proposed, err := propose(current.find(resourceType, namespace, name))
+	if err != nil {
+		return dryRun, err
+	}
+	if proposed.GetObjectMeta().Name != name {
+		return dryRun, errors2.NewBadRequest("the name of the object can not be changed")
+	}
+
+	before := in.runDryRunCheckers(namespace, current)
+	after := in.runDryRunCheckers(namespace, current.with(resourceType, proposed))
+
+	key := models.BuildKey(objectType, name, namespace)
+	if validation, ok := after[key]; ok {
+		dryRun.Validation = validation
+	} else {
+		_, dryRun.Validation = checkers.EmptyValidValidation(name, namespace, objectType)
+	}
+	dryRun.Impacts = models.ValidationImpacts(before, after, key)
+	return dryRun, nil
+}
+
+// fetchDryRunObjects fetches the current objects the checkers of a namespace run with
+func (in *IstioValidationsService) fetchDryRunObjects(namespace string) (dryRunObjects, error) {
+	current := dryRunObjects{}
+	wg := sync.WaitGroup{}
+	errChan := make(chan error, 1)
+	wg.Add(8)
+	go in.fetchNamespaces(&current.namespaces, errChan, &wg)
+	go in.fetchDetails(&current.istioDetails, namespace, errChan, &wg)
+	go in.fetchServices(&current.services, namespace, errChan, &wg)
+	go in.fetchWorkloads(&current.workloads, namespace, errChan, &wg)
+	go in.fetchGatewaysPerNamespace(&current.gatewaysPerNamespace, errChan, &wg)
+	go in.fetchNonLocalmTLSConfigs(&current.mtlsDetails, namespace, errChan, &wg)
+	go in.fetchAuthorizationDetails(&current.rbacDetails, namespace, errChan, &wg)
+	go in.fetchCustomRules(&current.customRules, errChan, &wg)
+	wg.Wait()
+	close(errChan)
+	for e := range errChan {
+		if e != nil {
+			return current, e
+		}
+	}
+	return current, nil
+}
+
+func (in *IstioValidationsService) runDryRunCheckers(namespace string, o dryRunObjects) models.IstioValidations {
+	objectCheckers := in.getAllObjectCheckers(namespace, o.istioDetails, o.services, o.workloads, o.gatewaysPerNamespace, o.mtlsDetails,
+		o.rbacDetails, o.namespaces, fetchRemoteServices(o.istioDetails.ServiceEntries))
+	objectCheckers = append(objectCheckers, checkers.CustomRulesChecker{Rules: o.customRules, Namespace: namespace, IstioDetails: o.istioDetails,
+		MTLSDetails: o.mtlsDetails, RBACDetails: o.rbacDetails, Services: o.services, WorkloadList: o.workloads})
+	return suppressChecks(runObjectCheckers(objectCheckers), o.istioDetails, o.mtlsDetails, o.rbacDetails, o.gatewaysPerNamespace, o.services)
+}
+
+func isDryRunSupported(resourceType string) bool {
+	switch resourceType {
+	case Gateways, VirtualServices, DestinationRules, ServiceEntries, Sidecars, EnvoyFilters, PeerAuthentications,
+		ServiceMeshPolicies, AuthorizationPolicies, ServiceRoles, ServiceRoleBindings, RequestAuthentications:
+		return true
+	}
+	return false
+}
+
+// find returns the object of the given type and name, nil when it does not exist
+func (o dryRunObjects) find(resourceType, namespace, name string) kubernetes.IstioObject {
+	for _, list := range o.lists(resourceType, namespace) {
+		for _, obj := range *list {
+			if obj.GetObjectMeta().Namespace == namespace && obj.GetObjectMeta().Name == name {
+				return obj
+			}
+		}
+	}
+	return nil
+}
+
+// with returns a copy of the objects where the proposed object replaces the object of the same name, or is added
+func (o dryRunObjects) with(resourceType string, proposed kubernetes.IstioObject) dryRunObjects {
+	meta := proposed.GetObjectMeta()
+	o.gatewaysPerNamespace = append([][]kubernetes.IstioObject{}, o.gatewaysPerNamespace...)
+	for _, list := range o.lists(resourceType, meta.Namespace) {
+		replaced := make([]kubernetes.IstioObject, 0, len(*list)+1)
+		for _, obj := range *list {
+			if obj.GetObjectMeta().Namespace != meta.Namespace || obj.GetObjectMeta().Name != meta.Name {
+				replaced = append(replaced, obj)
+			}
+		}
+		*list = append(replaced, proposed)
+	}
+	return o
+}
+
+// lists returns the lists holding the objects of a type, the objects of some types are given to the checkers twice
+func (o *dryRunObjects) lists(resourceType, namespace string) []*[]kubernetes.IstioObject {
+	switch resourceType {
+	case Gateways:
+		lists := []*[]kubernetes.IstioObject{&o.istioDetails.Gateways}
+		for i := range o.gatewaysPerNamespace {
+			if len(o.gatewaysPerNamespace[i]) > 0 && o.gatewaysPerNamespace[i][0].GetObjectMeta().Namespace == namespace {
+				return append(lists, &o.gatewaysPerNamespace[i])
+			}
+		}
+		o.gatewaysPerNamespace = append(o.gatewaysPerNamespace, []kubernetes.IstioObject{})
+		return append(lists, &o.gatewaysPerNamespace[len(o.gatewaysPerNamespace)-1])
+	case VirtualServices:
+		return []*[]kubernetes.IstioObject{&o.istioDetails.VirtualServices}
+	case DestinationRules:
+		return []*[]kubernetes.IstioObject{&o.istioDetails.DestinationRules, &o.mtlsDetails.DestinationRules}
+	case ServiceEntries:
+		return []*[]kubernetes.IstioObject{&o.istioDetails.ServiceEntries}
+	case Sidecars:
+		return []*[]kubernetes.IstioObject{&o.istioDetails.Sidecars}
+	case EnvoyFilters:
+		return []*[]kubernetes.IstioObject{&o.istioDetails.EnvoyFilters}
+	case PeerAuthentications:
+		if namespace == config.Get().IstioNamespace {
+			return []*[]kubernetes.IstioObject{&o.mtlsDetails.PeerAuthentications, &o.mtlsDetails.MeshPeerAuthentications}
+		}
+		return []*[]kubernetes.IstioObject{&o.mtlsDetails.PeerAuthentications}
+	case ServiceMeshPolicies:
+		return []*[]kubernetes.IstioObject{&o.mtlsDetails.ServiceMeshPolicies}
+	case AuthorizationPolicies:
+		return []*[]kubernetes.IstioObject{&o.rbacDetails.AuthorizationPolicies}
+	case ServiceRoles:
+		return []*[]kubernetes.IstioObject{&o.rbacDetails.ServiceRoles}
+	case ServiceRoleBindings:
+		return []*[]kubernetes.IstioObject{&o.rbacDetails.ServiceRoleBindings}
+	case RequestAuthentications:
+		if namespace == config.Get().IstioNamespace {
+			return []*[]kubernetes.IstioObject{&o.rbacDetails.RequestAuthentications, &o.rbacDetails.MeshRequestAuthentications}
+		}
+		return []*[]kubernetes.IstioObject{&o.rbacDetails.RequestAuthentications}
+	}
+	return nil
+}
+
+// applyMergePatch returns a copy of the object with the JSON merge patch (RFC 7386) applied, as the update would do
+func applyMergePatch(current kubernetes.IstioObject, patch interface{}) (kubernetes.IstioObject, error) {
+	content, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+	var target interface{}
+	if err := json.Unmarshal(content, &target); err != nil {
+		return nil, err
+	}
+	patched, err := json.Marshal(mergePatch(target, patch))
+	if err != nil {
+		return nil, err
+	}
+	result := &kubernetes.GenericIstioObject{}
+	if err := json.Unmarshal(patched, result); err != nil {
+		return nil, errors2.NewBadRequest(err.Error())
+	}
+	result.SetTypeMeta(current.GetTypeMeta())
+	return result, nil
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	patchMap, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetMap, ok := target.(map[string]interface{})
+	if !ok {
+		targetMap = map[string]interface{}{}
+	}
+	for k, v := range patchMap {
+		if v == nil {
+			delete(targetMap, k)
+		} else {
+			targetMap[k] = mergePatch(targetMap[k], v)
+		}
+	}
+	return targetMap
+}
@@ -0,0 +1,71 @@
+package business
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	assert := assert.New(t)
+
+	current := &kubernetes.GenericIstioObject{
+		TypeMeta:   meta_v1.TypeMeta{Kind: "VirtualService", APIVersion: "networking.istio.io/v1alpha3"},
+		ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo", Labels: map[string]string{"app": "reviews", "team": "a"}},
+		Spec: map[string]interface{}{
+			"hosts":    []interface{}{"reviews"},
+			"gateways": []interface{}{"bookinfo-gateway"},
+		},
+	}
+	var patch interface{}
+	assert.NoError(json.Unmarshal([]byte(`{"metadata":{"labels":{"team":null}},"spec":{"gateways":null,"hosts":["reviews.bookinfo"]}}`), &patch))
+
+	patched, err := applyMergePatch(current, patch)
+	assert.NoError(err)
+	assert.Equal("VirtualService", patched.GetTypeMeta().Kind)
+	assert.Equal("reviews", patched.GetObjectMeta().Name)
+	assert.Equal(map[string]string{"app": "reviews"}, patched.GetObjectMeta().Labels)
+	assert.Equal([]interface{}{"reviews.bookinfo"}, patched.GetSpec()["hosts"])
+	assert.NotContains(patched.GetSpec(), "gateways")
+
+	// the current object is not modified
+	assert.Equal([]interface{}{"reviews"}, current.Spec["hosts"])
+	assert.Equal("a", current.Labels["team"])
+}
+
+func TestDryRunObjectsWith(t *testing.T) {
+	assert := assert.New(t)
+
+	object := func(namespace, name string) kubernetes.IstioObject {
+		return &kubernetes.GenericIstioObject{ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+	current := dryRunObjects{}
+	current.istioDetails.Gateways = []kubernetes.IstioObject{object("bookinfo", "bookinfo-gateway"), object("istio-system", "ingress")}
+	current.gatewaysPerNamespace = [][]kubernetes.IstioObject{{object("istio-system", "ingress")}}
+	current.istioDetails.DestinationRules = []kubernetes.IstioObject{object("bookinfo", "reviews")}
+	current.mtlsDetails.DestinationRules = []kubernetes.IstioObject{object("bookinfo", "reviews")}
+
+	assert.NotNil(current.find(DestinationRules, "bookinfo", "reviews"))
+	assert.Nil(current.find(DestinationRules, "bookinfo", "ratings"))
+
+	// an update replaces the object in every list holding it
+	updated := object("bookinfo", "reviews")
+	proposed := current.with(DestinationRules, updated)
+	assert.Len(proposed.istioDetails.DestinationRules, 1)
+	assert.True(proposed.istioDetails.DestinationRules[0] == updated)
+	assert.True(proposed.mtlsDetails.DestinationRules[0] == updated)
+	assert.False(current.istioDetails.DestinationRules[0] == updated)
+
+	// a created gateway is added to the gateways of its namespace
+	created := object("bookinfo", "canary-gateway")
+	proposed = current.with(Gateways, created)
+	assert.Len(proposed.istioDetails.Gateways, 3)
+	assert.Len(proposed.gatewaysPerNamespace, 2)
+	assert.Equal([]kubernetes.IstioObject{created}, proposed.gatewaysPerNamespace[1])
+	assert.Len(current.istioDetails.Gateways, 2)
+	assert.Len(current.gatewaysPerNamespace, 1)
+}
@@ -0,0 +1,146 @@
+// Package export writes Istio validations in machine readable formats: SARIF for code scanning UIs,
+// JUnit XML for CI test reports and JSON Lines for log pipelines.
+//
+// 将 istio 配置校验结果导出为 SARIF, JUnit XML 和 JSON Lines 格式, 便于 CI 和 GitOps 流程使用
+package export
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const (
+	FormatJSONLines = "jsonl"
+	FormatJUnit     = "junit"
+	FormatSARIF     = "sarif"
+)
+
+// Formats are the supported export formats
+var Formats = []string{FormatJSONLines, FormatJUnit, FormatSARIF}
+
+// codeRegexp matches the check code prefixing check messages, i.e. KIA1107, or the id of a custom rule
+var codeRegexp = regexp.MustCompile(`^([A-Z]+\d+)\s+(.*)$`)
+
+// Locator returns the file and line of a path (i.e. spec/http[0]/route) of a validated object,
+// an empty file when the object was not read from a file
+type Locator func(objectType, namespace, name, path string) (file string, line int)
+
+// Finding is a single check of a validated object
+type Finding struct {
+	Namespace  string               `json:"namespace"`
+	ObjectType string               `json:"objectType"`
+	Name       string               `json:"name"`
+	Code       string               `json:"code,omitempty"` // i.e. KIA1107
+	Message    string               `json:"message"`
+	Severity   models.SeverityLevel `json:"severity"`
+	Path       string               `json:"path,omitempty"`
+	File       string               `json:"file,omitempty"`
+	Line       int                  `json:"line,omitempty"`
+	Suppressed bool                 `json:"suppressed,omitempty"` // suppressed by configuration or annotation
+}
+
+// ManifestsLocator locates the objects read from manifest files
+func ManifestsLocator(manifests *kubernetes.Manifests) Locator {
+	return func(objectType, namespace, name, path string) (string, int) {
+		source := manifests.FindSource(objectType, namespace, name)
+		if source == nil {
+			return "", 0
+		}
+		return source.File, source.PathLine(path)
+	}
+}
+
+// Write writes the validations in the given format
+func Write(w io.Writer, format string, validations models.IstioValidations, locator Locator) error {
+	switch format {
+	case FormatJSONLines:
+		return WriteJSONLines(w, validations, locator)
+	case FormatJUnit:
+		return WriteJUnit(w, validations, locator)
+	case FormatSARIF:
+		return WriteSARIF(w, validations, locator)
+	}
+	return fmt.Errorf("export format [%s] not supported", format)
+}
+
+// NewFindings returns the checks of the validations, suppressed ones included, sorted by namespace,
+// object type, name and path
+func NewFindings(validations models.IstioValidations, locator Locator) []Finding {
+	findings := []Finding{}
+	for key, validation := range validations {
+		for _, check := range validation.Checks {
+			findings = append(findings, newFinding(key, check, locator))
+		}
+		for _, check := range validation.SuppressedChecks {
+			finding := newFinding(key, check, locator)
+			finding.Suppressed = true
+			findings = append(findings, finding)
+		}
+	}
+	sortFindings(findings)
+	return findings
+}
+
+func newFinding(key models.IstioValidationKey, check *models.IstioCheck, locator Locator) Finding {
+	finding := Finding{
+		Namespace:  key.Namespace,
+		ObjectType: key.ObjectType,
+		Name:       key.Name,
+		Message:    check.Message,
+		Severity:   check.Severity,
+		Path:       check.Path,
+	}
+	if match := codeRegexp.FindStringSubmatch(check.Message); match != nil {
+		finding.Code = match[1]
+		finding.Message = match[2]
+	}
+	if check.Code != "" {
+		finding.Code = check.Code
+	}
+	if locator != nil {
+		finding.File, finding.Line = locator(key.ObjectType, key.Namespace, key.Name, check.Path)
+	}
+	return finding
+}
+
+func sortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.ObjectType != b.ObjectType {
+			return a.ObjectType < b.ObjectType
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Message < b.Message
+	})
+}
+
+// sortedKeys returns the validation keys sorted by namespace, object type and name
+func sortedKeys(validations models.IstioValidations) []models.IstioValidationKey {
+	keys := make([]models.IstioValidationKey, 0, len(validations))
+	for k := range validations {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Namespace != keys[j].Namespace {
+			return keys[i].Namespace < keys[j].Namespace
+		}
+		if keys[i].ObjectType != keys[j].ObjectType {
+			return keys[i].ObjectType < keys[j].ObjectType
+		}
+		return keys[i].Name < keys[j].Name
+	})
+	return keys
+}
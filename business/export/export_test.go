@@ -0,0 +1,158 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/models"
+)
+
+func testValidations() models.IstioValidations {
+	return models.IstioValidations{
+		models.IstioValidationKey{ObjectType: "virtualservice", Namespace: "bookinfo", Name: "reviews"}: &models.IstioValidation{
+			Name:       "reviews",
+			ObjectType: "virtualservice",
+			Valid:      true,
+			Checks: []*models.IstioCheck{
+				{Message: "KIA1107 Subset not found", Severity: models.WarningSeverity, Path: "spec/http[0]/route[0]/destination"},
+			},
+		},
+		models.IstioValidationKey{ObjectType: "destinationrule", Namespace: "bookinfo", Name: "ratings"}: &models.IstioValidation{
+			Name:       "ratings",
+			ObjectType: "destinationrule",
+			Valid:      false,
+			Checks: []*models.IstioCheck{
+				{Message: "KIA0202 This host has no matching entry in the service registry (service, workload or service entries)", Severity: models.ErrorSeverity, Path: "spec/host"},
+			},
+		},
+		models.IstioValidationKey{ObjectType: "gateway", Namespace: "istio-system", Name: "ingress"}: &models.IstioValidation{
+			Name:       "ingress",
+			ObjectType: "gateway",
+			Valid:      true,
+			Checks:     []*models.IstioCheck{},
+		},
+	}
+}
+
+// testLocator locates the bookinfo objects only
+func testLocator(objectType, namespace, name, path string) (string, int) {
+	if namespace != "bookinfo" {
+		return "", 0
+	}
+	return "manifests/" + name + ".yaml", 10 + len(path)
+}
+
+func TestNewFindings(t *testing.T) {
+	assert := assert.New(t)
+
+	findings := NewFindings(testValidations(), testLocator)
+	assert.Equal([]Finding{
+		{Namespace: "bookinfo", ObjectType: "destinationrule", Name: "ratings", Code: "KIA0202",
+			Message:  "This host has no matching entry in the service registry (service, workload or service entries)",
+			Severity: models.ErrorSeverity, Path: "spec/host", File: "manifests/ratings.yaml", Line: 19},
+		{Namespace: "bookinfo", ObjectType: "virtualservice", Name: "reviews", Code: "KIA1107",
+			Message: "Subset not found", Severity: models.WarningSeverity, Path: "spec/http[0]/route[0]/destination",
+			File: "manifests/reviews.yaml", Line: 43},
+	}, findings)
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	assert := assert.New(t)
+
+	out := &bytes.Buffer{}
+	assert.NoError(Write(out, FormatJSONLines, testValidations(), nil))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(lines, 2)
+	finding := Finding{}
+	assert.NoError(json.Unmarshal([]byte(lines[1]), &finding))
+	assert.Equal("KIA1107", finding.Code)
+	assert.Equal("", finding.File)
+}
+
+func TestWriteJUnit(t *testing.T) {
+	assert := assert.New(t)
+
+	out := &bytes.Buffer{}
+	assert.NoError(Write(out, FormatJUnit, testValidations(), testLocator))
+	assert.True(strings.HasPrefix(out.String(), xml.Header))
+
+	report := junitTestSuites{}
+	assert.NoError(xml.Unmarshal(out.Bytes(), &report))
+	assert.Equal(3, report.Tests)
+	assert.Equal(1, report.Failures)
+	assert.Len(report.Suites, 2)
+
+	bookinfo := report.Suites[0]
+	assert.Equal("bookinfo", bookinfo.Name)
+	assert.Equal(2, bookinfo.Tests)
+	ratings := bookinfo.TestCases[0]
+	assert.Equal("bookinfo.destinationrule", ratings.ClassName)
+	assert.Equal("manifests/ratings.yaml", ratings.File)
+	assert.NotNil(ratings.Failure)
+	assert.Contains(ratings.Failure.Text, "error KIA0202 This host has no matching entry")
+	assert.Contains(ratings.Failure.Text, "(manifests/ratings.yaml:19)")
+	reviews := bookinfo.TestCases[1]
+	assert.Nil(reviews.Failure)
+	assert.Contains(reviews.SystemOut, "warning KIA1107 Subset not found")
+
+	assert.Equal("istio-system", report.Suites[1].Name)
+	assert.Equal(0, report.Suites[1].Failures)
+}
+
+func TestWriteSARIF(t *testing.T) {
+	assert := assert.New(t)
+
+	out := &bytes.Buffer{}
+	assert.NoError(Write(out, FormatSARIF, testValidations(), testLocator))
+
+	log := sarifLog{}
+	assert.NoError(json.Unmarshal(out.Bytes(), &log))
+	assert.Equal("2.1.0", log.Version)
+	assert.Len(log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal([]string{"KIA0202", "KIA1107"}, []string{run.Tool.Driver.Rules[0].Id, run.Tool.Driver.Rules[1].Id})
+	assert.Len(run.Results, 2)
+	result := run.Results[0]
+	assert.Equal("KIA0202", result.RuleId)
+	assert.Equal("error", result.Level)
+	assert.Equal("manifests/ratings.yaml", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(19, result.Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Equal("bookinfo/destinationrule/ratings", result.Locations[0].LogicalLocations[0].FullyQualifiedName)
+	assert.Equal("warning", run.Results[1].Level)
+
+	// objects not read from a file only have a logical location
+	out.Reset()
+	assert.NoError(Write(out, FormatSARIF, testValidations(), nil))
+	assert.NotContains(out.String(), "physicalLocation")
+}
+
+func TestSuppressedFindings(t *testing.T) {
+	assert := assert.New(t)
+
+	validations := testValidations()
+	ratings := validations[models.BuildKey("destinationrule", "ratings", "bookinfo")]
+	ratings.SuppressedChecks, ratings.Checks = ratings.Checks, []*models.IstioCheck{}
+	ratings.Valid = true
+
+	out := &bytes.Buffer{}
+	assert.NoError(Write(out, FormatSARIF, validations, nil))
+	log := sarifLog{}
+	assert.NoError(json.Unmarshal(out.Bytes(), &log))
+	assert.Equal([]sarifSuppression{{Kind: "external"}}, log.Runs[0].Results[0].Suppressions)
+	assert.Empty(log.Runs[0].Results[1].Suppressions)
+
+	// suppressed errors do not fail the test case
+	out.Reset()
+	assert.NoError(Write(out, FormatJUnit, validations, nil))
+	assert.Contains(out.String(), `failures="0"`)
+	assert.Contains(out.String(), "suppressed error KIA0202")
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	assert.Error(t, Write(&bytes.Buffer{}, "csv", testValidations(), nil))
+}
@@ -0,0 +1,19 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/kiali/kiali/models"
+)
+
+// WriteJSONLines writes one json Finding per line
+func WriteJSONLines(w io.Writer, validations models.IstioValidations, locator Locator) error {
+	encoder := json.NewEncoder(w)
+	for _, finding := range NewFindings(validations, locator) {
+		if err := encoder.Encode(finding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
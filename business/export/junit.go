@@ -0,0 +1,116 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kiali/kiali/models"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes a test suite per namespace and a test case per validated object. Objects with
+// error checks fail, warnings and suppressed checks are reported in the test case output and do not fail it.
+func WriteJUnit(w io.Writer, validations models.IstioValidations, locator Locator) error {
+	report := junitTestSuites{Name: "kiali"}
+	findings := NewFindings(validations, locator)
+	for _, key := range sortedKeys(validations) {
+		if len(report.Suites) == 0 || report.Suites[len(report.Suites)-1].Name != key.Namespace {
+			report.Suites = append(report.Suites, junitTestSuite{Name: key.Namespace})
+		}
+		suite := &report.Suites[len(report.Suites)-1]
+
+		testCase := junitTestCase{
+			ClassName: key.Namespace + "." + key.ObjectType,
+			Name:      key.Name,
+		}
+		if locator != nil {
+			testCase.File, testCase.Line = locator(key.ObjectType, key.Namespace, key.Name, "")
+		}
+		errors, warnings := []string{}, []string{}
+		for _, f := range findings {
+			if f.Namespace != key.Namespace || f.ObjectType != key.ObjectType || f.Name != key.Name {
+				continue
+			}
+			if f.Severity == models.ErrorSeverity && !f.Suppressed {
+				errors = append(errors, findingText(f))
+			} else {
+				warnings = append(warnings, findingText(f))
+			}
+		}
+		if len(errors) > 0 {
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d validation errors", len(errors)),
+				Type:    string(models.ErrorSeverity),
+				Text:    strings.Join(errors, "\n"),
+			}
+			suite.Failures++
+			report.Failures++
+		}
+		testCase.SystemOut = strings.Join(warnings, "\n")
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+		report.Tests++
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// findingText is a one line description of a finding, i.e.
+// error KIA0202 This host has no matching entry in the service registry [spec/host] (dr.yaml:8)
+func findingText(f Finding) string {
+	text := string(f.Severity)
+	if f.Suppressed {
+		text = "suppressed " + text
+	}
+	if f.Code != "" {
+		text += " " + f.Code
+	}
+	text += " " + f.Message
+	if f.Path != "" {
+		text += " [" + f.Path + "]"
+	}
+	if f.File != "" {
+		text += fmt.Sprintf(" (%s:%d)", f.File, f.Line)
+	}
+	return text
+}
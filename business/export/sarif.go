@@ -0,0 +1,143 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+
+	"github.com/kiali/kiali/models"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleId       string             `json:"ruleId,omitempty"`
+	Level        string             `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind string `json:"kind"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"` // <namespace>/<objectType>/<name>
+	Kind               string `json:"kind"`
+}
+
+// WriteSARIF writes a SARIF 2.1.0 log with a rule per check code and a result per finding. Results of
+// objects read from manifests have a physical location, all of them have a logical location.
+// Suppressed findings are reported with a suppression.
+func WriteSARIF(w io.Writer, validations models.IstioValidations, locator Locator) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "kiali",
+			InformationURI: "https://kiali.io",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	rules := make(map[string]bool)
+	for _, f := range NewFindings(validations, locator) {
+		if f.Code != "" && !rules[f.Code] {
+			rules[f.Code] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{Id: f.Code, ShortDescription: sarifMessage{Text: f.Message}})
+		}
+		location := sarifLocation{
+			LogicalLocations: []sarifLogicalLocation{{
+				FullyQualifiedName: f.Namespace + "/" + f.ObjectType + "/" + f.Name,
+				Kind:               "object",
+			}},
+		}
+		if f.File != "" {
+			location.PhysicalLocation = &sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(f.File)},
+			}
+			if f.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: f.Line}
+			}
+		}
+		message := f.Message
+		if f.Path != "" {
+			message += " [" + f.Path + "]"
+		}
+		result := sarifResult{
+			RuleId:    f.Code,
+			Level:     sarifLevel(f.Severity),
+			Message:   sarifMessage{Text: message},
+			Locations: []sarifLocation{location},
+		}
+		if f.Suppressed {
+			// suppressed outside of the manifest: by the Kiali config or the ignore annotation
+			result.Suppressions = []sarifSuppression{{Kind: "external"}}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}})
+}
+
+func sarifLevel(severity models.SeverityLevel) string {
+	switch severity {
+	case models.ErrorSeverity:
+		return "error"
+	case models.WarningSeverity:
+		return "warning"
+	}
+	return "note"
+}
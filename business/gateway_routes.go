@@ -0,0 +1,273 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// GetGatewayRoutes returns the routing topology declared for a Gateway: the workloads selected by the Gateway,
+// the hosts and routes of every VirtualService bound to it, and the service each route destination resolves to.
+// 返回绑定到某个 Gateway 的所有 VirtualService 的 host 和路由, 以及每个路由对应的服务
+func (in *IstioConfigService) GetGatewayRoutes(namespace, gateway string) (*models.GatewayRoutes, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "GetGatewayRoutes")
+	defer promtimer.ObserveNow(&err)
+
+	gw, err := in.k8s.GetGateway(namespace, gateway)
+	if err != nil {
+		return nil, err
+	}
+
+	gatewayRoutes := &models.GatewayRoutes{
+		Hosts:  []string{},
+		Routes: []models.GatewayRoute{},
+	}
+	gatewayRoutes.Gateway.Parse(gw)
+
+	if gatewayRoutes.Workloads, err = in.getGatewayWorkloads(gw); err != nil {
+		return nil, err
+	}
+
+	namespaces, err := in.businessLayer.Namespace.GetNamespaces()
+	if err != nil {
+		return nil, err
+	}
+	nsNames := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		nsNames = append(nsNames, ns.Name)
+	}
+
+	resolver := gatewayHostResolver{k8s: in.k8s, namespaces: nsNames, services: map[string][]core_v1.Service{}}
+	hosts := map[string]bool{}
+	for _, ns := range nsNames {
+		vss, err := in.k8s.GetVirtualServices(ns, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, vs := range vss {
+			if !IsBoundToGateway(vs, namespace, gateway) {
+				continue
+			}
+			vsHosts := toStringSlice(vs.GetSpec()["hosts"])
+			for _, h := range vsHosts {
+				hosts[h] = true
+			}
+			for _, protocol := range []string{"http", "tcp", "tls"} {
+				routes, ok := vs.GetSpec()[protocol].([]interface{})
+				if !ok {
+					continue
+				}
+				for i, r := range routes {
+					route := models.GatewayRoute{
+						VirtualService: vs.GetObjectMeta().Name,
+						Namespace:      vs.GetObjectMeta().Namespace,
+						Hosts:          vsHosts,
+						Protocol:       protocol,
+						Path:           fmt.Sprintf("spec/%s[%d]", protocol, i),
+						Destinations:   []models.GatewayRouteDestination{},
+					}
+					for _, host := range routeDestinations(r) {
+						destination, err := resolver.resolve(host[0], host[1], route.Namespace)
+						if err != nil {
+							return nil, err
+						}
+						route.Destinations = append(route.Destinations, destination)
+					}
+					gatewayRoutes.Routes = append(gatewayRoutes.Routes, route)
+				}
+			}
+		}
+	}
+
+	for h := range hosts {
+		gatewayRoutes.Hosts = append(gatewayRoutes.Hosts, h)
+	}
+	sort.Strings(gatewayRoutes.Hosts)
+
+	return gatewayRoutes, nil
+}
+
+// getGatewayWorkloads returns the deployments matching the Gateway selector. Gateway selectors are not restricted
+// to the Gateway namespace, so the Istio control plane namespace is searched too.
+func (in *IstioConfigService) getGatewayWorkloads(gw kubernetes.IstioObject) ([]models.GatewayWorkload, error) {
+	workloads := []models.GatewayWorkload{}
+	selector, ok := gw.GetSpec()["selector"].(map[string]interface{})
+	if !ok || len(selector) == 0 {
+		return workloads, nil
+	}
+	selectorLabels := labels.Set{}
+	for k, v := range selector {
+		if s, ok := v.(string); ok {
+			selectorLabels[k] = s
+		}
+	}
+	gwSelector := labels.SelectorFromSet(selectorLabels)
+
+	searchNamespaces := []string{gw.GetObjectMeta().Namespace}
+	if istioNs := config.Get().IstioNamespace; istioNs != gw.GetObjectMeta().Namespace {
+		searchNamespaces = append(searchNamespaces, istioNs)
+	}
+
+	appLabel := config.Get().IstioLabels.AppLabelName
+	versionLabel := config.Get().IstioLabels.VersionLabelName
+	for _, ns := range searchNamespaces {
+		deployments, err := in.k8s.GetDeployments(ns)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range deployments {
+			podLabels := labels.Set(d.Spec.Template.Labels)
+			if gwSelector.Matches(podLabels) {
+				workloads = append(workloads, models.GatewayWorkload{
+					Namespace: d.Namespace,
+					Name:      d.Name,
+					App:       podLabels.Get(appLabel),
+					Version:   podLabels.Get(versionLabel),
+				})
+			}
+		}
+	}
+	return workloads, nil
+}
+
+// IsBoundToGateway returns true when the VirtualService gateways field references the given Gateway.
+// Gateway references may use the <gateway>, <namespace>/<gateway> or FQDN formats.
+func IsBoundToGateway(vs kubernetes.IstioObject, gwNamespace, gwName string) bool {
+	vsNamespace := vs.GetObjectMeta().Namespace
+	for _, ref := range toStringSlice(vs.GetSpec()["gateways"]) {
+		if ref == "mesh" {
+			continue
+		}
+		if strings.Contains(ref, "/") {
+			parts := strings.SplitN(ref, "/", 2)
+			if parts[0] == gwNamespace && parts[1] == gwName {
+				return true
+			}
+			continue
+		}
+		host := kubernetes.ParseHost(ref, vsNamespace, "")
+		svc, ns := kubernetes.ParseTwoPartHost(host)
+		if svc == gwName && ns == gwNamespace {
+			return true
+		}
+	}
+	return false
+}
+
+// routeDestinations returns the [host, subset] pairs declared by the destinations of a single route
+func routeDestinations(route interface{}) [][2]string {
+	destinations := [][2]string{}
+	mRoute, ok := route.(map[string]interface{})
+	if !ok {
+		return destinations
+	}
+	aRoute, ok := mRoute["route"].([]interface{})
+	if !ok {
+		return destinations
+	}
+	for _, r := range aRoute {
+		if mr, ok := r.(map[string]interface{}); ok {
+			if destination, ok := mr["destination"].(map[string]interface{}); ok {
+				host, _ := destination["host"].(string)
+				subset, _ := destination["subset"].(string)
+				if host != "" {
+					destinations = append(destinations, [2]string{host, subset})
+				}
+			}
+		}
+	}
+	return destinations
+}
+
+// gatewayHostResolver resolves route destination hosts to services, caching the lookups per namespace
+type gatewayHostResolver struct {
+	k8s            kubernetes.IstioClientInterface
+	namespaces     []string
+	services       map[string][]core_v1.Service
+	serviceEntries map[string][]string
+}
+
+func (r *gatewayHostResolver) resolve(host, subset, vsNamespace string) (models.GatewayRouteDestination, error) {
+	destination := models.GatewayRouteDestination{
+		Host:   host,
+		Subset: subset,
+	}
+	parsed := kubernetes.GetHost(host, vsNamespace, config.Get().ExternalServices.Istio.IstioIdentityDomain, r.namespaces)
+	if parsed.CompleteInput {
+		destination.Service = parsed.Service
+		destination.Namespace = parsed.Namespace
+		services, err := r.getServices(parsed.Namespace)
+		if err != nil {
+			return destination, err
+		}
+		if kubernetes.HasMatchingServices(parsed.Service, services) {
+			destination.HasService = true
+			return destination, nil
+		}
+	} else {
+		destination.Service = host
+		destination.Namespace = vsNamespace
+	}
+
+	if r.serviceEntries == nil {
+		seList := []kubernetes.IstioObject{}
+		for _, ns := range r.namespaces {
+			ses, err := r.k8s.GetServiceEntries(ns)
+			if err != nil {
+				return destination, err
+			}
+			seList = append(seList, ses...)
+		}
+		r.serviceEntries = kubernetes.ServiceEntryHostnames(seList)
+	}
+	destination.HasService = kubernetes.HasMatchingServiceEntries(host, r.serviceEntries)
+	return destination, nil
+}
+
+func (r *gatewayHostResolver) getServices(namespace string) ([]core_v1.Service, error) {
+	if services, found := r.services[namespace]; found {
+		return services, nil
+	}
+	// Unknown namespaces have no services
+	known := false
+	for _, ns := range r.namespaces {
+		if ns == namespace {
+			known = true
+			break
+		}
+	}
+	if !known {
+		r.services[namespace] = []core_v1.Service{}
+		return r.services[namespace], nil
+	}
+	services, err := r.k8s.GetServices(namespace, nil)
+	if err != nil {
+		return nil, err
+	}
+	r.services[namespace] = services
+	return services, nil
+}
+
+func toStringSlice(value interface{}) []string {
+	result := []string{}
+	switch v := value.(type) {
+	case []interface{}:
+		for _, i := range v {
+			if s, ok := i.(string); ok {
+				result = append(result, s)
+			}
+		}
+	case []string:
+		result = append(result, v...)
+	}
+	return result
+}
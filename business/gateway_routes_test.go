@@ -0,0 +1,101 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestGetGatewayRoutes(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	gw := data.CreateEmptyGateway("bookinfo-gateway", "test", map[string]string{"istio": "ingressgateway"})
+
+	bound := data.AddGatewaysToVirtualService([]string{"test/bookinfo-gateway"},
+		data.AddRoutesToVirtualService("http", data.CreateRoute("reviews", "v1", -1),
+			data.CreateEmptyVirtualService("reviews", "test", []string{"bookinfo.example.com"})))
+	missing := data.AddGatewaysToVirtualService([]string{"bookinfo-gateway"},
+		data.AddRoutesToVirtualService("http", data.CreateRoute("ratings", "", -1),
+			data.CreateEmptyVirtualService("ratings", "test", []string{"ratings.example.com"})))
+	unbound := data.AddGatewaysToVirtualService([]string{"mesh"},
+		data.AddRoutesToVirtualService("http", data.CreateRoute("details", "", -1),
+			data.CreateEmptyVirtualService("details", "test", []string{"details"})))
+
+	k8s := new(kubetest.K8SClientMock)
+	k8s.On("IsOpenShift").Return(false)
+	k8s.On("IsMaistraApi").Return(false)
+	k8s.On("GetNamespaces").Return([]core_v1.Namespace{{ObjectMeta: meta_v1.ObjectMeta{Name: "test"}}}, nil)
+	k8s.On("GetGateway", "test", "bookinfo-gateway").Return(gw, nil)
+	k8s.On("GetVirtualServices", "test", "").Return([]kubernetes.IstioObject{bound, missing, unbound}, nil)
+	k8s.On("GetServiceEntries", "test").Return([]kubernetes.IstioObject{}, nil)
+	k8s.On("GetServices", "test", mock.AnythingOfType("map[string]string")).Return([]core_v1.Service{
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "test"}},
+	}, nil)
+	k8s.On("GetDeployments", "test").Return([]apps_v1.Deployment{}, nil)
+	k8s.On("GetDeployments", "istio-system").Return([]apps_v1.Deployment{
+		fakeGatewayDeployment("istio-ingressgateway", map[string]string{"istio": "ingressgateway", "app": "istio-ingressgateway"}),
+		fakeGatewayDeployment("istio-egressgateway", map[string]string{"istio": "egressgateway", "app": "istio-egressgateway"}),
+	}, nil)
+
+	layer := NewWithBackends(k8s, nil, nil)
+	routes, err := layer.IstioConfig.GetGatewayRoutes("test", "bookinfo-gateway")
+	assert.NoError(err)
+
+	assert.Len(routes.Workloads, 1)
+	assert.Equal("istio-ingressgateway", routes.Workloads[0].Name)
+	assert.Equal("istio-ingressgateway", routes.Workloads[0].App)
+
+	assert.Equal([]string{"bookinfo.example.com", "ratings.example.com"}, routes.Hosts)
+	assert.Len(routes.Routes, 2)
+
+	assert.Equal("reviews", routes.Routes[0].VirtualService)
+	assert.Equal("spec/http[0]", routes.Routes[0].Path)
+	assert.Len(routes.Routes[0].Destinations, 1)
+	assert.Equal("reviews", routes.Routes[0].Destinations[0].Service)
+	assert.Equal("test", routes.Routes[0].Destinations[0].Namespace)
+	assert.Equal("v1", routes.Routes[0].Destinations[0].Subset)
+	assert.True(routes.Routes[0].Destinations[0].HasService)
+
+	assert.Equal("ratings", routes.Routes[1].VirtualService)
+	assert.Len(routes.Routes[1].Destinations, 1)
+	assert.False(routes.Routes[1].Destinations[0].HasService)
+}
+
+func TestIsBoundToGateway(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vs := func(gateways ...string) kubernetes.IstioObject {
+		return data.AddGatewaysToVirtualService(gateways, data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"}))
+	}
+
+	assert.True(IsBoundToGateway(vs("bookinfo-gateway"), "bookinfo", "bookinfo-gateway"))
+	assert.True(IsBoundToGateway(vs("mesh", "istio-system/bookinfo-gateway"), "istio-system", "bookinfo-gateway"))
+	assert.True(IsBoundToGateway(vs("bookinfo-gateway.istio-system.svc.cluster.local"), "istio-system", "bookinfo-gateway"))
+	assert.False(IsBoundToGateway(vs("bookinfo-gateway"), "istio-system", "bookinfo-gateway"))
+	assert.False(IsBoundToGateway(vs("mesh"), "bookinfo", "mesh"))
+	assert.False(IsBoundToGateway(vs(), "bookinfo", "bookinfo-gateway"))
+}
+
+func fakeGatewayDeployment(name string, labels map[string]string) apps_v1.Deployment {
+	return apps_v1.Deployment{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "istio-system"},
+		Spec: apps_v1.DeploymentSpec{
+			Template: core_v1.PodTemplateSpec{
+				ObjectMeta: meta_v1.ObjectMeta{Labels: labels},
+			},
+		},
+	}
+}
@@ -0,0 +1,259 @@
+// Package history keeps the results of the periodic validations of the namespaces, so it is known when
+// a misconfiguration first appeared and when it was resolved.
+//
+// 校验历史: 定期校验命名空间的 Istio 配置, 保存汇总和每条问题, 用于查询新增/已解决的问题和趋势
+package history
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/kiali/kiali/models"
+)
+
+var (
+	summariesBucket = []byte("summaries")
+	findingsBucket  = []byte("findings")
+)
+
+// Store persists the validation summaries and findings in a BoltDB file. Both are kept in a bucket
+// per namespace: summaries keyed by the time of the run, findings by the time they were first seen.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens, or creates, the store at the given path
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{summariesBucket, findingsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the store file
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Record saves the validations of a namespace computed at the given time. Checks not reported before,
+// or resolved since, become new findings; findings not reported anymore are resolved.
+// It returns the summary of the validations.
+func (s *Store) Record(namespace string, at time.Time, validations models.IstioValidations) (models.IstioValidationSummary, error) {
+	summary := validations.SummarizeValidation(namespace)
+
+	current := map[string]models.ValidationFinding{}
+	for key, validation := range validations {
+		if key.Namespace != namespace {
+			continue
+		}
+		for _, check := range validation.Checks {
+			f := models.NewValidationFinding(key, check, at)
+			current[f.Id()] = f
+		}
+	}
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		summaries, err := tx.Bucket(summariesBucket).CreateBucketIfNotExists([]byte(namespace))
+		if err != nil {
+			return err
+		}
+		sample, err := json.Marshal(models.ValidationSummarySample{Time: at, IstioValidationSummary: summary})
+		if err != nil {
+			return err
+		}
+		if err := summaries.Put(timeKey(at, ""), sample); err != nil {
+			return err
+		}
+
+		findings, err := tx.Bucket(findingsBucket).CreateBucketIfNotExists([]byte(namespace))
+		if err != nil {
+			return err
+		}
+		// the bucket is not modified while iterating, the updates are applied once read
+		updated := map[string]models.ValidationFinding{}
+		err = findings.ForEach(func(k, v []byte) error {
+			f := models.ValidationFinding{}
+			if err := json.Unmarshal(v, &f); err != nil {
+				return err
+			}
+			if f.ResolvedAt != nil {
+				return nil
+			}
+			if _, found := current[f.Id()]; found {
+				f.LastSeen = at
+				delete(current, f.Id())
+			} else {
+				resolvedAt := at
+				f.ResolvedAt = &resolvedAt
+			}
+			updated[string(k)] = f
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for id, f := range current {
+			updated[string(timeKey(at, id))] = f
+		}
+		for k, f := range updated {
+			if err := putFinding(findings, []byte(k), f); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return summary, err
+}
+
+// NewFindings returns the findings of the namespaces first seen since the given time, resolved or not
+func (s *Store) NewFindings(namespaces []string, since time.Time) ([]models.ValidationFinding, error) {
+	return s.findings(namespaces, func(f models.ValidationFinding) bool {
+		return !f.FirstSeen.Before(since)
+	})
+}
+
+// ResolvedFindings returns the findings of the namespaces resolved since the given time
+func (s *Store) ResolvedFindings(namespaces []string, since time.Time) ([]models.ValidationFinding, error) {
+	return s.findings(namespaces, func(f models.ValidationFinding) bool {
+		return f.ResolvedAt != nil && !f.ResolvedAt.Before(since)
+	})
+}
+
+// Trend returns the summaries of a namespace recorded since the given time, oldest first
+func (s *Store) Trend(namespace string, since time.Time) (models.ValidationTrend, error) {
+	trend := models.ValidationTrend{Namespace: namespace, Samples: []models.ValidationSummarySample{}}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		summaries := tx.Bucket(summariesBucket).Bucket([]byte(namespace))
+		if summaries == nil {
+			return nil
+		}
+		c := summaries.Cursor()
+		for k, v := c.Seek(timeKey(since, "")); k != nil; k, v = c.Next() {
+			sample := models.ValidationSummarySample{}
+			if err := json.Unmarshal(v, &sample); err != nil {
+				return err
+			}
+			trend.Samples = append(trend.Samples, sample)
+		}
+		return nil
+	})
+	return trend, err
+}
+
+// Prune deletes the summaries recorded and the findings resolved before the given time
+func (s *Store) Prune(before time.Time) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := forEachNamespace(tx.Bucket(summariesBucket), func(b *bolt.Bucket) error {
+			limit := timeKey(before, "")
+			c := b.Cursor()
+			for k, _ := c.First(); k != nil && bytes.Compare(k, limit) < 0; k, _ = c.First() {
+				if err := c.Delete(); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return forEachNamespace(tx.Bucket(findingsBucket), func(b *bolt.Bucket) error {
+			expired := [][]byte{}
+			err := b.ForEach(func(k, v []byte) error {
+				f := models.ValidationFinding{}
+				if err := json.Unmarshal(v, &f); err != nil {
+					return err
+				}
+				if f.ResolvedAt != nil && f.ResolvedAt.Before(before) {
+					expired = append(expired, append([]byte{}, k...))
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			for _, k := range expired {
+				if err := b.Delete(k); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+func (s *Store) findings(namespaces []string, include func(models.ValidationFinding) bool) ([]models.ValidationFinding, error) {
+	result := []models.ValidationFinding{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		for _, ns := range namespaces {
+			findings := tx.Bucket(findingsBucket).Bucket([]byte(ns))
+			if findings == nil {
+				continue
+			}
+			err := findings.ForEach(func(_, v []byte) error {
+				f := models.ValidationFinding{}
+				if err := json.Unmarshal(v, &f); err != nil {
+					return err
+				}
+				if include(f) {
+					result = append(result, f)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].FirstSeen.Before(result[j].FirstSeen)
+	})
+	return result, err
+}
+
+func putFinding(b *bolt.Bucket, key []byte, f models.ValidationFinding) error {
+	value, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	return b.Put(key, value)
+}
+
+func forEachNamespace(parent *bolt.Bucket, fn func(*bolt.Bucket) error) error {
+	return parent.ForEach(func(k, v []byte) error {
+		// nested buckets have a nil value
+		if v != nil {
+			return nil
+		}
+		return fn(parent.Bucket(k))
+	})
+}
+
+// timeKey returns a key sorted by time: the big endian unix nanos followed by the suffix
+func timeKey(t time.Time, suffix string) []byte {
+	key := make([]byte, 8, 8+len(suffix))
+	binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	return append(key, suffix...)
+}
@@ -0,0 +1,125 @@
+package history
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/models"
+)
+
+func openTestStore(t *testing.T) *Store {
+	dir, err := ioutil.TempDir("", "validation-history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := Open(filepath.Join(dir, "history", "validations.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		store.Close()
+		os.RemoveAll(dir)
+	})
+	return store
+}
+
+func validations(namespace string, checks map[string][]*models.IstioCheck) models.IstioValidations {
+	iv := models.IstioValidations{}
+	for name, cs := range checks {
+		key := models.IstioValidationKey{ObjectType: "virtualservice", Name: name, Namespace: namespace}
+		iv[key] = &models.IstioValidation{Name: name, ObjectType: "virtualservice", Valid: true, Checks: cs}
+	}
+	return iv
+}
+
+func TestRecordFindings(t *testing.T) {
+	assert := assert.New(t)
+	store := openTestStore(t)
+
+	weights := &models.IstioCheck{Code: "KIA1106", Message: "KIA1106 Weight sum should be 100", Severity: models.ErrorSeverity, Path: "spec/http[0]"}
+	subset := &models.IstioCheck{Code: "KIA1107", Message: "KIA1107 Subset not found", Severity: models.WarningSeverity, Path: "spec/http[0]/route[0]"}
+
+	t0 := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	t1, t2, t3 := t0.Add(time.Hour), t0.Add(2*time.Hour), t0.Add(3*time.Hour)
+
+	summary, err := store.Record("bookinfo", t0, validations("bookinfo", map[string][]*models.IstioCheck{"reviews": {weights}, "ratings": {}}))
+	assert.NoError(err)
+	assert.Equal(models.IstioValidationSummary{Errors: 1, ObjectCount: 2}, summary)
+
+	_, err = store.Record("bookinfo", t1, validations("bookinfo", map[string][]*models.IstioCheck{"reviews": {weights}, "ratings": {subset}}))
+	assert.NoError(err)
+	_, err = store.Record("bookinfo", t2, validations("bookinfo", map[string][]*models.IstioCheck{"reviews": {}, "ratings": {subset}}))
+	assert.NoError(err)
+
+	// the weights error first appeared at t0, the missing subset at t1
+	findings, err := store.NewFindings([]string{"bookinfo"}, t0)
+	assert.NoError(err)
+	assert.Len(findings, 2)
+	assert.Equal("reviews", findings[0].Name)
+	assert.Equal(t0, findings[0].FirstSeen.UTC())
+	assert.Equal(t1, findings[0].LastSeen.UTC())
+	assert.Equal(t2, findings[0].ResolvedAt.UTC())
+	assert.Equal("ratings", findings[1].Name)
+	assert.Nil(findings[1].ResolvedAt)
+
+	findings, err = store.NewFindings([]string{"bookinfo"}, t1)
+	assert.NoError(err)
+	assert.Len(findings, 1)
+	assert.Equal("KIA1107", findings[0].Code)
+
+	findings, err = store.ResolvedFindings([]string{"bookinfo", "default"}, t1)
+	assert.NoError(err)
+	assert.Len(findings, 1)
+	assert.Equal("KIA1106", findings[0].Code)
+
+	// a finding reported again after being resolved is a new finding
+	_, err = store.Record("bookinfo", t3, validations("bookinfo", map[string][]*models.IstioCheck{"reviews": {weights}, "ratings": {subset}}))
+	assert.NoError(err)
+	findings, err = store.NewFindings([]string{"bookinfo"}, t3)
+	assert.NoError(err)
+	assert.Len(findings, 1)
+	assert.Equal(t3, findings[0].FirstSeen.UTC())
+}
+
+func TestTrendAndPrune(t *testing.T) {
+	assert := assert.New(t)
+	store := openTestStore(t)
+
+	warning := &models.IstioCheck{Code: "KIA1107", Severity: models.WarningSeverity}
+	t0 := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		checks := map[string][]*models.IstioCheck{"reviews": {}}
+		if i%2 == 0 {
+			checks["reviews"] = []*models.IstioCheck{warning}
+		}
+		_, err := store.Record("bookinfo", t0.Add(time.Duration(i)*time.Hour), validations("bookinfo", checks))
+		assert.NoError(err)
+	}
+
+	trend, err := store.Trend("bookinfo", t0.Add(time.Hour))
+	assert.NoError(err)
+	assert.Equal("bookinfo", trend.Namespace)
+	assert.Len(trend.Samples, 2)
+	assert.Equal(0, trend.Samples[0].Warnings)
+	assert.Equal(1, trend.Samples[1].Warnings)
+
+	trend, err = store.Trend("default", t0)
+	assert.NoError(err)
+	assert.Empty(trend.Samples)
+
+	// the samples before the limit and the findings resolved before it are removed
+	assert.NoError(store.Prune(t0.Add(90 * time.Minute)))
+	trend, err = store.Trend("bookinfo", t0)
+	assert.NoError(err)
+	assert.Len(trend.Samples, 1)
+
+	findings, err := store.NewFindings([]string{"bookinfo"}, t0)
+	assert.NoError(err)
+	assert.Len(findings, 1)
+	assert.Nil(findings[0].ResolvedAt)
+}
@@ -27,6 +27,7 @@ type IstioConfigCriteria struct {
 	IncludeGateways               bool
 	IncludeVirtualServices        bool
 	IncludeDestinationRules       bool
+	IncludeEnvoyFilters           bool
 	IncludeServiceEntries         bool
 	IncludeRules                  bool
 	IncludeAdapters               bool
@@ -51,6 +52,7 @@ type IstioConfigCriteria struct {
 const (
 	VirtualServices        = "virtualservices"
 	DestinationRules       = "destinationrules"
+	EnvoyFilters           = "envoyfilters"
 	ServiceEntries         = "serviceentries"
 	Gateways               = "gateways"
 	Rules                  = "rules"
@@ -81,6 +83,7 @@ var resourceTypesToAPI = map[string]string{
 	ServiceEntries:         kubernetes.NetworkingGroupVersion.Group,
 	Gateways:               kubernetes.NetworkingGroupVersion.Group,
 	Sidecars:               kubernetes.NetworkingGroupVersion.Group,
+	EnvoyFilters:           kubernetes.NetworkingGroupVersion.Group,
 	WorkloadEntries:        kubernetes.NetworkingGroupVersion.Group,
 	Adapters:               kubernetes.ConfigGroupVersion.Group,
 	Templates:              kubernetes.ConfigGroupVersion.Group,
@@ -135,6 +138,7 @@ func (in *IstioConfigService) GetIstioConfigList(criteria IstioConfigCriteria) (
 		Gateways:               models.Gateways{},
 		VirtualServices:        models.VirtualServices{Items: []models.VirtualService{}},
 		DestinationRules:       models.DestinationRules{Items: []models.DestinationRule{}},
+		EnvoyFilters:           models.EnvoyFilters{},
 		ServiceEntries:         models.ServiceEntries{},
 		Rules:                  models.IstioRules{},
 		Adapters:               models.IstioAdapters{},
@@ -168,7 +172,7 @@ func (in *IstioConfigService) GetIstioConfigList(criteria IstioConfigCriteria) (
 	errChan := make(chan error, 20)
 
 	var wg sync.WaitGroup
-	wg.Add(22)
+	wg.Add(23)
 
 	go func(errChan chan error) {
 		defer wg.Done()
@@ -227,6 +231,25 @@ func (in *IstioConfigService) GetIstioConfigList(criteria IstioConfigCriteria) (
 		}
 	}(errChan)
 
+	go func(errChan chan error) {
+		defer wg.Done()
+		if criteria.IncludeEnvoyFilters {
+			var ef []kubernetes.IstioObject
+			var efErr error
+			// Check if namespace is cached
+			if kCache != nil && kCache.CheckIstioResource(kubernetes.EnvoyFilterType) && kCache.CheckNamespace(criteria.Namespace) {
+				ef, efErr = kCache.GetIstioResources(kubernetes.EnvoyFilterType, criteria.Namespace)
+			} else {
+				ef, efErr = in.k8s.GetEnvoyFilters(criteria.Namespace)
+			}
+			if efErr == nil {
+				(&istioConfigList.EnvoyFilters).Parse(ef)
+			} else {
+				errChan <- efErr
+			}
+		}
+	}(errChan)
+
 	go func(errChan chan error) {
 		defer wg.Done()
 		if criteria.IncludeServiceEntries {
@@ -530,6 +553,13 @@ func (in *IstioConfigService) GetIstioConfigDetails(namespace, objectType, objec
 		} else {
 			err = iErr
 		}
+	case EnvoyFilters:
+		if ef, iErr := in.k8s.GetEnvoyFilter(namespace, object); iErr == nil {
+			istioConfigDetail.EnvoyFilter = &models.EnvoyFilter{}
+			istioConfigDetail.EnvoyFilter.Parse(ef)
+		} else {
+			err = iErr
+		}
 	case Sidecars:
 		if sc, iErr := in.k8s.GetSidecar(namespace, object); iErr == nil {
 			istioConfigDetail.Sidecar = &models.Sidecar{}
@@ -708,6 +738,9 @@ func (in *IstioConfigService) ParseJsonForCreate(resourceType, subresourceType s
 	case Sidecars:
 		istioConfigDetail.Sidecar = &models.Sidecar{}
 		err = json.Unmarshal(body, istioConfigDetail.Sidecar)
+	case EnvoyFilters:
+		istioConfigDetail.EnvoyFilter = &models.EnvoyFilter{}
+		err = json.Unmarshal(body, istioConfigDetail.EnvoyFilter)
 	case Rules:
 		istioConfigDetail.Rule = &models.IstioRule{}
 		err = json.Unmarshal(body, istioConfigDetail.Rule)
@@ -738,6 +771,21 @@ func (in *IstioConfigService) ParseJsonForCreate(resourceType, subresourceType s
 	case AuthorizationPolicies:
 		istioConfigDetail.AuthorizationPolicy = &models.AuthorizationPolicy{}
 		err = json.Unmarshal(body, istioConfigDetail.AuthorizationPolicy)
+	case PeerAuthentications:
+		istioConfigDetail.PeerAuthentication = &models.PeerAuthentication{}
+		err = json.Unmarshal(body, istioConfigDetail.PeerAuthentication)
+	case RequestAuthentications:
+		istioConfigDetail.RequestAuthentication = &models.RequestAuthentication{}
+		err = json.Unmarshal(body, istioConfigDetail.RequestAuthentication)
+	case WorkloadEntries:
+		istioConfigDetail.WorkloadEntry = &models.WorkloadEntry{}
+		err = json.Unmarshal(body, istioConfigDetail.WorkloadEntry)
+	case ServiceRoles:
+		istioConfigDetail.ServiceRole = &models.ServiceRole{}
+		err = json.Unmarshal(body, istioConfigDetail.ServiceRole)
+	case ServiceRoleBindings:
+		istioConfigDetail.ServiceRoleBinding = &models.ServiceRoleBinding{}
+		err = json.Unmarshal(body, istioConfigDetail.ServiceRoleBinding)
 	default:
 		err = fmt.Errorf("object type not found: %v", resourceType)
 	}
@@ -836,6 +884,9 @@ func (in *IstioConfigService) modifyIstioConfigDetail(api, namespace, resourceTy
 	case Sidecars:
 		istioConfigDetail.Sidecar = &models.Sidecar{}
 		istioConfigDetail.Sidecar.Parse(result)
+	case EnvoyFilters:
+		istioConfigDetail.EnvoyFilter = &models.EnvoyFilter{}
+		istioConfigDetail.EnvoyFilter.Parse(result)
 	case Rules:
 		istioRule := models.CastIstioRule(result)
 		istioConfigDetail.Rule = &istioRule
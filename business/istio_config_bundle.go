@@ -0,0 +1,406 @@
+package business
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+	"github.com/kiali/kiali/util"
+)
+
+// bundleTypes are the namespaced Istio types exported in the bundles, in the order they are applied, with the
+// field of the IstioConfigList holding them
+var bundleTypes = []struct {
+	resourceType string
+	listField    string
+}{
+	{ServiceEntries, "serviceEntries"},
+	{WorkloadEntries, "workloadEntries"},
+	{Gateways, "gateways"},
+	{DestinationRules, "destinationRules"},
+	{VirtualServices, "virtualServices"},
+	{Sidecars, "sidecars"},
+	{EnvoyFilters, "envoyFilters"},
+	{PeerAuthentications, "peerAuthentications"},
+	{RequestAuthentications, "requestAuthentications"},
+	{AuthorizationPolicies, "authorizationPolicies"},
+	{Policies, "policies"},
+	{ServiceMeshPolicies, "serviceMeshPolicies"},
+	{ServiceMeshRbacConfigs, "serviceMeshRbacConfigs"},
+	{ServiceRoles, "serviceRoles"},
+	{ServiceRoleBindings, "serviceRoleBindings"},
+	{QuotaSpecs, "quotaSpecs"},
+	{QuotaSpecBindings, "quotaSpecBindings"},
+}
+
+// bundleMetadata are the fields of the metadata kept in the bundles, the others are set by the cluster
+var bundleMetadata = []string{"name", "namespace", "labels", "annotations"}
+
+// bundleAnnotations are the annotations set by the tools, removed from the bundles
+var bundleAnnotations = []string{"kubectl.kubernetes.io/last-applied-configuration"}
+
+// bundleObject is an Istio object of a bundle, with only the fields needed to apply it
+type bundleObject struct {
+	resourceType string
+	content      map[string]interface{}
+}
+
+func (o bundleObject) metadata() map[string]interface{} {
+	metadata, _ := o.content["metadata"].(map[string]interface{})
+	return metadata
+}
+
+func (o bundleObject) name() string {
+	name, _ := o.metadata()["name"].(string)
+	return name
+}
+
+func (o bundleObject) namespace() string {
+	namespace, _ := o.metadata()["namespace"].(string)
+	return namespace
+}
+
+// ExportIstioConfig returns the Istio objects of the namespaces as a multi-document YAML bundle, ready to be applied
+func (in *IstioConfigService) ExportIstioConfig(namespaces []string) ([]byte, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "ExportIstioConfig")
+	defer promtimer.ObserveNow(&err)
+
+	objects := []bundleObject{}
+	for _, ns := range namespaces {
+		var nsObjects []bundleObject
+		if nsObjects, err = in.fetchBundleObjects(ns); err != nil {
+			return nil, err
+		}
+		objects = append(objects, nsObjects...)
+	}
+	return marshalBundle(objects)
+}
+
+// ImportIstioConfig validates the objects of a multi-document YAML bundle against the current state of their
+// namespaces and, unless it is a dry run, creates them or updates the existing ones. The objects are placed in
+// the target namespace when it is not empty. Nothing is applied when an object is invalid.
+func (in *IstioConfigService) ImportIstioConfig(bundle []byte, targetNamespace string, dryRun bool) (models.IstioConfigImport, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "ImportIstioConfig")
+	defer promtimer.ObserveNow(&err)
+
+	result := models.IstioConfigImport{Objects: []models.IstioConfigImportObject{}, Validations: models.IstioValidations{}}
+	objects, err := unmarshalBundle(bundle, targetNamespace)
+	if err != nil {
+		return result, err
+	}
+
+	// the objects are compared with the existing ones, and validated with them, per namespace
+	existing := map[string]map[string]bundleObject{}
+	proposed := map[string]dryRunObjects{}
+	keys := map[models.IstioValidationKey]bool{}
+	valid := true
+	for _, o := range objects {
+		ns := o.namespace()
+		if _, ok := existing[ns]; !ok {
+			var nsObjects []bundleObject
+			if nsObjects, err = in.fetchBundleObjects(ns); err != nil {
+				return result, err
+			}
+			existing[ns] = map[string]bundleObject{}
+			for _, e := range nsObjects {
+				existing[ns][e.resourceType+"/"+e.name()] = e
+			}
+			if proposed[ns], err = in.businessLayer.Validations.fetchDryRunObjects(ns); err != nil {
+				return result, err
+			}
+		}
+
+		object := models.IstioConfigImportObject{Namespace: ns, ObjectType: o.resourceType, Name: o.name(), Action: models.BundleCreate}
+		if current, ok := existing[ns][o.resourceType+"/"+o.name()]; ok {
+			object.Action = models.BundleUpdate
+			if len(bundlePatch(current.content, o.content)) == 0 {
+				object.Action = models.BundleNone
+			}
+		}
+		if _, err := in.ParseJsonForCreate(o.resourceType, "", bundleBody(o)); err != nil {
+			object.Error = err.Error()
+			valid = false
+		} else if isDryRunSupported(o.resourceType) {
+			generic := &kubernetes.GenericIstioObject{}
+			content, _ := json.Marshal(o.content)
+			if err := json.Unmarshal(content, generic); err == nil {
+				proposed[ns] = proposed[ns].with(o.resourceType, generic)
+				keys[models.BuildKey(models.ObjectTypeSingular[o.resourceType], o.name(), ns)] = true
+			}
+		}
+		result.Objects = append(result.Objects, object)
+	}
+
+	for ns, o := range proposed {
+		for key, validation := range in.businessLayer.Validations.runDryRunCheckers(ns, o) {
+			if keys[key] {
+				result.Validations[key] = validation
+				valid = valid && validation.Valid
+			}
+		}
+	}
+	if dryRun || !valid {
+		return result, nil
+	}
+
+	for i, o := range objects {
+		object := &result.Objects[i]
+		api := GetIstioAPI(o.resourceType)
+		switch object.Action {
+		case models.BundleCreate:
+			_, err = in.CreateIstioConfigDetail(api, object.Namespace, o.resourceType, "", bundleBody(o))
+		case models.BundleUpdate:
+			patch, _ := json.Marshal(bundlePatch(existing[object.Namespace][o.resourceType+"/"+o.name()].content, o.content))
+			_, err = in.UpdateIstioConfigDetail(api, object.Namespace, o.resourceType, "", object.Name, string(patch))
+		default:
+			continue
+		}
+		if err != nil {
+			object.Error = err.Error()
+		} else {
+			object.Applied = true
+		}
+	}
+	err = nil
+	result.Applied = true
+	return result, nil
+}
+
+// fetchBundleObjects returns the Istio objects of a namespace exported in the bundles
+func (in *IstioConfigService) fetchBundleObjects(namespace string) ([]bundleObject, error) {
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err := in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return nil, err
+	}
+	criteria := IstioConfigCriteria{
+		Namespace:                     namespace,
+		IncludeGateways:               true,
+		IncludeVirtualServices:        true,
+		IncludeDestinationRules:       true,
+		IncludeEnvoyFilters:           true,
+		IncludeServiceEntries:         true,
+		IncludeQuotaSpecs:             true,
+		IncludeQuotaSpecBindings:      true,
+		IncludePolicies:               true,
+		IncludeServiceMeshPolicies:    true,
+		IncludeServiceMeshRbacConfigs: true,
+		IncludeServiceRoles:           true,
+		IncludeServiceRoleBindings:    true,
+		IncludeSidecars:               true,
+		IncludeAuthorizationPolicies:  true,
+		IncludePeerAuthentication:     true,
+		IncludeWorkloadEntries:        true,
+		IncludeRequestAuthentications: true,
+	}
+	list, err := in.GetIstioConfigList(criteria)
+	if err != nil {
+		return nil, err
+	}
+	return bundleObjects(list)
+}
+
+// bundleObjects returns the objects of a config list cleaned to be applied: without status and without the
+// metadata set by the cluster, as resourceVersion, uid or managedFields
+func bundleObjects(list models.IstioConfigList) ([]bundleObject, error) {
+	content, err := json.Marshal(list)
+	if err != nil {
+		return nil, err
+	}
+	lists := map[string]json.RawMessage{}
+	if err := json.Unmarshal(content, &lists); err != nil {
+		return nil, err
+	}
+
+	objects := []bundleObject{}
+	for _, bt := range bundleTypes {
+		items := []map[string]interface{}{}
+		if raw, ok := lists[bt.listField]; ok && string(raw) != "null" {
+			if err := json.Unmarshal(raw, &items); err != nil {
+				// some lists are wrapped with their permissions
+				wrapped := struct {
+					Items []map[string]interface{} `json:"items"`
+				}{}
+				if err := json.Unmarshal(raw, &wrapped); err != nil {
+					return nil, err
+				}
+				items = wrapped.Items
+			}
+		}
+		for _, item := range items {
+			objects = append(objects, cleanBundleObject(bt.resourceType, item))
+		}
+	}
+	sortBundle(objects)
+	return objects, nil
+}
+
+func cleanBundleObject(resourceType string, item map[string]interface{}) bundleObject {
+	content := map[string]interface{}{
+		"apiVersion": apiToVersion[GetIstioAPI(resourceType)],
+		"kind":       kubernetes.PluralType[resourceType],
+	}
+	metadata := map[string]interface{}{}
+	if itemMetadata, ok := item["metadata"].(map[string]interface{}); ok {
+		for _, field := range bundleMetadata {
+			if value, ok := itemMetadata[field]; ok && value != nil {
+				metadata[field] = value
+			}
+		}
+	}
+	if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+		for _, a := range bundleAnnotations {
+			delete(annotations, a)
+		}
+		if len(annotations) == 0 {
+			delete(metadata, "annotations")
+		}
+	}
+	content["metadata"] = metadata
+	if spec, ok := item["spec"].(map[string]interface{}); ok {
+		util.RemoveNilValues(spec)
+		content["spec"] = spec
+	}
+	return bundleObject{resourceType: resourceType, content: content}
+}
+
+// sortBundle sorts the objects by namespace, then in the order of the types, then by name
+func sortBundle(objects []bundleObject) {
+	order := map[string]int{}
+	for i, bt := range bundleTypes {
+		order[bt.resourceType] = i
+	}
+	sort.SliceStable(objects, func(i, j int) bool {
+		a, b := objects[i], objects[j]
+		if a.namespace() != b.namespace() {
+			return a.namespace() < b.namespace()
+		}
+		if order[a.resourceType] != order[b.resourceType] {
+			return order[a.resourceType] < order[b.resourceType]
+		}
+		return a.name() < b.name()
+	})
+}
+
+func marshalBundle(objects []bundleObject) ([]byte, error) {
+	bundle := bytes.Buffer{}
+	for _, o := range objects {
+		content, err := yaml.Marshal(o.content)
+		if err != nil {
+			return nil, err
+		}
+		bundle.WriteString("---\n")
+		bundle.Write(content)
+	}
+	return bundle.Bytes(), nil
+}
+
+// unmarshalBundle reads the objects of a multi-document YAML bundle, placed in the target namespace when not empty
+func unmarshalBundle(bundle []byte, targetNamespace string) ([]bundleObject, error) {
+	kinds := map[string]string{}
+	for _, bt := range bundleTypes {
+		kinds[kubernetes.PluralType[bt.resourceType]] = bt.resourceType
+	}
+
+	objects := []bundleObject{}
+	seen := map[string]bool{}
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(bundle), 4096)
+	for i := 1; ; i++ {
+		item := map[string]interface{}{}
+		if err := decoder.Decode(&item); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errors2.NewBadRequest(fmt.Sprintf("document %d of the bundle could not be read: %v", i, err))
+		}
+		if len(item) == 0 {
+			continue
+		}
+		kind, _ := item["kind"].(string)
+		resourceType, ok := kinds[kind]
+		if !ok {
+			return nil, errors2.NewBadRequest(fmt.Sprintf("document %d of the bundle: kind [%s] is not supported", i, kind))
+		}
+		if apiVersion, _ := item["apiVersion"].(string); apiVersion != apiToVersion[GetIstioAPI(resourceType)] {
+			return nil, errors2.NewBadRequest(fmt.Sprintf("document %d of the bundle: apiVersion [%s] is not supported for %s", i, apiVersion, kind))
+		}
+		o := cleanBundleObject(resourceType, item)
+		if targetNamespace != "" {
+			o.metadata()["namespace"] = targetNamespace
+		}
+		if o.name() == "" || o.namespace() == "" {
+			return nil, errors2.NewBadRequest(fmt.Sprintf("document %d of the bundle: name and namespace are required", i))
+		}
+		key := o.namespace() + "/" + resourceType + "/" + o.name()
+		if seen[key] {
+			return nil, errors2.NewBadRequest(fmt.Sprintf("document %d of the bundle: %s is duplicated", i, key))
+		}
+		seen[key] = true
+		objects = append(objects, o)
+	}
+	return objects, nil
+}
+
+// bundleBody returns the object in the format expected by CreateIstioConfigDetail, which sets the type itself
+func bundleBody(o bundleObject) []byte {
+	body := map[string]interface{}{"metadata": o.content["metadata"], "spec": o.content["spec"]}
+	content, _ := json.Marshal(body)
+	return content
+}
+
+// bundlePatch returns the JSON merge patch turning the labels, annotations and spec of an object into the desired
+// ones, empty when there is nothing to change
+func bundlePatch(current, desired map[string]interface{}) map[string]interface{} {
+	currentMetadata, _ := current["metadata"].(map[string]interface{})
+	desiredMetadata, _ := desired["metadata"].(map[string]interface{})
+	patch := mergePatchFields(
+		map[string]interface{}{"spec": current["spec"]},
+		map[string]interface{}{"spec": desired["spec"]})
+	metadataPatch := mergePatchFields(
+		map[string]interface{}{"labels": currentMetadata["labels"], "annotations": currentMetadata["annotations"]},
+		map[string]interface{}{"labels": desiredMetadata["labels"], "annotations": desiredMetadata["annotations"]})
+	if len(metadataPatch) > 0 {
+		patch["metadata"] = metadataPatch
+	}
+	return patch
+}
+
+// mergePatchFields returns the JSON merge patch (RFC 7386) turning an object into another, empty when they are
+// equal. The fields removed are set to null.
+func mergePatchFields(from, to map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for k, v := range from {
+		if t, ok := to[k]; !ok || t == nil {
+			if v != nil {
+				patch[k] = nil
+			}
+		}
+	}
+	for k, t := range to {
+		if t == nil {
+			continue
+		}
+		fromMap, fromIsMap := from[k].(map[string]interface{})
+		toMap, toIsMap := t.(map[string]interface{})
+		if fromIsMap && toIsMap {
+			if diff := mergePatchFields(fromMap, toMap); len(diff) > 0 {
+				patch[k] = diff
+			}
+		} else if !reflect.DeepEqual(from[k], t) {
+			patch[k] = t
+		}
+	}
+	return patch
+}
@@ -0,0 +1,116 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+func TestExportBundle(t *testing.T) {
+	assert := assert.New(t)
+
+	list := models.IstioConfigList{Namespace: models.Namespace{Name: "bookinfo"}}
+	vs := models.VirtualService{}
+	vs.Parse(&kubernetes.GenericIstioObject{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: "reviews", Namespace: "bookinfo", ResourceVersion: "1234", UID: "a-b-c", Generation: 3,
+			Labels:      map[string]string{"app": "reviews"},
+			Annotations: map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}"},
+		},
+		Spec: map[string]interface{}{"hosts": []interface{}{"reviews"}},
+	})
+	list.VirtualServices.Items = []models.VirtualService{vs}
+	gw := models.Gateway{}
+	gw.Parse(&kubernetes.GenericIstioObject{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo-gateway", Namespace: "bookinfo"},
+		Spec:       map[string]interface{}{"selector": map[string]interface{}{"istio": "ingressgateway"}},
+	})
+	list.Gateways = models.Gateways{gw}
+
+	objects, err := bundleObjects(list)
+	assert.NoError(err)
+	bundle, err := marshalBundle(objects)
+	assert.NoError(err)
+	assert.Equal(`---
+apiVersion: networking.istio.io/v1alpha3
+kind: Gateway
+metadata:
+  name: bookinfo-gateway
+  namespace: bookinfo
+spec:
+  selector:
+    istio: ingressgateway
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  labels:
+    app: reviews
+  name: reviews
+  namespace: bookinfo
+spec:
+  hosts:
+  - reviews
+`, string(bundle))
+
+	// the bundle is read back into another namespace
+	imported, err := unmarshalBundle(bundle, "bookinfo-copy")
+	assert.NoError(err)
+	assert.Len(imported, 2)
+	assert.Equal(Gateways, imported[0].resourceType)
+	assert.Equal("bookinfo-copy", imported[1].namespace())
+	assert.Equal("reviews", imported[1].name())
+	assert.Equal(map[string]interface{}{"hosts": []interface{}{"reviews"}}, imported[1].content["spec"])
+}
+
+func TestUnmarshalBundleErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, bundle := range []string{
+		"apiVersion: v1\nkind: Service\nmetadata:\n  name: reviews\n  namespace: bookinfo\n",
+		"apiVersion: networking.istio.io/v1beta1\nkind: VirtualService\nmetadata:\n  name: reviews\n  namespace: bookinfo\n",
+		"apiVersion: networking.istio.io/v1alpha3\nkind: VirtualService\nmetadata:\n  name: reviews\n",
+		"apiVersion: networking.istio.io/v1alpha3\nkind: VirtualService\nmetadata:\n  name: reviews\n  namespace: bookinfo\n" +
+			"---\napiVersion: networking.istio.io/v1alpha3\nkind: VirtualService\nmetadata:\n  name: reviews\n  namespace: bookinfo\n",
+	} {
+		_, err := unmarshalBundle([]byte(bundle), "")
+		assert.Error(err)
+	}
+
+	objects, err := unmarshalBundle([]byte("---\n---\napiVersion: networking.istio.io/v1alpha3\nkind: VirtualService\nmetadata:\n  name: reviews\n"), "bookinfo")
+	assert.NoError(err)
+	assert.Len(objects, 1)
+}
+
+func TestBundlePatch(t *testing.T) {
+	assert := assert.New(t)
+
+	current := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "reviews", "labels": map[string]interface{}{"app": "reviews", "team": "a"}},
+		"spec": map[string]interface{}{
+			"hosts":    []interface{}{"reviews"},
+			"gateways": []interface{}{"bookinfo-gateway"},
+			"http":     []interface{}{map[string]interface{}{"timeout": "1s"}},
+		},
+	}
+	assert.Empty(bundlePatch(current, current))
+
+	desired := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "reviews", "annotations": map[string]interface{}{"owner": "b"}},
+		"spec": map[string]interface{}{
+			"hosts": []interface{}{"reviews"},
+			"http":  []interface{}{map[string]interface{}{"timeout": "2s"}},
+		},
+	}
+	assert.Equal(map[string]interface{}{
+		"metadata": map[string]interface{}{"labels": nil, "annotations": map[string]interface{}{"owner": "b"}},
+		"spec": map[string]interface{}{
+			"gateways": nil,
+			"http":     []interface{}{map[string]interface{}{"timeout": "2s"}},
+		},
+	}, bundlePatch(current, desired))
+}
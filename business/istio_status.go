@@ -1,16 +1,27 @@
 package business
 
 import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
 	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
 )
 
 // SvcService deals with fetching istio/kubernetes services related content and convert to kiali model
 type IstioStatusService struct {
-	k8s kubernetes.IstioClientInterface
+	k8s  kubernetes.IstioClientInterface
+	prom prometheus.ClientInterface
 }
 
 type ComponentStatus struct {
@@ -37,10 +48,18 @@ type IstioComponentStatus []ComponentStatus
 
 const (
 	Healthy   string = "Healthy"
+	Degraded  string = "Degraded"
 	Unhealthy string = "Unhealthy"
 	NotFound  string = "NotFound"
 )
 
+// Label of the revisioned control planes, set on the istiod deployments and pods and on the injected namespaces
+const (
+	istioRevisionLabel  = "istio.io/rev"
+	defaultRevision     = "default"
+	istioInjectionLabel = "istio-injection"
+)
+
 // List of workloads part of a Istio deployment and if whether it is mandatory or not.
 // It follows the default profile
 var components = map[string]map[string]bool{
@@ -108,13 +127,19 @@ func (iss *IstioStatusService) getStatusOf(arch string, ds []apps_v1.Deployment)
 			continue
 		}
 
-		isCore, found := components[arch][d.Name]
+		// The deployments of a revisioned control plane are named istiod-<revision>
+		name := d.Name
+		if _, revisioned := d.Labels[istioRevisionLabel]; revisioned && strings.HasPrefix(name, "istiod-") {
+			name = "istiod"
+		}
+
+		isCore, found := components[arch][name]
 		if !found {
 			continue
 		}
 
 		// Component found
-		cf[d.Name] = true
+		cf[name] = true
 
 		if status := GetDeploymentStatus(d); status != Healthy {
 			// Check status
@@ -150,3 +175,184 @@ func GetDeploymentStatus(d apps_v1.Deployment) string {
 	}
 	return status
 }
+
+// GetControlPlaneStatus returns the health of each revision of istiod, from its deployments and pods and from the
+// metrics reported by the istiod instances. The metrics are best effort, the status only reflects the deployments
+// when Prometheus can't be queried.
+func (iss *IstioStatusService) GetControlPlaneStatus(ratesInterval string, queryTime time.Time) (*models.ControlPlaneStatus, error) {
+	conf := config.Get()
+	ds, err := iss.k8s.GetDeploymentsByLabel(conf.IstioNamespace, conf.ExternalServices.Istio.IstiodLabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := iss.k8s.GetPods(conf.IstioNamespace, conf.ExternalServices.Istio.IstiodLabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	var metrics map[string]model.Vector
+	if iss.prom != nil {
+		if metrics, err = iss.prom.GetControlPlaneMetrics(ratesInterval, queryTime); err != nil {
+			log.Warningf("Control plane metrics could not be read: %v", err)
+			metrics = nil
+		}
+	}
+
+	// The injected namespaces are only informative, they may not be readable with the Kiali permissions
+	namespaces, err := iss.k8s.GetNamespaces("")
+	if err != nil {
+		log.Debugf("Namespaces injected by the control plane revisions could not be read: %v", err)
+	}
+
+	return controlPlaneStatus(ds, pods, metrics, namespaces), nil
+}
+
+func controlPlaneStatus(ds []apps_v1.Deployment, pods []core_v1.Pod, metrics map[string]model.Vector, namespaces []core_v1.Namespace) *models.ControlPlaneStatus {
+	result := &models.ControlPlaneStatus{
+		Status:           NotFound,
+		MetricsAvailable: metrics != nil,
+		Revisions:        []models.ControlPlaneRevision{},
+	}
+
+	revisions := map[string]*models.ControlPlaneRevision{}
+	getRevision := func(labels map[string]string) *models.ControlPlaneRevision {
+		name := revisionOf(labels)
+		if _, found := revisions[name]; !found {
+			revisions[name] = &models.ControlPlaneRevision{Revision: name, Status: Healthy, Istiods: []models.IstiodStatus{}}
+		}
+		return revisions[name]
+	}
+
+	for _, d := range ds {
+		revision := getRevision(d.Labels)
+		if status := GetDeploymentStatus(d); status != Healthy {
+			revision.Status = Unhealthy
+			revision.Issues = append(revision.Issues, fmt.Sprintf("Deployment %s has not all its replicas available", d.Name))
+		}
+	}
+
+	for _, p := range pods {
+		revision := getRevision(p.Labels)
+		istiod := models.IstiodStatus{Name: p.Name, Status: Unhealthy}
+		if isPodReady(p) {
+			istiod.Status = Healthy
+		}
+		if len(p.Spec.Containers) > 0 {
+			if i := strings.LastIndex(p.Spec.Containers[0].Image, ":"); i != -1 {
+				istiod.Version = p.Spec.Containers[0].Image[i+1:]
+			}
+		}
+		if metrics != nil {
+			istiod.Metrics = instanceMetrics(p.Status.PodIP, metrics)
+			revision.Proxies += istiod.Metrics.Proxies
+		}
+		revision.Istiods = append(revision.Istiods, istiod)
+	}
+
+	for _, revision := range revisions {
+		checkRevision(revision)
+		for _, ns := range namespaces {
+			if revisionOf(ns.Labels) == revision.Revision && (ns.Labels[istioRevisionLabel] != "" || ns.Labels[istioInjectionLabel] == "enabled") {
+				revision.Namespaces = append(revision.Namespaces, ns.Name)
+			}
+		}
+		sort.Strings(revision.Namespaces)
+		sort.Slice(revision.Istiods, func(i, j int) bool {
+			return revision.Istiods[i].Name < revision.Istiods[j].Name
+		})
+		result.Revisions = append(result.Revisions, *revision)
+		result.Status = worstStatus(result.Status, revision.Status)
+	}
+	sort.Slice(result.Revisions, func(i, j int) bool {
+		return result.Revisions[i].Revision < result.Revisions[j].Revision
+	})
+
+	return result
+}
+
+// checkRevision sets a revision Unhealthy when none of its istiod instances is ready and Degraded when they report errors
+func checkRevision(revision *models.ControlPlaneRevision) {
+	ready := 0
+	var metrics models.ControlPlaneMetrics
+	for _, istiod := range revision.Istiods {
+		if istiod.Status == Healthy {
+			ready++
+		}
+		if istiod.Metrics != nil {
+			metrics.PushErrors += istiod.Metrics.PushErrors
+			metrics.RejectedConfigs += istiod.Metrics.RejectedConfigs
+			metrics.ConflictingListeners += istiod.Metrics.ConflictingListeners
+			metrics.InjectionFailures += istiod.Metrics.InjectionFailures
+		}
+	}
+	if ready == 0 {
+		revision.Status = Unhealthy
+		revision.Issues = append(revision.Issues, "No istiod instance is ready")
+	}
+
+	issues := []string{}
+	if metrics.PushErrors > 0 {
+		issues = append(issues, fmt.Sprintf("xDS pushes are failing (%.2f/s)", metrics.PushErrors))
+	}
+	if metrics.RejectedConfigs > 0 {
+		issues = append(issues, fmt.Sprintf("Configurations are rejected by the proxies (%.2f/s)", metrics.RejectedConfigs))
+	}
+	if metrics.ConflictingListeners > 0 {
+		issues = append(issues, fmt.Sprintf("%d listeners are conflicting", metrics.ConflictingListeners))
+	}
+	if metrics.InjectionFailures > 0 {
+		issues = append(issues, fmt.Sprintf("Sidecar injections are failing (%.2f/s)", metrics.InjectionFailures))
+	}
+	if len(issues) > 0 {
+		revision.Status = worstStatus(revision.Status, Degraded)
+		revision.Issues = append(revision.Issues, issues...)
+	}
+}
+
+// instanceMetrics returns the metrics of the istiod instance scraped at the pod IP
+func instanceMetrics(podIP string, metrics map[string]model.Vector) *models.ControlPlaneMetrics {
+	value := func(name string) float64 {
+		for _, sample := range metrics[name] {
+			if host, _, err := net.SplitHostPort(string(sample.Metric["instance"])); err == nil && host == podIP {
+				return float64(sample.Value)
+			}
+		}
+		return 0
+	}
+	return &models.ControlPlaneMetrics{
+		Proxies:              int(value(prometheus.ControlPlaneProxies)),
+		Pushes:               value(prometheus.ControlPlanePushes),
+		PushErrors:           value(prometheus.ControlPlanePushErrors),
+		RejectedConfigs:      value(prometheus.ControlPlaneRejectedConfigs),
+		ConflictingListeners: int(value(prometheus.ControlPlaneConflictingListeners)),
+		InjectionFailures:    value(prometheus.ControlPlaneInjectionFailures),
+	}
+}
+
+func revisionOf(labels map[string]string) string {
+	if revision, found := labels[istioRevisionLabel]; found && revision != "" {
+		return revision
+	}
+	return defaultRevision
+}
+
+func isPodReady(p core_v1.Pod) bool {
+	if p.Status.Phase != core_v1.PodRunning {
+		return false
+	}
+	for _, c := range p.Status.Conditions {
+		if c.Type == core_v1.PodReady {
+			return c.Status == core_v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// worstStatus returns the most severe of two statuses, NotFound being the least severe as a status with no revision
+func worstStatus(s1, s2 string) string {
+	severity := map[string]int{NotFound: 0, Healthy: 1, Degraded: 2, Unhealthy: 3}
+	if severity[s2] > severity[s1] {
+		return s2
+	}
+	return s1
+}
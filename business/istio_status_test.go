@@ -1,15 +1,21 @@
 package business
 
 import (
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/prometheus/prometheustest"
 )
 
 var healthyStatus = apps_v1.DeploymentStatus{
@@ -202,6 +208,150 @@ func TestMixerComp(t *testing.T) {
 	shutdown()
 }
 
+func TestRevisionedIstiodComp(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	pods := []apps_v1.Deployment{
+		fakeDeploymentWithStatus("istiod-1-7-0", map[string]string{"app": "istiod", "istio.io/rev": "1-7-0"}, healthyStatus),
+		fakeDeploymentWithStatus("istiod-1-8-0", map[string]string{"app": "istiod", "istio.io/rev": "1-8-0"}, unhealthyStatus),
+	}
+
+	k8s := mockDeploymentCall(pods, true)
+	iss := IstioStatusService{k8s: k8s}
+
+	icsl, error := iss.GetStatus()
+	assert.NoError(error)
+	assertComponent(assert, icsl, "istiod-1-8-0", Unhealthy, true)
+
+	// A revisioned istiod is not reported as missing
+	assertNotPresent(assert, icsl, "istiod")
+	assertNotPresent(assert, icsl, "istiod-1-7-0")
+
+	// Cleaning up environment
+	shutdown()
+}
+
+func TestGetControlPlaneStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	queryTime := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	istiodPod := func(name, revision, ip string, ready core_v1.ConditionStatus) core_v1.Pod {
+		return core_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name, Labels: map[string]string{"app": "istiod", "istio.io/rev": revision}},
+			Spec:       core_v1.PodSpec{Containers: []core_v1.Container{{Name: "discovery", Image: "docker.io/istio/pilot:" + strings.Replace(revision, "-", ".", -1)}}},
+			Status: core_v1.PodStatus{
+				Phase:      core_v1.PodRunning,
+				PodIP:      ip,
+				Conditions: []core_v1.PodCondition{{Type: core_v1.PodReady, Status: ready}},
+			},
+		}
+	}
+	sample := func(ip string, value float64) *model.Sample {
+		return &model.Sample{Metric: model.Metric{"instance": model.LabelValue(ip + ":15014")}, Value: model.SampleValue(value)}
+	}
+
+	k8s := new(kubetest.K8SClientMock)
+	k8s.On("GetDeploymentsByLabel", "istio-system", "app=istiod").Return([]apps_v1.Deployment{
+		fakeDeploymentWithStatus("istiod-1-7-0", map[string]string{"app": "istiod", "istio.io/rev": "1-7-0"}, healthyStatus),
+		fakeDeploymentWithStatus("istiod-1-8-0", map[string]string{"app": "istiod", "istio.io/rev": "1-8-0"}, healthyStatus),
+	}, nil)
+	k8s.On("GetPods", "istio-system", "app=istiod").Return([]core_v1.Pod{
+		istiodPod("istiod-1-7-0-b", "1-7-0", "10.1.0.6", core_v1.ConditionTrue),
+		istiodPod("istiod-1-7-0-a", "1-7-0", "10.1.0.5", core_v1.ConditionFalse),
+		istiodPod("istiod-1-8-0-a", "1-8-0", "10.1.0.7", core_v1.ConditionTrue),
+	}, nil)
+	k8s.On("GetNamespaces").Return([]core_v1.Namespace{
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo", Labels: map[string]string{"istio.io/rev": "1-8-0"}}},
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "travels", Labels: map[string]string{"istio.io/rev": "1-7-0"}}},
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "default"}},
+	}, nil)
+
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetControlPlaneMetrics", "10m", queryTime).Return(map[string]model.Vector{
+		prometheus.ControlPlaneProxies:              {sample("10.1.0.5", 3), sample("10.1.0.6", 4), sample("10.1.0.7", 2)},
+		prometheus.ControlPlanePushes:               {sample("10.1.0.6", 0.5), sample("10.1.0.7", 0.2)},
+		prometheus.ControlPlanePushErrors:           {sample("10.1.0.7", 0.1)},
+		prometheus.ControlPlaneConflictingListeners: {sample("10.1.0.7", 1)},
+	}, nil)
+
+	iss := IstioStatusService{k8s: k8s, prom: prom}
+	status, err := iss.GetControlPlaneStatus("10m", queryTime)
+	assert.NoError(err)
+	assert.True(status.MetricsAvailable)
+	assert.Equal(Degraded, status.Status)
+	assert.Len(status.Revisions, 2)
+
+	stable := status.Revisions[0]
+	assert.Equal("1-7-0", stable.Revision)
+	assert.Equal(Healthy, stable.Status)
+	assert.Empty(stable.Issues)
+	assert.Equal(7, stable.Proxies)
+	assert.Equal([]string{"travels"}, stable.Namespaces)
+	assert.Len(stable.Istiods, 2)
+	assert.Equal("istiod-1-7-0-a", stable.Istiods[0].Name)
+	assert.Equal(Unhealthy, stable.Istiods[0].Status)
+	assert.Equal("1.7.0", stable.Istiods[0].Version)
+	assert.Equal(0.5, stable.Istiods[1].Metrics.Pushes)
+
+	canary := status.Revisions[1]
+	assert.Equal("1-8-0", canary.Revision)
+	assert.Equal(Degraded, canary.Status)
+	assert.Len(canary.Issues, 2)
+	assert.Equal(2, canary.Proxies)
+	assert.Equal([]string{"bookinfo"}, canary.Namespaces)
+	assert.Equal(1, canary.Istiods[0].Metrics.ConflictingListeners)
+}
+
+func TestGetControlPlaneStatusWithoutMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := new(kubetest.K8SClientMock)
+	k8s.On("GetDeploymentsByLabel", "istio-system", "app=istiod").Return([]apps_v1.Deployment{
+		fakeDeploymentWithStatus("istiod", map[string]string{"app": "istiod"}, unhealthyStatus),
+	}, nil)
+	k8s.On("GetPods", "istio-system", "app=istiod").Return([]core_v1.Pod{}, nil)
+	k8s.On("GetNamespaces").Return([]core_v1.Namespace{
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo", Labels: map[string]string{"istio-injection": "enabled"}}},
+	}, nil)
+
+	iss := IstioStatusService{k8s: k8s}
+	status, err := iss.GetControlPlaneStatus("10m", time.Now())
+	assert.NoError(err)
+	assert.False(status.MetricsAvailable)
+	assert.Equal(Unhealthy, status.Status)
+	assert.Len(status.Revisions, 1)
+	assert.Equal("default", status.Revisions[0].Revision)
+	assert.Equal([]string{"bookinfo"}, status.Revisions[0].Namespaces)
+	assert.Len(status.Revisions[0].Issues, 2)
+	assert.Empty(status.Revisions[0].Istiods)
+}
+
+func TestGetControlPlaneStatusNotFound(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Set(config.NewConfig())
+
+	k8s := new(kubetest.K8SClientMock)
+	k8s.On("GetDeploymentsByLabel", "istio-system", "app=istiod").Return([]apps_v1.Deployment{}, nil)
+	k8s.On("GetPods", "istio-system", "app=istiod").Return([]core_v1.Pod{}, nil)
+	k8s.On("GetNamespaces").Return([]core_v1.Namespace{}, nil)
+
+	iss := IstioStatusService{k8s: k8s}
+	status, err := iss.GetControlPlaneStatus("10m", time.Now())
+	assert.NoError(err)
+	assert.Equal(NotFound, status.Status)
+	assert.Empty(status.Revisions)
+}
+
 func assertComponent(assert *assert.Assertions, icsl IstioComponentStatus, name string, status string, isCore bool) {
 	componentFound := false
 	for _, ics := range icsl {
@@ -2,13 +2,18 @@ package business
 
 import (
 	"fmt"
+	"net/http"
 	"sync"
+	"time"
 
 	apps_v1 "k8s.io/api/apps/v1"
 	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/kiali/kiali/business/checkers"
+	"github.com/kiali/kiali/business/checkers/custom"
+	"github.com/kiali/kiali/business/checkers/requestauthentications"
+	"github.com/kiali/kiali/business/checkers/serviceentries"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/log"
@@ -16,6 +21,40 @@ import (
 	"github.com/kiali/kiali/prometheus/internalmetrics"
 )
 
+// remoteClusterClient returns the client of a remote cluster, replaced in tests
+var remoteClusterClient = kubernetes.GetRemoteClusterClient
+
+// remoteServicesTTL is how long the Services of a remote namespace are kept. They are read on every validation,
+// twice for a dry-run.
+const remoteServicesTTL = time.Minute
+
+// remoteServicesCache keeps the Services of the remote namespaces by cluster and namespace, and the namespaces
+// of the clusters that could not be read
+var remoteServicesCache = struct {
+	sync.Mutex
+	entries map[remoteServicesKey]remoteServicesEntry
+}{entries: map[remoteServicesKey]remoteServicesEntry{}}
+
+type remoteServicesKey struct {
+	cluster   string
+	namespace string
+}
+
+type remoteServicesEntry struct {
+	services  []core_v1.Service
+	reachable bool
+	fetched   time.Time
+}
+
+// jwksClient returns the client fetching the jwksUri of the RequestAuthentications, nil when they are not fetched.
+// It is replaced in tests.
+var jwksClient = func() requestauthentications.HTTPClient {
+	if !config.Get().Validations.FetchJwks {
+		return nil
+	}
+	return &http.Client{Timeout: 5 * time.Second}
+}
+
 type IstioValidationsService struct {
 	k8s           kubernetes.IstioClientInterface
 	businessLayer *Layer
@@ -57,8 +96,9 @@ func (in *IstioValidationsService) GetValidations(namespace, service string) (mo
 	var mtlsDetails kubernetes.MTLSDetails
 	var rbacDetails kubernetes.RBACDetails
 	var deployments []apps_v1.Deployment
+	var customRules []*custom.Rule
 
-	wg.Add(7) // We need to add these here to make sure we don't execute wg.Wait() before scheduler has started goroutines
+	wg.Add(8) // We need to add these here to make sure we don't execute wg.Wait() before scheduler has started goroutines
 
 	if service != "" {
 		// These resources are not used if no service is targeted
@@ -75,6 +115,7 @@ func (in *IstioValidationsService) GetValidations(namespace, service string) (mo
 	go in.fetchNonLocalmTLSConfigs(&mtlsDetails, namespace, errChan, &wg)
 	go in.fetchAuthorizationDetails(&rbacDetails, namespace, errChan, &wg)
 	go in.fetchServices(&services, namespace, errChan, &wg)
+	go in.fetchCustomRules(&customRules, errChan, &wg)
 
 	wg.Wait()
 	close(errChan)
@@ -84,14 +125,17 @@ func (in *IstioValidationsService) GetValidations(namespace, service string) (mo
 		}
 	}
 
-	objectCheckers := in.getAllObjectCheckers(namespace, istioDetails, services, workloads, gatewaysPerNamespace, mtlsDetails, rbacDetails, namespaces)
+	remoteServices := fetchRemoteServices(istioDetails.ServiceEntries)
+	objectCheckers := in.getAllObjectCheckers(namespace, istioDetails, services, workloads, gatewaysPerNamespace, mtlsDetails, rbacDetails, namespaces, remoteServices)
+	objectCheckers = append(objectCheckers, checkers.CustomRulesChecker{Rules: customRules, Namespace: namespace, IstioDetails: istioDetails,
+		MTLSDetails: mtlsDetails, RBACDetails: rbacDetails, Services: services, WorkloadList: workloads})
 
 	if service != "" {
 		objectCheckers = append(objectCheckers, in.getServiceCheckers(namespace, services, deployments, pods)...)
 	}
 
 	// Get group validations for same kind istio objects
-	validations := runObjectCheckers(objectCheckers)
+	validations := suppressChecks(runObjectCheckers(objectCheckers), istioDetails, mtlsDetails, rbacDetails, gatewaysPerNamespace, services)
 	if service != "" {
 		validations = validations.FilterBySingleType("service", service)
 	}
@@ -105,7 +149,7 @@ func (in *IstioValidationsService) getServiceCheckers(namespace string, services
 	}
 }
 
-func (in *IstioValidationsService) getAllObjectCheckers(namespace string, istioDetails kubernetes.IstioDetails, services []core_v1.Service, workloads models.WorkloadList, gatewaysPerNamespace [][]kubernetes.IstioObject, mtlsDetails kubernetes.MTLSDetails, rbacDetails kubernetes.RBACDetails, namespaces []models.Namespace) []ObjectChecker {
+func (in *IstioValidationsService) getAllObjectCheckers(namespace string, istioDetails kubernetes.IstioDetails, services []core_v1.Service, workloads models.WorkloadList, gatewaysPerNamespace [][]kubernetes.IstioObject, mtlsDetails kubernetes.MTLSDetails, rbacDetails kubernetes.RBACDetails, namespaces []models.Namespace, remoteServices serviceentries.RemoteServices) []ObjectChecker {
 	return []ObjectChecker{
 		checkers.NoServiceChecker{Namespace: namespace, Namespaces: namespaces, IstioDetails: &istioDetails, Services: services, WorkloadList: workloads, GatewaysPerNamespace: gatewaysPerNamespace, AuthorizationDetails: &rbacDetails},
 		checkers.VirtualServiceChecker{Namespace: namespace, Namespaces: namespaces, DestinationRules: istioDetails.DestinationRules, VirtualServices: istioDetails.VirtualServices},
@@ -113,10 +157,12 @@ func (in *IstioValidationsService) getAllObjectCheckers(namespace string, istioD
 		checkers.GatewayChecker{GatewaysPerNamespace: gatewaysPerNamespace, Namespace: namespace, WorkloadList: workloads},
 		checkers.ServiceMeshPolicyChecker{ServiceMeshPolicies: mtlsDetails.ServiceMeshPolicies, MTLSDetails: mtlsDetails},
 		checkers.PeerAuthenticationChecker{PeerAuthentications: mtlsDetails.PeerAuthentications, MTLSDetails: mtlsDetails},
-		checkers.ServiceEntryChecker{ServiceEntries: istioDetails.ServiceEntries},
+		checkers.ServiceEntryChecker{ServiceEntries: istioDetails.ServiceEntries, Clusters: config.Get().MultiCluster.Clusters, RemoteServices: remoteServices},
 		checkers.ServiceRoleBindChecker{RBACDetails: rbacDetails},
-		checkers.AuthorizationPolicyChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, Namespace: namespace, Namespaces: namespaces, Services: services, ServiceEntries: istioDetails.ServiceEntries, WorkloadList: workloads},
+		checkers.AuthorizationPolicyChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, Namespace: namespace, Namespaces: namespaces, RequestAuthentications: allRequestAuthentications(rbacDetails), Services: services, ServiceEntries: istioDetails.ServiceEntries, WorkloadList: workloads},
+		checkers.RequestAuthenticationChecker{RequestAuthentications: rbacDetails.RequestAuthentications, WorkloadList: workloads, JwksClient: jwksClient()},
 		checkers.SidecarChecker{Sidecars: istioDetails.Sidecars, Namespaces: namespaces, WorkloadList: workloads, Services: services, ServiceEntries: istioDetails.ServiceEntries},
+		checkers.EnvoyFilterChecker{EnvoyFilters: istioDetails.EnvoyFilters, WorkloadList: workloads, Services: services, ServiceEntries: istioDetails.ServiceEntries, GatewaysPerNamespace: gatewaysPerNamespace},
 	}
 }
 
@@ -132,6 +178,7 @@ func (in *IstioValidationsService) GetIstioObjectValidations(namespace string, o
 	var gatewaysPerNamespace [][]kubernetes.IstioObject
 	var mtlsDetails kubernetes.MTLSDetails
 	var rbacDetails kubernetes.RBACDetails
+	var customRules []*custom.Rule
 
 	var objectCheckers []ObjectChecker
 
@@ -145,7 +192,7 @@ func (in *IstioValidationsService) GetIstioObjectValidations(namespace string, o
 	errChan := make(chan error, 1)
 
 	// Get all the Istio objects from a Namespace and all gateways from every namespace
-	wg.Add(7)
+	wg.Add(8)
 	go in.fetchNamespaces(&namespaces, errChan, &wg)
 	go in.fetchDetails(&istioDetails, namespace, errChan, &wg)
 	go in.fetchServices(&services, namespace, errChan, &wg)
@@ -153,6 +200,7 @@ func (in *IstioValidationsService) GetIstioObjectValidations(namespace string, o
 	go in.fetchGatewaysPerNamespace(&gatewaysPerNamespace, errChan, &wg)
 	go in.fetchNonLocalmTLSConfigs(&mtlsDetails, namespace, errChan, &wg)
 	go in.fetchAuthorizationDetails(&rbacDetails, namespace, errChan, &wg)
+	go in.fetchCustomRules(&customRules, errChan, &wg)
 	wg.Wait()
 
 	noServiceChecker := checkers.NoServiceChecker{Namespace: namespace, Namespaces: namespaces, IstioDetails: &istioDetails, Services: services, WorkloadList: workloads, GatewaysPerNamespace: gatewaysPerNamespace, AuthorizationDetails: &rbacDetails}
@@ -172,7 +220,8 @@ func (in *IstioValidationsService) GetIstioObjectValidations(namespace string, o
 		smPoliciesChecker := checkers.ServiceMeshPolicyChecker{ServiceMeshPolicies: mtlsDetails.ServiceMeshPolicies, MTLSDetails: mtlsDetails}
 		objectCheckers = []ObjectChecker{smPoliciesChecker}
 	case ServiceEntries:
-		serviceEntryChecker := checkers.ServiceEntryChecker{ServiceEntries: istioDetails.ServiceEntries}
+		serviceEntryChecker := checkers.ServiceEntryChecker{ServiceEntries: istioDetails.ServiceEntries,
+			Clusters: config.Get().MultiCluster.Clusters, RemoteServices: fetchRemoteServices(istioDetails.ServiceEntries)}
 		objectCheckers = []ObjectChecker{serviceEntryChecker}
 	case Rules:
 		// Validations on Istio Rules are not yet in place
@@ -196,9 +245,14 @@ func (in *IstioValidationsService) GetIstioObjectValidations(namespace string, o
 		sidecarsChecker := checkers.SidecarChecker{Sidecars: istioDetails.Sidecars, Namespaces: namespaces,
 			WorkloadList: workloads, Services: services, ServiceEntries: istioDetails.ServiceEntries}
 		objectCheckers = []ObjectChecker{sidecarsChecker}
+	case EnvoyFilters:
+		envoyFiltersChecker := checkers.EnvoyFilterChecker{EnvoyFilters: istioDetails.EnvoyFilters, WorkloadList: workloads,
+			Services: services, ServiceEntries: istioDetails.ServiceEntries, GatewaysPerNamespace: gatewaysPerNamespace}
+		objectCheckers = []ObjectChecker{envoyFiltersChecker}
 	case AuthorizationPolicies:
 		authPoliciesChecker := checkers.AuthorizationPolicyChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies,
-			Namespace: namespace, Namespaces: namespaces, Services: services, ServiceEntries: istioDetails.ServiceEntries, WorkloadList: workloads}
+			Namespace: namespace, Namespaces: namespaces, RequestAuthentications: allRequestAuthentications(rbacDetails),
+			Services: services, ServiceEntries: istioDetails.ServiceEntries, WorkloadList: workloads}
 		objectCheckers = []ObjectChecker{authPoliciesChecker}
 	case ServiceRoles:
 		objectCheckers = []ObjectChecker{noServiceChecker}
@@ -212,7 +266,9 @@ func (in *IstioValidationsService) GetIstioObjectValidations(namespace string, o
 	case WorkloadEntries:
 		// Validation on WorkloadEntries
 	case RequestAuthentications:
-		// Validation on RequestAuthentications
+		requestAuthnChecker := checkers.RequestAuthenticationChecker{RequestAuthentications: rbacDetails.RequestAuthentications,
+			WorkloadList: workloads, JwksClient: jwksClient()}
+		objectCheckers = []ObjectChecker{requestAuthnChecker}
 	default:
 		err = fmt.Errorf("object type not found: %v", objectType)
 	}
@@ -227,8 +283,18 @@ func (in *IstioValidationsService) GetIstioObjectValidations(namespace string, o
 	if objectCheckers == nil {
 		return models.IstioValidations{}, err
 	}
+	objectCheckers = append(objectCheckers, checkers.CustomRulesChecker{Rules: customRules, Namespace: namespace, IstioDetails: istioDetails,
+		MTLSDetails: mtlsDetails, RBACDetails: rbacDetails, Services: services, WorkloadList: workloads})
 
-	return runObjectCheckers(objectCheckers).FilterByKey(models.ObjectTypeSingular[objectType], object), nil
+	validations := suppressChecks(runObjectCheckers(objectCheckers), istioDetails, mtlsDetails, rbacDetails, gatewaysPerNamespace, services)
+	return validations.FilterByKey(models.ObjectTypeSingular[objectType], object), nil
+}
+
+// allRequestAuthentications returns the RequestAuthentications of the namespace and of the mesh
+func allRequestAuthentications(rbacDetails kubernetes.RBACDetails) []kubernetes.IstioObject {
+	ras := make([]kubernetes.IstioObject, 0, len(rbacDetails.RequestAuthentications)+len(rbacDetails.MeshRequestAuthentications))
+	ras = append(ras, rbacDetails.RequestAuthentications...)
+	return append(ras, rbacDetails.MeshRequestAuthentications...)
 }
 
 func runObjectCheckers(objectCheckers []ObjectChecker) models.IstioValidations {
@@ -343,6 +409,104 @@ func (in *IstioValidationsService) fetchServices(rValue *[]core_v1.Service, name
 	}
 }
 
+// fetchCustomRules reads the custom validation rules, when configured. A missing ConfigMap means no
+// rules, an unreadable ConfigMap and invalid rules are logged and skipped so they do not break the built-in
+// validations.
+func (in *IstioValidationsService) fetchCustomRules(rValue *[]*custom.Rule, errChan chan error, wg *sync.WaitGroup) {
+	defer wg.Done()
+	cfg := config.Get()
+	if len(errChan) != 0 || cfg.Validations.CustomRulesConfigMap == "" {
+		return
+	}
+	cm, err := in.k8s.GetConfigMap(cfg.Deployment.Namespace, cfg.Validations.CustomRulesConfigMap)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			log.Errorf("Unable to read the custom validation rules ConfigMap [%s/%s]: %v", cfg.Deployment.Namespace, cfg.Validations.CustomRulesConfigMap, err)
+		}
+		return
+	}
+	rules, err := custom.RulesFromConfigMap(cm)
+	if err != nil {
+		log.Errorf("%v", err)
+	}
+	*rValue = rules
+}
+
+// fetchRemoteServices reads, from the clusters targeted by the .global ServiceEntries, the Services of the
+// namespaces of their hosts. Clusters without kubeconfig or not reachable are left out, so they are not checked.
+func fetchRemoteServices(serviceEntries []kubernetes.IstioObject) serviceentries.RemoteServices {
+	remoteServices := serviceentries.RemoteServices{}
+	clusters := config.Get().MultiCluster.Clusters
+	if len(clusters) == 0 {
+		return remoteServices
+	}
+
+	unreachable := map[string]bool{}
+	for _, se := range serviceEntries {
+		hosts := serviceentries.GlobalHosts(se)
+		if len(hosts) == 0 {
+			continue
+		}
+		for _, cluster := range serviceentries.TargetClusters(se, clusters) {
+			if unreachable[cluster.Name] {
+				continue
+			}
+			if _, ok := remoteServices[cluster.Name]; !ok {
+				remoteServices[cluster.Name] = map[string][]core_v1.Service{}
+			}
+			for _, h := range hosts {
+				if _, fetched := remoteServices[cluster.Name][h.Namespace]; !h.Valid || fetched {
+					continue
+				}
+				services, reachable := remoteNamespaceServices(cluster, h.Namespace)
+				if !reachable {
+					delete(remoteServices, cluster.Name)
+					unreachable[cluster.Name] = true
+					break
+				}
+				remoteServices[cluster.Name][h.Namespace] = services
+			}
+		}
+	}
+	return remoteServices
+}
+
+// remoteNamespaceServices returns the Services of a namespace of a remote cluster, and false when the cluster has
+// no kubeconfig or can't be read. The cache is not locked while the cluster is read, concurrent reads of an
+// expired entry read it again.
+func remoteNamespaceServices(cluster config.RemoteCluster, namespace string) ([]core_v1.Service, bool) {
+	key := remoteServicesKey{cluster: cluster.Name, namespace: namespace}
+	remoteServicesCache.Lock()
+	entry, found := remoteServicesCache.entries[key]
+	remoteServicesCache.Unlock()
+	if found && time.Since(entry.fetched) < remoteServicesTTL {
+		return entry.services, entry.reachable
+	}
+
+	entry = remoteServicesEntry{}
+	if client, err := remoteClusterClient(cluster); err != nil || client == nil {
+		if err != nil {
+			log.Warningf("Unable to connect to cluster [%s]: %v", cluster.Name, err)
+		}
+	} else if services, err := client.GetServices(namespace, nil); err != nil {
+		log.Warningf("Unable to read the Services of namespace [%s] in cluster [%s]: %v", namespace, cluster.Name, err)
+	} else {
+		entry.services, entry.reachable = services, true
+	}
+
+	remoteServicesCache.Lock()
+	defer remoteServicesCache.Unlock()
+	now := time.Now()
+	for k, e := range remoteServicesCache.entries {
+		if now.Sub(e.fetched) >= remoteServicesTTL {
+			delete(remoteServicesCache.entries, k)
+		}
+	}
+	entry.fetched = now
+	remoteServicesCache.entries[key] = entry
+	return entry.services, entry.reachable
+}
+
 func (in *IstioValidationsService) fetchDeployments(rValue *[]apps_v1.Deployment, namespace string, errChan chan error, wg *sync.WaitGroup) {
 	defer wg.Done()
 	if len(errChan) == 0 {
@@ -410,7 +574,7 @@ func (in *IstioValidationsService) fetchDetails(rValue *kubernetes.IstioDetails,
 	if len(errChan) == 0 {
 		var err error
 		wg2 := sync.WaitGroup{}
-		errChan2 := make(chan error, 5)
+		errChan2 := make(chan error, 6)
 		istioDetails := kubernetes.IstioDetails{}
 
 		// Check if namespace is cached
@@ -446,6 +610,12 @@ func (in *IstioValidationsService) fetchDetails(rValue *kubernetes.IstioDetails,
 			wg2.Add(1)
 			go fetchNoEntry(&istioDetails.Sidecars, namespace, in.k8s.GetSidecars, &wg2, errChan2)
 		}
+		if nsCached && kialiCache.CheckIstioResource(kubernetes.EnvoyFilterType) {
+			istioDetails.EnvoyFilters, err = kialiCache.GetIstioResources(kubernetes.EnvoyFilterType, namespace)
+		} else {
+			wg2.Add(1)
+			go fetchNoEntry(&istioDetails.EnvoyFilters, namespace, in.k8s.GetEnvoyFilters, &wg2, errChan2)
+		}
 		wg2.Wait()
 
 		// Error may come either from errChan2 (when goroutines are used / without cache) or err (with cache / synchronous)
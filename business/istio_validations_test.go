@@ -1,6 +1,7 @@
 package business
 
 import (
+	"sync"
 	"testing"
 
 	osapps_v1 "github.com/openshift/api/apps/v1"
@@ -10,8 +11,11 @@ import (
 	batch_v1 "k8s.io/api/batch/v1"
 	batch_v1beta1 "k8s.io/api/batch/v1beta1"
 	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/kiali/kiali/business/checkers/custom"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/kubernetes/kubetest"
@@ -44,6 +48,28 @@ func TestGetIstioObjectValidations(t *testing.T) {
 	assert.NotEmpty(validations)
 }
 
+func TestFetchCustomRulesUnreadableConfigMap(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	conf.Validations.CustomRulesConfigMap = "kiali-validation-rules"
+	config.Set(conf)
+
+	k8s := new(kubetest.K8SClientMock)
+	forbidden := errors.NewForbidden(schema.GroupResource{Resource: "configmaps"}, "kiali-validation-rules", nil)
+	k8s.On("GetConfigMap", conf.Deployment.Namespace, "kiali-validation-rules").Return((*core_v1.ConfigMap)(nil), forbidden)
+	vs := IstioValidationsService{k8s: k8s}
+
+	// The validations go on without custom rules
+	var rules []*custom.Rule
+	errChan := make(chan error, 1)
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	vs.fetchCustomRules(&rules, errChan, &wg)
+	wg.Wait()
+	assert.Empty(rules)
+	assert.Len(errChan, 0)
+}
+
 func TestGatewayValidation(t *testing.T) {
 	assert := assert.New(t)
 	conf := config.NewConfig()
@@ -54,6 +80,52 @@ func TestGatewayValidation(t *testing.T) {
 	assert.NotEmpty(validations)
 }
 
+func TestFetchRemoteServices(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	conf.MultiCluster.Clusters = []config.RemoteCluster{
+		{Name: "east", GatewayAddresses: []string{"10.0.0.1"}, Kubeconfig: "/kiali/east/kubeconfig"},
+		{Name: "west", GatewayAddresses: []string{"10.0.0.2"}},
+	}
+	config.Set(conf)
+
+	east := new(kubetest.K8SClientMock)
+	east.On("GetServices", "bookinfo", mock.AnythingOfType("map[string]string")).Return(fakeCombinedServices([]string{"reviews"}), nil)
+	clientCalls := 0
+	defer func() { remoteClusterClient = kubernetes.GetRemoteClusterClient }()
+	remoteClusterClient = func(cluster config.RemoteCluster) (kubernetes.IstioClientInterface, error) {
+		clientCalls++
+		if cluster.Name == "east" {
+			return east, nil
+		}
+		return nil, nil
+	}
+	remoteServicesCache.entries = map[remoteServicesKey]remoteServicesEntry{}
+	defer func() { remoteServicesCache.entries = map[remoteServicesKey]remoteServicesEntry{} }()
+
+	// the clusters are not read without .global hosts
+	local := data.CreateEmptyMeshExternalServiceEntry("reviews", "bookinfo", []string{"reviews.bookinfo.svc.cluster.local"})
+	assert.Empty(fetchRemoteServices([]kubernetes.IstioObject{local}))
+	assert.Equal(0, clientCalls)
+
+	se := data.AddEndpointsToServiceEntry([]string{"10.0.0.1", "10.0.0.2"},
+		data.CreateEmptyMeshExternalServiceEntry("reviews-global", "bookinfo", []string{"reviews.bookinfo.global", "ratings.bookinfo.global"}))
+	remoteServices := fetchRemoteServices([]kubernetes.IstioObject{se})
+
+	// the namespace is read once, the cluster without kubeconfig is unknown
+	east.AssertNumberOfCalls(t, "GetServices", 1)
+	assert.Len(remoteServices, 1)
+	assert.Len(remoteServices["east"]["bookinfo"], 1)
+
+	// the Services and the unknown clusters are cached
+	clientCalls = 0
+	remoteServices = fetchRemoteServices([]kubernetes.IstioObject{se})
+	east.AssertNumberOfCalls(t, "GetServices", 1)
+	assert.Equal(0, clientCalls)
+	assert.Len(remoteServices, 1)
+	assert.Len(remoteServices["east"]["bookinfo"], 1)
+}
+
 func mockWorkLoadService(k8s *kubetest.K8SClientMock) WorkloadService {
 	// Setup mocks
 	k8s.On("IsOpenShift").Return(true)
@@ -82,6 +154,7 @@ func mockMultiNamespaceGatewaysValidationService() IstioValidationsService {
 	mockWorkLoadService(k8s)
 	k8s.On("GetDestinationRules", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(fakeCombinedIstioDetails().DestinationRules, nil)
 	k8s.On("GetSidecars", mock.AnythingOfType("string")).Return(fakeCombinedIstioDetails().Sidecars, nil)
+	k8s.On("GetEnvoyFilters", mock.AnythingOfType("string")).Return([]kubernetes.IstioObject{}, nil)
 	k8s.On("GetServices", mock.AnythingOfType("string"), mock.AnythingOfType("map[string]string")).Return(fakeCombinedServices([]string{""}), nil)
 	k8s.On("GetDeployments", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(FakeDepSyncedWithRS(), nil)
 	k8s.On("GetMeshPolicies", mock.AnythingOfType("string")).Return(fakeMeshPolicies(), nil)
@@ -96,6 +169,7 @@ func mockMultiNamespaceGatewaysValidationService() IstioValidationsService {
 func mockCombinedValidationService(istioObjects *kubernetes.IstioDetails, services []string, podList *core_v1.PodList) IstioValidationsService {
 	k8s := new(kubetest.K8SClientMock)
 	k8s.On("GetSidecars", mock.AnythingOfType("string")).Return(istioObjects.Sidecars, nil)
+	k8s.On("GetEnvoyFilters", mock.AnythingOfType("string")).Return([]kubernetes.IstioObject{}, nil)
 	k8s.On("GetServices", mock.AnythingOfType("string"), mock.AnythingOfType("map[string]string")).Return(fakeCombinedServices(services), nil)
 	k8s.On("GetDeployments", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(FakeDepSyncedWithRS(), nil)
 	k8s.On("GetVirtualServices", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(fakeCombinedIstioDetails().VirtualServices, nil)
@@ -17,23 +17,26 @@ import (
 
 // Layer is a container for fast access to inner services
 type Layer struct {
-	Svc            SvcService
-	Health         HealthService
-	Validations    IstioValidationsService
-	IstioConfig    IstioConfigService
-	Workload       WorkloadService
-	App            AppService
-	Namespace      NamespaceService
-	Jaeger         JaegerService
-	k8s            kubernetes.IstioClientInterface
-	OpenshiftOAuth OpenshiftOAuthService
-	TLS            TLSService
-	Replicase      ReplicaseService
-	ThreeScale     ThreeScaleService
-	Iter8          Iter8Service
-	IstioStatus    IstioStatusService
-	PromAddress    string
-	Host           string
+	Svc               SvcService
+	Health            HealthService
+	Validations       IstioValidationsService
+	IstioConfig       IstioConfigService
+	Workload          WorkloadService
+	App               AppService
+	Namespace         NamespaceService
+	Jaeger            JaegerService
+	k8s               kubernetes.IstioClientInterface
+	OpenshiftOAuth    OpenshiftOAuthService
+	TLS               TLSService
+	Replicase         ReplicaseService
+	ThreeScale        ThreeScaleService
+	Iter8             Iter8Service
+	IstioStatus       IstioStatusService
+	SidecarScope      SidecarScopeService
+	ValidationHistory ValidationHistoryService
+	Proxy             ProxyService
+	PromAddress       string
+	Host              string
 }
 
 // Global clientfactory and prometheus clients.
@@ -203,10 +206,16 @@ func NewWithBackends(k8s kubernetes.IstioClientInterface, prom prometheus.Client
 		businessLayer: temporaryLayer,
 	}
 	temporaryLayer.OpenshiftOAuth = OpenshiftOAuthService{k8s: k8s}
-	temporaryLayer.TLS = TLSService{k8s: k8s, businessLayer: temporaryLayer}
+	temporaryLayer.TLS = TLSService{k8s: k8s, prom: prom, businessLayer: temporaryLayer}
 	temporaryLayer.ThreeScale = ThreeScaleService{k8s: k8s}
 	temporaryLayer.Iter8 = Iter8Service{k8s: k8s, businessLayer: temporaryLayer}
-	temporaryLayer.IstioStatus = IstioStatusService{k8s: k8s}
+	temporaryLayer.IstioStatus = IstioStatusService{k8s: k8s, prom: prom}
+	temporaryLayer.SidecarScope = SidecarScopeService{k8s: k8s, businessLayer: temporaryLayer}
+	temporaryLayer.ValidationHistory = ValidationHistoryService{businessLayer: temporaryLayer}
+	// The proxy admin and istiod debug clients are only implemented by the real client
+	proxyAdmin, _ := k8s.(kubernetes.ProxyAdminClient)
+	istiodDebug, _ := k8s.(kubernetes.IstiodDebugClient)
+	temporaryLayer.Proxy = ProxyService{k8s: k8s, proxyAdmin: proxyAdmin, istiodDebug: istiodDebug, businessLayer: temporaryLayer}
 
 	return temporaryLayer
 }
@@ -0,0 +1,483 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// mtlsDestination is a port of a service backed by a workload
+type mtlsDestination struct {
+	workload   models.WorkloadMTLS
+	service    string
+	port       uint32
+	targetPort uint32
+}
+
+// WorkloadMTLSStatus returns the effective mTLS mode accepted by a workload
+func (in *TLSService) WorkloadMTLSStatus(namespace, workload string) (models.WorkloadMTLS, error) {
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err := in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return models.WorkloadMTLS{}, err
+	}
+
+	w, err := fetchWorkload(in.businessLayer, namespace, workload)
+	if err != nil {
+		return models.WorkloadMTLS{}, err
+	}
+	pas, err := in.k8s.GetPeerAuthentications(namespace)
+	if err != nil {
+		return models.WorkloadMTLS{}, err
+	}
+
+	return resolveWorkloadMTLS(namespace, w.Name, w.Labels, w.IstioSidecar, pas, in.getMeshPeerAuthentications()), nil
+}
+
+// MTLSMatrix returns the effective mTLS status of the traffic from every workload of the namespaces to every port
+// of the services of the namespaces, by destination workload. When a rate interval is given, the status is compared
+// with the connection_security_policy observed in the telemetry over the interval. The telemetry does not tell the
+// port, so the policies observed for a service are compared with all its ports.
+func (in *TLSService) MTLSMatrix(namespaces []string, rateInterval string, queryTime time.Time) (models.MTLSMatrix, error) {
+	autoMTLS := in.hasAutoMTLSEnabled()
+	matrix := models.MTLSMatrix{
+		AutoMTLS:  autoMTLS,
+		Workloads: []models.WorkloadMTLS{},
+		Cells:     []models.MTLSMatrixCell{},
+	}
+
+	meshPAs := in.getMeshPeerAuthentications()
+	drs, err := in.getAllDestinationRules(namespaces)
+	if err != nil {
+		return matrix, err
+	}
+	drs = append(drs, in.getRootNamespaceDestinationRules(namespaces)...)
+
+	sources := []models.WorkloadMTLS{}
+	destinations := []mtlsDestination{}
+	for _, namespace := range namespaces {
+		if _, err := in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+			return matrix, err
+		}
+		workloads, err := fetchWorkloads(in.businessLayer, namespace, "")
+		if err != nil {
+			return matrix, err
+		}
+		pas, err := in.k8s.GetPeerAuthentications(namespace)
+		if err != nil {
+			return matrix, err
+		}
+		services, err := in.k8s.GetServices(namespace, nil)
+		if err != nil {
+			return matrix, err
+		}
+
+		for _, w := range workloads {
+			wMTLS := resolveWorkloadMTLS(namespace, w.Name, w.Labels, w.IstioSidecar, pas, meshPAs)
+			matrix.Workloads = append(matrix.Workloads, wMTLS)
+			sources = append(sources, wMTLS)
+			destinations = append(destinations, workloadDestinations(wMTLS, w.Labels, services)...)
+		}
+	}
+
+	observed, err := in.observedSecurityPolicies(namespaces, rateInterval, queryTime)
+	if err != nil {
+		return matrix, err
+	}
+
+	for _, source := range sources {
+		for _, destination := range destinations {
+			cell := mtlsMatrixCell(source, destination, drs, autoMTLS)
+			cell.Observed = observed[observedKey(source.Namespace, source.Workload, destination.workload.Namespace,
+				destination.workload.Workload, destination.service)]
+			cell.Mismatch = mtlsMismatch(cell.Status, cell.Observed)
+			matrix.Cells = append(matrix.Cells, cell)
+		}
+	}
+
+	return matrix, nil
+}
+
+// getMeshPeerAuthentications returns the PeerAuthentications of the control plane namespace, none when the user
+// cannot access it
+func (in *TLSService) getMeshPeerAuthentications() []kubernetes.IstioObject {
+	istioNamespace := config.Get().IstioNamespace
+	pas, err := in.k8s.GetPeerAuthentications(istioNamespace)
+	if err != nil {
+		log.Warningf("GetPeerAuthentications failed during a TLS validation. Probably user can't access to %s namespace. Error: %s", istioNamespace, err)
+		return nil
+	}
+	return pas
+}
+
+// getRootNamespaceDestinationRules returns the DestinationRules of the control plane namespace, applying to the
+// whole mesh, when it is not one of the namespaces already fetched
+func (in *TLSService) getRootNamespaceDestinationRules(namespaces []string) []kubernetes.IstioObject {
+	istioNamespace := config.Get().IstioNamespace
+	for _, namespace := range namespaces {
+		if namespace == istioNamespace {
+			return nil
+		}
+	}
+	drs, err := in.k8s.GetDestinationRules(istioNamespace, "")
+	if err != nil {
+		log.Warningf("GetDestinationRules failed during a TLS validation. Probably user can't access to %s namespace. Error: %s", istioNamespace, err)
+		return nil
+	}
+	return drs
+}
+
+// observedSecurityPolicies returns the connection_security_policy reported by the destinations of the traffic of
+// the namespaces, by source workload, destination workload and service
+func (in *TLSService) observedSecurityPolicies(namespaces []string, rateInterval string, queryTime time.Time) (map[string][]string, error) {
+	observed := map[string][]string{}
+	if in.prom == nil || rateInterval == "" {
+		return observed, nil
+	}
+
+	policies := map[string]map[string]bool{}
+	for _, namespace := range namespaces {
+		rates, err := in.prom.GetAllRequestRates(namespace, rateInterval, queryTime)
+		if err != nil {
+			return nil, err
+		}
+		for _, sample := range rates {
+			m := sample.Metric
+			// only the destination proxy knows the security policy of the connection
+			if m["reporter"] != "destination" || sample.Value == 0 {
+				continue
+			}
+			key := observedKey(string(m["source_workload_namespace"]), string(m["source_workload"]),
+				string(m["destination_workload_namespace"]), string(m["destination_workload"]), string(m["destination_service_name"]))
+			if policies[key] == nil {
+				policies[key] = map[string]bool{}
+			}
+			policies[key][string(m["connection_security_policy"])] = true
+		}
+	}
+
+	for key, set := range policies {
+		for policy := range set {
+			observed[key] = append(observed[key], policy)
+		}
+		sort.Strings(observed[key])
+	}
+	return observed, nil
+}
+
+func observedKey(sourceNamespace, sourceWorkload, destinationNamespace, destinationWorkload, service string) string {
+	return strings.Join([]string{sourceNamespace, sourceWorkload, destinationNamespace, destinationWorkload, service}, "/")
+}
+
+// resolveWorkloadMTLS returns the mode accepted by a workload. As in Istio, the mode of the oldest PeerAuthentication
+// selecting the workload applies, then the one of the namespace and then the one of the mesh when it is UNSET.
+// The port level modes are only taken from the PeerAuthentication selecting the workload.
+func resolveWorkloadMTLS(namespace, workload string, workloadLabels map[string]string, sidecar bool, pas, meshPAs []kubernetes.IstioObject) models.WorkloadMTLS {
+	w := models.WorkloadMTLS{
+		Namespace:    namespace,
+		Workload:     workload,
+		IstioSidecar: sidecar,
+		Mode:         "PERMISSIVE",
+	}
+	// There is no proxy to terminate the mTLS
+	if !sidecar {
+		w.Mode = "DISABLE"
+		return w
+	}
+
+	var workloadPA kubernetes.IstioObject
+	for _, pa := range oldestFirst(pas) {
+		if pa.HasMatchLabelsSelector() && selectorMatches(pa, workloadLabels) {
+			workloadPA = pa
+			break
+		}
+	}
+
+	for _, pa := range []kubernetes.IstioObject{workloadPA, namespaceWidePeerAuthn(pas), namespaceWidePeerAuthn(meshPAs)} {
+		if pa == nil {
+			continue
+		}
+		if mode := peerAuthnMode(pa.GetSpec()["mtls"]); mode != "" {
+			w.Mode = mode
+			w.PeerAuthentication = pa.GetObjectMeta().Namespace + "/" + pa.GetObjectMeta().Name
+			break
+		}
+	}
+
+	if workloadPA != nil {
+		if portLevel, ok := workloadPA.GetSpec()["portLevelMtls"].(map[string]interface{}); ok {
+			for key, mtls := range portLevel {
+				port, err := strconv.ParseUint(key, 10, 32)
+				if err != nil {
+					continue
+				}
+				if mode := peerAuthnMode(mtls); mode != "" {
+					if w.PortModes == nil {
+						w.PortModes = map[uint32]string{}
+					}
+					w.PortModes[uint32(port)] = mode
+				}
+			}
+		}
+	}
+
+	return w
+}
+
+// peerAuthnMode returns the mode of a PeerAuthentication mtls field, empty when it is inherited
+func peerAuthnMode(mtls interface{}) string {
+	if mtlsMap, ok := mtls.(map[string]interface{}); ok {
+		if mode, ok := mtlsMap["mode"].(string); ok && mode != "UNSET" {
+			return mode
+		}
+	}
+	return ""
+}
+
+// namespaceWidePeerAuthn returns the oldest PeerAuthentication without selector
+func namespaceWidePeerAuthn(pas []kubernetes.IstioObject) kubernetes.IstioObject {
+	for _, pa := range oldestFirst(pas) {
+		if !pa.HasMatchLabelsSelector() {
+			return pa
+		}
+	}
+	return nil
+}
+
+func oldestFirst(objects []kubernetes.IstioObject) []kubernetes.IstioObject {
+	sorted := make([]kubernetes.IstioObject, len(objects))
+	copy(sorted, objects)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		mi, mj := sorted[i].GetObjectMeta(), sorted[j].GetObjectMeta()
+		if !mi.CreationTimestamp.Equal(&mj.CreationTimestamp) {
+			return mi.CreationTimestamp.Before(&mj.CreationTimestamp)
+		}
+		return mi.Name < mj.Name
+	})
+	return sorted
+}
+
+func selectorMatches(object kubernetes.IstioObject, workloadLabels map[string]string) bool {
+	selector, ok := object.GetSpec()["selector"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	matchLabels, ok := selector["matchLabels"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for name, value := range matchLabels {
+		if workloadLabels[name] != fmt.Sprintf("%v", value) {
+			return false
+		}
+	}
+	return true
+}
+
+// workloadDestinations returns the ports of the services selecting the workload
+func workloadDestinations(workload models.WorkloadMTLS, workloadLabels map[string]string, services []core_v1.Service) []mtlsDestination {
+	destinations := []mtlsDestination{}
+	for _, svc := range services {
+		if len(svc.Spec.Selector) == 0 || !labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(workloadLabels)) {
+			continue
+		}
+		for _, port := range svc.Spec.Ports {
+			// The PeerAuthentication ports are the workload ports. A named target port is not resolved.
+			targetPort := uint32(port.TargetPort.IntVal)
+			if targetPort == 0 {
+				targetPort = uint32(port.Port)
+			}
+			destinations = append(destinations, mtlsDestination{
+				workload:   workload,
+				service:    svc.Name,
+				port:       uint32(port.Port),
+				targetPort: targetPort,
+			})
+		}
+	}
+	return destinations
+}
+
+func mtlsMatrixCell(source models.WorkloadMTLS, destination mtlsDestination, drs []kubernetes.IstioObject, autoMTLS bool) models.MTLSMatrixCell {
+	cell := models.MTLSMatrixCell{
+		SourceNamespace:      source.Namespace,
+		SourceWorkload:       source.Workload,
+		DestinationNamespace: destination.workload.Namespace,
+		DestinationWorkload:  destination.workload.Workload,
+		DestinationService:   destination.service,
+		Port:                 destination.port,
+		ServerMode:           destination.workload.Mode,
+	}
+	if mode, found := destination.workload.PortModes[destination.targetPort]; found {
+		cell.ServerMode = mode
+	}
+
+	drMode := ""
+	if source.IstioSidecar {
+		if dr := destinationRuleFor(source.Namespace, destination.service, destination.workload.Namespace, drs); dr != nil {
+			drMode = destinationRuleTLSMode(dr, destination.port)
+			if drMode != "" {
+				cell.DestinationRule = dr.GetObjectMeta().Namespace + "/" + dr.GetObjectMeta().Name
+			}
+		}
+	}
+	cell.ClientMode, cell.Status = mtlsPairStatus(source.IstioSidecar, drMode, cell.ServerMode, autoMTLS)
+	return cell
+}
+
+// mtlsPairStatus returns the TLS mode used by a client and the resulting status of the traffic. Without TLS mode
+// in the DestinationRule, the auto mTLS sends mTLS to the destinations accepting it and plain text otherwise.
+func mtlsPairStatus(sourceSidecar bool, drMode, serverMode string, autoMTLS bool) (string, string) {
+	clientMode := drMode
+	if !sourceSidecar {
+		clientMode = "DISABLE"
+	} else if clientMode == "" {
+		clientMode = "DISABLE"
+		if autoMTLS && serverMode != "DISABLE" {
+			clientMode = "ISTIO_MUTUAL"
+		}
+	}
+
+	mutual := clientMode == "ISTIO_MUTUAL" || clientMode == "MUTUAL"
+	switch serverMode {
+	case "STRICT":
+		if mutual {
+			return clientMode, MTLSEnabled
+		}
+		return clientMode, MTLSConflict
+	case "DISABLE":
+		if mutual {
+			return clientMode, MTLSConflict
+		}
+		return clientMode, MTLSDisabled
+	default:
+		if mutual {
+			return clientMode, MTLSEnabled
+		}
+		return clientMode, MTLSDisabled
+	}
+}
+
+// mtlsMismatch tells whether the policies observed in the telemetry differ from the expected status. No traffic
+// is expected to get through a conflict.
+func mtlsMismatch(status string, observed []string) bool {
+	for _, policy := range observed {
+		switch status {
+		case MTLSEnabled:
+			if policy != "mutual_tls" {
+				return true
+			}
+		case MTLSDisabled:
+			if policy == "mutual_tls" {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// destinationRuleFor returns the DestinationRule applied by Istio to the traffic from a namespace to a service:
+// the DestinationRules of the client namespace are looked up first, then the ones of the service namespace and
+// then the ones of the control plane namespace. In a namespace, the most specific host wins.
+func destinationRuleFor(sourceNamespace, service, serviceNamespace string, drs []kubernetes.IstioObject) kubernetes.IstioObject {
+	fqdn := fmt.Sprintf("%s.%s.%s", service, serviceNamespace, config.Get().ExternalServices.Istio.IstioIdentityDomain)
+	for _, namespace := range []string{sourceNamespace, serviceNamespace, config.Get().IstioNamespace} {
+		var best kubernetes.IstioObject
+		bestSpecificity := 0
+		for _, dr := range oldestFirst(drs) {
+			if dr.GetObjectMeta().Namespace != namespace || !exportedTo(dr, sourceNamespace) {
+				continue
+			}
+			host, _ := dr.GetSpec()["host"].(string)
+			if specificity := hostSpecificity(host, dr.GetObjectMeta().Namespace, service, serviceNamespace, fqdn); specificity > bestSpecificity {
+				best, bestSpecificity = dr, specificity
+			}
+		}
+		if best != nil {
+			return best
+		}
+	}
+	return nil
+}
+
+// hostSpecificity returns how specifically a DestinationRule host matches the service, 0 when it does not match
+func hostSpecificity(host, drNamespace, service, serviceNamespace, fqdn string) int {
+	if strings.HasPrefix(host, "*") {
+		if strings.HasSuffix(fqdn, host[1:]) {
+			return len(host)
+		}
+		return 0
+	}
+	// short names are resolved in the namespace of the DestinationRule
+	if !strings.Contains(host, ".") && drNamespace != serviceNamespace {
+		return 0
+	}
+	if kubernetes.FilterByHost(host, service, serviceNamespace) {
+		return len(fqdn) + 1
+	}
+	return 0
+}
+
+// exportedTo tells whether an object is visible from a namespace
+func exportedTo(object kubernetes.IstioObject, namespace string) bool {
+	exportTo, ok := object.GetSpec()["exportTo"].([]interface{})
+	if !ok || len(exportTo) == 0 {
+		return true
+	}
+	for _, e := range exportTo {
+		switch e {
+		case "*", namespace:
+			return true
+		case ".":
+			if object.GetObjectMeta().Namespace == namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// destinationRuleTLSMode returns the TLS mode of a DestinationRule for a port: the mode of the port level settings,
+// or else the one of the traffic policy
+func destinationRuleTLSMode(dr kubernetes.IstioObject, port uint32) string {
+	trafficPolicy, ok := dr.GetSpec()["trafficPolicy"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	if settings, ok := trafficPolicy["portLevelSettings"].([]interface{}); ok {
+		for _, setting := range settings {
+			settingMap, ok := setting.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			portMap, _ := settingMap["port"].(map[string]interface{})
+			if number, ok := portMap["number"]; ok && fmt.Sprintf("%v", number) == strconv.FormatUint(uint64(port), 10) {
+				if mode := tlsMode(settingMap["tls"]); mode != "" {
+					return mode
+				}
+			}
+		}
+	}
+	return tlsMode(trafficPolicy["tls"])
+}
+
+func tlsMode(tls interface{}) string {
+	if tlsMap, ok := tls.(map[string]interface{}); ok {
+		if mode, ok := tlsMap["mode"].(string); ok {
+			return mode
+		}
+	}
+	return ""
+}
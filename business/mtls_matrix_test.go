@@ -0,0 +1,192 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestResolveWorkloadMTLS(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	meshPAs := []kubernetes.IstioObject{data.CreateEmptyMeshPeerAuthentication("default", data.CreateMTLS("STRICT"))}
+	workloadPA := data.AddSelectorToPeerAuthn(data.CreateOneLabelSelector("reviews"),
+		data.CreateEmptyPeerAuthentication("reviews", "bookinfo", data.CreateMTLS("UNSET")))
+	workloadPA.GetSpec()["portLevelMtls"] = map[string]interface{}{
+		"9080": data.CreateMTLS("DISABLE"),
+		"9090": data.CreateMTLS("UNSET"),
+	}
+	nsPAs := []kubernetes.IstioObject{
+		workloadPA,
+		data.CreateEmptyPeerAuthentication("default", "bookinfo", data.CreateMTLS("PERMISSIVE")),
+	}
+
+	// The UNSET mode of the workload is inherited from the namespace
+	w := resolveWorkloadMTLS("bookinfo", "reviews-v1", map[string]string{"app": "reviews"}, true, nsPAs, meshPAs)
+	assert.Equal("PERMISSIVE", w.Mode)
+	assert.Equal("bookinfo/default", w.PeerAuthentication)
+	assert.Equal(map[uint32]string{9080: "DISABLE"}, w.PortModes)
+
+	// Without namespace mode, the mesh one applies
+	w = resolveWorkloadMTLS("bookinfo", "reviews-v1", map[string]string{"app": "reviews"}, true, nsPAs[:1], meshPAs)
+	assert.Equal("STRICT", w.Mode)
+	assert.Equal("istio-system/default", w.PeerAuthentication)
+
+	// The workload PeerAuthentication does not select other workloads
+	w = resolveWorkloadMTLS("bookinfo", "ratings-v1", map[string]string{"app": "ratings"}, true, nsPAs, meshPAs)
+	assert.Equal("PERMISSIVE", w.Mode)
+	assert.Empty(w.PortModes)
+
+	// The default mode is PERMISSIVE
+	w = resolveWorkloadMTLS("bookinfo", "ratings-v1", map[string]string{"app": "ratings"}, true, nil, nil)
+	assert.Equal("PERMISSIVE", w.Mode)
+	assert.Empty(w.PeerAuthentication)
+
+	// A workload without sidecar only accepts plain text
+	w = resolveWorkloadMTLS("bookinfo", "reviews-v1", map[string]string{"app": "reviews"}, false, nsPAs, meshPAs)
+	assert.Equal("DISABLE", w.Mode)
+}
+
+func TestResolveWorkloadMTLSOldestWins(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	older := data.CreateEmptyPeerAuthentication("older", "bookinfo", data.CreateMTLS("STRICT"))
+	newer := data.CreateEmptyPeerAuthentication("newer", "bookinfo", data.CreateMTLS("DISABLE"))
+	meta := older.GetObjectMeta()
+	meta.CreationTimestamp = meta_v1.Unix(1000, 0)
+	older.SetObjectMeta(meta)
+	meta = newer.GetObjectMeta()
+	meta.CreationTimestamp = meta_v1.Unix(2000, 0)
+	newer.SetObjectMeta(meta)
+
+	w := resolveWorkloadMTLS("bookinfo", "reviews-v1", nil, true, []kubernetes.IstioObject{newer, older}, nil)
+	assert.Equal("STRICT", w.Mode)
+	assert.Equal("bookinfo/older", w.PeerAuthentication)
+}
+
+func TestDestinationRuleFor(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	mesh := data.CreateEmptyDestinationRule("istio-system", "mesh", "*.local")
+	namespace := data.CreateEmptyDestinationRule("bookinfo", "namespace", "*.bookinfo.svc.cluster.local")
+	service := data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews")
+	client := data.CreateEmptyDestinationRule("client", "reviews", "reviews.bookinfo.svc.cluster.local")
+	other := data.CreateEmptyDestinationRule("other", "reviews", "reviews")
+	drs := []kubernetes.IstioObject{mesh, namespace, service, client, other}
+
+	// The most specific host of the service namespace
+	assert.Equal(service, destinationRuleFor("bookinfo", "reviews", "bookinfo", drs))
+	// The client namespace first
+	assert.Equal(client, destinationRuleFor("client", "reviews", "bookinfo", drs))
+	// A short name is resolved in the namespace of the DestinationRule
+	assert.Equal(service, destinationRuleFor("other", "reviews", "bookinfo", drs))
+	assert.Equal(namespace, destinationRuleFor("bookinfo", "ratings", "bookinfo", drs))
+	assert.Equal(mesh, destinationRuleFor("bookinfo", "details", "default", drs))
+
+	// A DestinationRule not exported is only visible from its namespace
+	client.GetSpec()["exportTo"] = []interface{}{"."}
+	service.GetSpec()["exportTo"] = []interface{}{"."}
+	assert.Equal(namespace, destinationRuleFor("foo", "reviews", "bookinfo", drs))
+	assert.Equal(service, destinationRuleFor("bookinfo", "reviews", "bookinfo", drs))
+}
+
+func TestDestinationRuleTLSMode(t *testing.T) {
+	assert := assert.New(t)
+
+	dr := data.AddTrafficPolicyToDestinationRule(data.CreateTLSPortLevelTrafficPolicyForDestinationRules(),
+		data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews"))
+	dr.GetSpec()["trafficPolicy"].(map[string]interface{})["tls"] = map[string]interface{}{"mode": "ISTIO_MUTUAL"}
+
+	assert.Equal("SIMPLE", destinationRuleTLSMode(dr, 9080))
+	assert.Equal("ISTIO_MUTUAL", destinationRuleTLSMode(dr, 9090))
+	assert.Empty(destinationRuleTLSMode(data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews"), 9080))
+}
+
+func TestMTLSPairStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		sidecar    bool
+		drMode     string
+		serverMode string
+		autoMTLS   bool
+		clientMode string
+		status     string
+	}{
+		{true, "", "STRICT", true, "ISTIO_MUTUAL", MTLSEnabled},
+		{true, "", "PERMISSIVE", true, "ISTIO_MUTUAL", MTLSEnabled},
+		{true, "", "DISABLE", true, "DISABLE", MTLSDisabled},
+		{true, "", "STRICT", false, "DISABLE", MTLSConflict},
+		{true, "", "PERMISSIVE", false, "DISABLE", MTLSDisabled},
+		{true, "DISABLE", "STRICT", true, "DISABLE", MTLSConflict},
+		{true, "ISTIO_MUTUAL", "DISABLE", true, "ISTIO_MUTUAL", MTLSConflict},
+		{true, "SIMPLE", "PERMISSIVE", true, "SIMPLE", MTLSDisabled},
+		{false, "ISTIO_MUTUAL", "STRICT", true, "DISABLE", MTLSConflict},
+	}
+	for _, c := range cases {
+		clientMode, status := mtlsPairStatus(c.sidecar, c.drMode, c.serverMode, c.autoMTLS)
+		assert.Equal(c.clientMode, clientMode, "%+v", c)
+		assert.Equal(c.status, status, "%+v", c)
+	}
+}
+
+func TestMTLSMismatch(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(mtlsMismatch(MTLSEnabled, nil))
+	assert.False(mtlsMismatch(MTLSEnabled, []string{"mutual_tls"}))
+	assert.True(mtlsMismatch(MTLSEnabled, []string{"mutual_tls", "none"}))
+	assert.False(mtlsMismatch(MTLSDisabled, []string{"none"}))
+	assert.True(mtlsMismatch(MTLSDisabled, []string{"mutual_tls"}))
+	assert.True(mtlsMismatch(MTLSConflict, []string{"none"}))
+}
+
+func TestMTLSMatrixCell(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	source := models.WorkloadMTLS{Namespace: "bookinfo", Workload: "productpage-v1", IstioSidecar: true, Mode: "PERMISSIVE"}
+	reviews := models.WorkloadMTLS{Namespace: "bookinfo", Workload: "reviews-v1", IstioSidecar: true, Mode: "STRICT",
+		PortModes: map[uint32]string{9080: "PERMISSIVE"}}
+	services := []core_v1.Service{{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"},
+		Spec: core_v1.ServiceSpec{
+			Selector: map[string]string{"app": "reviews"},
+			Ports: []core_v1.ServicePort{
+				{Name: "http", Port: 80, TargetPort: intstr.FromInt(9080)},
+				{Name: "grpc", Port: 9090},
+			},
+		},
+	}}
+	destinations := workloadDestinations(reviews, map[string]string{"app": "reviews", "version": "v1"}, services)
+	assert.Len(destinations, 2)
+	assert.Empty(workloadDestinations(reviews, map[string]string{"app": "ratings"}, services))
+
+	dr := data.AddTrafficPolicyToDestinationRule(data.CreateDisabledMTLSTrafficPolicyForDestinationRules(),
+		data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews"))
+
+	// The port level mode of the target port applies
+	cell := mtlsMatrixCell(source, destinations[0], []kubernetes.IstioObject{dr}, true)
+	assert.Equal(uint32(80), cell.Port)
+	assert.Equal("PERMISSIVE", cell.ServerMode)
+	assert.Equal("DISABLE", cell.ClientMode)
+	assert.Equal("bookinfo/reviews", cell.DestinationRule)
+	assert.Equal(MTLSDisabled, cell.Status)
+
+	cell = mtlsMatrixCell(source, destinations[1], nil, true)
+	assert.Equal("STRICT", cell.ServerMode)
+	assert.Equal("ISTIO_MUTUAL", cell.ClientMode)
+	assert.Empty(cell.DestinationRule)
+	assert.Equal(MTLSEnabled, cell.Status)
+}
@@ -0,0 +1,134 @@
+package business
+
+import (
+	"sort"
+
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/business/checkers"
+	"github.com/kiali/kiali/business/checkers/custom"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// offlineNamespace holds the manifests of one namespace, split like the objects fetched by GetValidations
+type offlineNamespace struct {
+	istioDetails kubernetes.IstioDetails
+	rbacDetails  kubernetes.RBACDetails
+	peerAuthns   []kubernetes.IstioObject
+	meshPolicies []kubernetes.IstioObject
+	services     []core_v1.Service
+	deployments  []apps_v1.Deployment
+}
+
+// GetOfflineValidations runs the Istio checkers against manifests instead of the objects of a cluster, so
+// the config can be validated before it is applied, i.e. in a CI pipeline. Objects without namespace are
+// placed in defaultNamespace. Only the namespaces found in the manifests exist, and the mesh is assumed to
+// run with auto mTLS, the Istio default. The custom rules, if any, run as well.
+func GetOfflineValidations(manifests *kubernetes.Manifests, defaultNamespace string, customRules []*custom.Rule) models.IstioValidations {
+	manifests.SetDefaultNamespace(defaultNamespace)
+
+	namespaceObjects := map[string]*offlineNamespace{}
+	nsObjects := func(namespace string) *offlineNamespace {
+		if _, ok := namespaceObjects[namespace]; !ok {
+			// an empty, not nil, list of services: the checkers skip the namespace when services are unknown
+			namespaceObjects[namespace] = &offlineNamespace{services: []core_v1.Service{}}
+		}
+		return namespaceObjects[namespace]
+	}
+
+	allDestinationRules := []kubernetes.IstioObject{}
+	for _, o := range manifests.IstioObjects {
+		ns := nsObjects(o.GetObjectMeta().Namespace)
+		switch o.GetTypeMeta().Kind {
+		case kubernetes.VirtualServiceType:
+			ns.istioDetails.VirtualServices = append(ns.istioDetails.VirtualServices, o)
+		case kubernetes.DestinationRuleType:
+			ns.istioDetails.DestinationRules = append(ns.istioDetails.DestinationRules, o)
+			allDestinationRules = append(allDestinationRules, o)
+		case kubernetes.ServiceentryType:
+			ns.istioDetails.ServiceEntries = append(ns.istioDetails.ServiceEntries, o)
+		case kubernetes.GatewayType:
+			ns.istioDetails.Gateways = append(ns.istioDetails.Gateways, o)
+		case kubernetes.SidecarType:
+			ns.istioDetails.Sidecars = append(ns.istioDetails.Sidecars, o)
+		case kubernetes.EnvoyFilterType:
+			ns.istioDetails.EnvoyFilters = append(ns.istioDetails.EnvoyFilters, o)
+		case kubernetes.PeerAuthenticationsType:
+			ns.peerAuthns = append(ns.peerAuthns, o)
+		case "ServiceMeshPolicy":
+			ns.meshPolicies = append(ns.meshPolicies, o)
+		case kubernetes.AuthorizationPoliciesType:
+			ns.rbacDetails.AuthorizationPolicies = append(ns.rbacDetails.AuthorizationPolicies, o)
+		case "ClusterRbacConfig":
+			ns.rbacDetails.ClusterRbacConfigs = append(ns.rbacDetails.ClusterRbacConfigs, o)
+		case "ServiceMeshRbacConfig":
+			ns.rbacDetails.ServiceMeshRbacConfigs = append(ns.rbacDetails.ServiceMeshRbacConfigs, o)
+		case "ServiceRole":
+			ns.rbacDetails.ServiceRoles = append(ns.rbacDetails.ServiceRoles, o)
+		case "ServiceRoleBinding":
+			ns.rbacDetails.ServiceRoleBindings = append(ns.rbacDetails.ServiceRoleBindings, o)
+		}
+	}
+	for _, s := range manifests.Services {
+		ns := nsObjects(s.Namespace)
+		ns.services = append(ns.services, s)
+	}
+	for _, d := range manifests.Deployments {
+		ns := nsObjects(d.Namespace)
+		ns.deployments = append(ns.deployments, d)
+	}
+
+	names := make([]string, 0, len(namespaceObjects))
+	for name := range namespaceObjects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	namespaces := make(models.Namespaces, 0, len(names))
+	gatewaysPerNamespace := make([][]kubernetes.IstioObject, 0, len(names))
+	for _, name := range names {
+		namespaces = append(namespaces, models.Namespace{Name: name})
+		gatewaysPerNamespace = append(gatewaysPerNamespace, namespaceObjects[name].istioDetails.Gateways)
+	}
+	var meshPeerAuthns []kubernetes.IstioObject
+	if istioNs, ok := namespaceObjects[config.Get().IstioNamespace]; ok {
+		meshPeerAuthns = istioNs.peerAuthns
+	}
+
+	in := IstioValidationsService{}
+	validations := models.IstioValidations{}
+	for _, name := range names {
+		ns := namespaceObjects[name]
+		mtlsDetails := kubernetes.MTLSDetails{
+			DestinationRules:        allDestinationRules,
+			MeshPeerAuthentications: meshPeerAuthns,
+			ServiceMeshPolicies:     ns.meshPolicies,
+			PeerAuthentications:     ns.peerAuthns,
+			EnabledAutoMtls:         true,
+		}
+		workloads := offlineWorkloads(name, ns.deployments)
+		objectCheckers := in.getAllObjectCheckers(name, ns.istioDetails, ns.services, workloads, gatewaysPerNamespace, mtlsDetails, ns.rbacDetails, namespaces, nil)
+		objectCheckers = append(objectCheckers, checkers.CustomRulesChecker{Rules: customRules, Namespace: name, IstioDetails: ns.istioDetails,
+			MTLSDetails: mtlsDetails, RBACDetails: ns.rbacDetails, Services: ns.services, WorkloadList: workloads})
+		validations.MergeValidations(suppressChecks(runObjectCheckers(objectCheckers), ns.istioDetails, mtlsDetails, ns.rbacDetails, gatewaysPerNamespace, ns.services))
+	}
+	return validations
+}
+
+// offlineWorkloads builds the workload list of a namespace from its Deployments
+func offlineWorkloads(namespace string, deployments []apps_v1.Deployment) models.WorkloadList {
+	workloadList := models.WorkloadList{
+		Namespace: models.Namespace{Name: namespace},
+		Workloads: []models.WorkloadListItem{},
+	}
+	for i := range deployments {
+		w := &models.Workload{}
+		w.ParseDeployment(&deployments[i])
+		item := models.WorkloadListItem{}
+		item.ParseWorkload(w)
+		workloadList.Workloads = append(workloadList.Workloads, item)
+	}
+	return workloadList
+}
@@ -0,0 +1,100 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const offlineManifests = `
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: reviews
+spec:
+  hosts:
+  - reviews
+  http:
+  - route:
+    - destination:
+        host: reviews
+        subset: v2
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: reviews
+spec:
+  host: reviews
+  subsets:
+  - name: v1
+    labels:
+      version: v1
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: reviews
+spec:
+  selector:
+    app: reviews
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: reviews-v1
+spec:
+  template:
+    metadata:
+      labels:
+        app: reviews
+        version: v1
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: EnvoyFilter
+metadata:
+  name: reviews-lua
+spec:
+  workloadSelector:
+    labels:
+      app: ratings
+  configPatches:
+  - applyTo: HTTP_FILTER
+    match:
+      listener:
+        name: 0.0.0.0_9080
+`
+
+func TestGetOfflineValidations(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	manifests := &kubernetes.Manifests{}
+	assert.NoError(manifests.Parse(strings.NewReader(offlineManifests)))
+
+	validations := GetOfflineValidations(manifests, "bookinfo", nil)
+
+	// the VirtualService routes to a subset the DestinationRule does not define
+	vs, ok := validations[models.IstioValidationKey{ObjectType: "virtualservice", Namespace: "bookinfo", Name: "reviews"}]
+	assert.True(ok)
+	assert.Len(vs.Checks, 1)
+	assert.Equal("KIA1107 Subset not found", vs.Checks[0].Message)
+	assert.Equal(models.WarningSeverity, vs.Checks[0].Severity)
+
+	dr, ok := validations[models.IstioValidationKey{ObjectType: "destinationrule", Namespace: "bookinfo", Name: "reviews"}]
+	assert.True(ok)
+	assert.True(dr.Valid)
+
+	// the EnvoyFilter selects no workload and patches a listener no service exposes
+	ef, ok := validations[models.IstioValidationKey{ObjectType: "envoyfilter", Namespace: "bookinfo", Name: "reviews-lua"}]
+	assert.True(ok)
+	assert.True(ef.Valid)
+	assert.Len(ef.Checks, 2)
+	assert.Equal("KIA1201", ef.Checks[0].Code)
+	assert.Equal("KIA1202", ef.Checks[1].Code)
+}
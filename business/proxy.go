@@ -0,0 +1,61 @@
+package business
+
+import (
+	"fmt"
+
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const proxyContainerName = "istio-proxy"
+
+// ProxyService deals with the state of the Istio proxies, queried through their admin interface
+type ProxyService struct {
+	k8s           kubernetes.IstioClientInterface
+	proxyAdmin    kubernetes.ProxyAdminClient
+	istiodDebug   kubernetes.IstiodDebugClient
+	businessLayer *Layer
+}
+
+// getProxyAdmin returns the response of an endpoint of the admin interface of the proxy of a pod
+func (in *ProxyService) getProxyAdmin(namespace, pod, path string, params map[string]string) ([]byte, error) {
+	if in.proxyAdmin == nil {
+		return nil, fmt.Errorf("the admin interface of the proxies is not reachable with the current client")
+	}
+	return in.proxyAdmin.GetProxyAdmin(namespace, pod, path, params)
+}
+
+// getProxyPod returns a pod checking it has an Istio proxy
+func (in *ProxyService) getProxyPod(namespace, pod string) (*models.Pod, error) {
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err := in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return nil, err
+	}
+
+	p, err := in.k8s.GetPod(namespace, pod)
+	if err != nil {
+		return nil, err
+	}
+	result := models.Pod{}
+	result.Parse(p)
+	if !hasProxy(p, &result) {
+		return nil, kubernetes.NewNotFound(pod, "Kiali", "Proxy")
+	}
+	return &result, nil
+}
+
+// hasProxy returns true when the pod has an injected sidecar or is a gateway, which runs the proxy as main container
+func hasProxy(p *core_v1.Pod, pod *models.Pod) bool {
+	if pod.HasIstioSidecar() {
+		return true
+	}
+	for _, c := range p.Spec.Containers {
+		if c.Name == proxyContainerName {
+			return true
+		}
+	}
+	return false
+}
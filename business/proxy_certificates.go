@@ -0,0 +1,271 @@
+package business
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/util"
+)
+
+const (
+	// Names of the SDS secrets of the workload certificate chain and of the root certificate
+	workloadCertSecret = "default"
+	rootCertSecret     = "ROOTCA"
+	// Maximum number of proxies queried at the same time for a namespace
+	maxProxyQueries = 10
+)
+
+// proxySecretsDump is the part of the config_dump of a proxy with the secrets. The /certs endpoint reports
+// neither the issuer nor the PEM needed for the fingerprints, the private keys are redacted in the dump.
+type proxySecretsDump struct {
+	Configs []struct {
+		DynamicActiveSecrets []proxySecret `json:"dynamic_active_secrets"`
+		StaticSecrets        []proxySecret `json:"static_secrets"`
+	} `json:"configs"`
+}
+
+type proxySecret struct {
+	Name   string `json:"name"`
+	Secret struct {
+		TLSCertificate *struct {
+			CertificateChain proxyDataSource `json:"certificate_chain"`
+		} `json:"tls_certificate"`
+		ValidationContext *struct {
+			TrustedCA proxyDataSource `json:"trusted_ca"`
+		} `json:"validation_context"`
+	} `json:"secret"`
+}
+
+type proxyDataSource struct {
+	InlineBytes  []byte `json:"inline_bytes"`
+	InlineString string `json:"inline_string"`
+}
+
+func (ds proxyDataSource) pem() []byte {
+	if len(ds.InlineBytes) > 0 {
+		return ds.InlineBytes
+	}
+	return []byte(ds.InlineString)
+}
+
+// GetProxyCertificates returns the workload certificate chain and the root certificates loaded by the proxy of a pod
+func (in *ProxyService) GetProxyCertificates(namespace, pod string) (*models.ProxyCertificates, error) {
+	if _, err := in.getProxyPod(namespace, pod); err != nil {
+		return nil, err
+	}
+	return in.fetchProxyCertificates(namespace, pod)
+}
+
+// GetNamespaceCertificates returns the certificate status of the proxies of a namespace, flagging the certificates
+// expiring within the given duration and the identities of another trust domain than the Istio identity domain
+func (in *ProxyService) GetNamespaceCertificates(namespace string, expiringWithin time.Duration) (*models.NamespaceCertificates, error) {
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err := in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return nil, err
+	}
+
+	var pods []core_v1.Pod
+	var err error
+	if kialiCache != nil && kialiCache.CheckNamespace(namespace) {
+		pods, err = kialiCache.GetPods(namespace, "")
+	} else {
+		pods, err = in.k8s.GetPods(namespace, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.NamespaceCertificates{
+		Namespace:      namespace,
+		TrustDomain:    trustDomain(),
+		ExpiringWithin: int64(expiringWithin.Seconds()),
+		Pods:           []models.PodCertificatesStatus{},
+	}
+	statuses := make([]models.PodCertificatesStatus, len(pods))
+	queried := make([]bool, len(pods))
+	wg := sync.WaitGroup{}
+	sem := make(chan struct{}, maxProxyQueries)
+	for i := range pods {
+		pod := models.Pod{}
+		pod.Parse(&pods[i])
+		if !hasProxy(&pods[i], &pod) || pods[i].Status.Phase != core_v1.PodRunning {
+			continue
+		}
+		queried[i] = true
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			statuses[i] = in.podCertificatesStatus(namespace, name, result.TrustDomain, result.ExpiringWithin)
+		}(i, pods[i].Name)
+	}
+	wg.Wait()
+
+	for i, status := range statuses {
+		if queried[i] {
+			result.Pods = append(result.Pods, status)
+		}
+	}
+	sort.Slice(result.Pods, func(i, j int) bool {
+		return result.Pods[i].Pod < result.Pods[j].Pod
+	})
+	return result, nil
+}
+
+func (in *ProxyService) podCertificatesStatus(namespace, pod, expectedTrustDomain string, expiringWithin int64) models.PodCertificatesStatus {
+	status := models.PodCertificatesStatus{Pod: pod}
+	certs, err := in.fetchProxyCertificates(namespace, pod)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Certificates = certs
+	status.ExpiringSoon = certs.ExpiresIn < expiringWithin
+	status.TrustDomainMismatch = certs.TrustDomain != expectedTrustDomain
+	return status
+}
+
+func (in *ProxyService) fetchProxyCertificates(namespace, pod string) (*models.ProxyCertificates, error) {
+	dump, err := in.getProxyAdmin(namespace, pod, "config_dump", nil)
+	if err != nil {
+		return nil, err
+	}
+	certs, err := parseProxyCertificates(dump, util.Clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the certificates of the proxy of %s/%s: %v", namespace, pod, err)
+	}
+	certs.Namespace, certs.Pod = namespace, pod
+	return certs, nil
+}
+
+// parseProxyCertificates reads the workload certificate chain and the root certificates from a config_dump
+func parseProxyCertificates(configDump []byte, now time.Time) (*models.ProxyCertificates, error) {
+	dump := proxySecretsDump{}
+	if err := json.Unmarshal(configDump, &dump); err != nil {
+		return nil, err
+	}
+	var chainSecret, rootSecret *proxySecret
+	for _, c := range dump.Configs {
+		for _, secrets := range [][]proxySecret{c.DynamicActiveSecrets, c.StaticSecrets} {
+			for i := range secrets {
+				s := &secrets[i]
+				if s.Secret.TLSCertificate != nil && (chainSecret == nil || s.Name == workloadCertSecret) {
+					chainSecret = s
+				}
+				if s.Secret.ValidationContext != nil && (rootSecret == nil || s.Name == rootCertSecret) {
+					rootSecret = s
+				}
+			}
+		}
+	}
+	if chainSecret == nil {
+		return nil, fmt.Errorf("no certificate found")
+	}
+
+	certs := &models.ProxyCertificates{Chain: []models.CertificateInfo{}, RootCAs: []models.CertificateInfo{}}
+	chain, err := parseCertificates(chainSecret.Secret.TLSCertificate.CertificateChain.pem())
+	if err != nil {
+		return nil, err
+	}
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("empty certificate chain")
+	}
+	for _, c := range chain {
+		certs.Chain = append(certs.Chain, certificateInfo(c))
+	}
+	leaf := chain[0]
+	certs.SpiffeID = spiffeID(leaf)
+	if u, err := url.Parse(certs.SpiffeID); err == nil {
+		certs.TrustDomain = u.Host
+	}
+	certs.Issuer = leaf.Issuer.String()
+	certs.ValidFrom, certs.ValidTo = leaf.NotBefore, leaf.NotAfter
+	certs.ExpiresIn = int64(leaf.NotAfter.Sub(now).Seconds())
+
+	if rootSecret != nil {
+		roots, err := parseCertificates(rootSecret.Secret.ValidationContext.TrustedCA.pem())
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range roots {
+			certs.RootCAs = append(certs.RootCAs, certificateInfo(c))
+		}
+		if len(roots) > 0 {
+			certs.RootCAFingerprint = certs.RootCAs[0].Fingerprint
+		}
+	}
+	return certs, nil
+}
+
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	certs := []*x509.Certificate{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+}
+
+func certificateInfo(cert *x509.Certificate) models.CertificateInfo {
+	info := models.CertificateInfo{
+		Subject:      cert.Subject.String(),
+		Issuer:       cert.Issuer.String(),
+		SerialNumber: cert.SerialNumber.String(),
+		ValidFrom:    cert.NotBefore,
+		ValidTo:      cert.NotAfter,
+		IsCA:         cert.IsCA,
+		Fingerprint:  fingerprint(cert),
+	}
+	for _, u := range cert.URIs {
+		info.URIs = append(info.URIs, u.String())
+	}
+	return info
+}
+
+// fingerprint returns the SHA-256 fingerprint of a certificate, as colon separated hexadecimal bytes
+func fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	hexBytes := make([]string, len(sum))
+	for i, b := range sum {
+		hexBytes[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(hexBytes, ":")
+}
+
+func spiffeID(cert *x509.Certificate) string {
+	for _, u := range cert.URIs {
+		if u.Scheme == "spiffe" {
+			return u.String()
+		}
+	}
+	return ""
+}
+
+// trustDomain returns the trust domain of the mesh identities, the Istio identity domain without the svc label
+func trustDomain() string {
+	return strings.TrimPrefix(config.Get().ExternalServices.Istio.IstioIdentityDomain, "svc.")
+}
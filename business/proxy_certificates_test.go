@@ -0,0 +1,208 @@
+package business
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/util"
+)
+
+type fakeProxyAdmin struct {
+	responses map[string][]byte
+}
+
+func (f fakeProxyAdmin) GetProxyAdmin(namespace, pod, path string, params map[string]string) ([]byte, error) {
+	if response, found := f.responses[namespace+"/"+pod+"/"+path]; found {
+		return response, nil
+	}
+	return nil, fmt.Errorf("connection refused")
+}
+
+// fakeCertificates returns a CA certificate and a workload certificate signed by it, both as PEM
+func fakeCertificates(t *testing.T, spiffeID string, notAfter time.Time) ([]byte, []byte) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{Organization: []string{"cluster.local"}},
+		NotBefore:             notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              notAfter.Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDer, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	assert.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDer)
+	assert.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	uri, err := url.Parse(spiffeID)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+		URIs:         []*url.URL{uri},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	assert.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDer})
+}
+
+func fakeSecretsConfigDump(t *testing.T, chain, root []byte) []byte {
+	dump := map[string]interface{}{
+		"configs": []interface{}{
+			map[string]interface{}{
+				"@type": "type.googleapis.com/envoy.admin.v3.ClustersConfigDump",
+			},
+			map[string]interface{}{
+				"@type": "type.googleapis.com/envoy.admin.v3.SecretsConfigDump",
+				"dynamic_active_secrets": []interface{}{
+					map[string]interface{}{
+						"name": "default",
+						"secret": map[string]interface{}{
+							"name": "default",
+							"tls_certificate": map[string]interface{}{
+								"certificate_chain": map[string]interface{}{"inline_bytes": chain},
+								"private_key":       map[string]interface{}{"inline_string": "[redacted]"},
+							},
+						},
+					},
+					map[string]interface{}{
+						"name": "ROOTCA",
+						"secret": map[string]interface{}{
+							"name": "ROOTCA",
+							"validation_context": map[string]interface{}{
+								"trusted_ca": map[string]interface{}{"inline_bytes": root},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	result, err := json.Marshal(dump)
+	assert.NoError(t, err)
+	return result
+}
+
+func TestParseProxyCertificates(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	leaf, ca := fakeCertificates(t, "spiffe://cluster.local/ns/bookinfo/sa/bookinfo-reviews", now.Add(2*time.Hour))
+	certs, err := parseProxyCertificates(fakeSecretsConfigDump(t, append(leaf, ca...), ca), now)
+	assert.NoError(err)
+
+	assert.Equal("spiffe://cluster.local/ns/bookinfo/sa/bookinfo-reviews", certs.SpiffeID)
+	assert.Equal("cluster.local", certs.TrustDomain)
+	assert.Equal("O=cluster.local", certs.Issuer)
+	assert.Equal(now.Add(-22*time.Hour), certs.ValidFrom.UTC())
+	assert.Equal(now.Add(2*time.Hour), certs.ValidTo.UTC())
+	assert.Equal(int64(7200), certs.ExpiresIn)
+
+	assert.Len(certs.Chain, 2)
+	assert.False(certs.Chain[0].IsCA)
+	assert.Equal([]string{"spiffe://cluster.local/ns/bookinfo/sa/bookinfo-reviews"}, certs.Chain[0].URIs)
+	assert.True(certs.Chain[1].IsCA)
+	assert.Len(certs.RootCAs, 1)
+	assert.Equal(certs.Chain[1].Fingerprint, certs.RootCAFingerprint)
+	assert.Len(certs.RootCAFingerprint, 32*3-1)
+}
+
+func TestParseProxyCertificatesWithoutSecrets(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := parseProxyCertificates([]byte(`{"configs":[{"@type":"type.googleapis.com/envoy.admin.v3.BootstrapConfigDump"}]}`), time.Now())
+	assert.Error(err)
+}
+
+func TestGetNamespaceCertificates(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	util.Clock = util.ClockMock{Time: now}
+
+	sidecarAnnotation := map[string]string{conf.ExternalServices.Istio.IstioSidecarAnnotation: `{"containers":["istio-proxy"]}`}
+	pod := func(name string, annotations map[string]string, container string, phase core_v1.PodPhase) core_v1.Pod {
+		return core_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "bookinfo", Annotations: annotations},
+			Spec:       core_v1.PodSpec{Containers: []core_v1.Container{{Name: container}}},
+			Status:     core_v1.PodStatus{Phase: phase},
+		}
+	}
+
+	k8s := new(kubetest.K8SClientMock)
+	k8s.On("IsOpenShift").Return(false)
+	k8s.On("IsMaistraApi").Return(false)
+	k8s.On("GetNamespace", "bookinfo").Return(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}}, nil)
+	k8s.On("GetPods", "bookinfo", "").Return([]core_v1.Pod{
+		pod("reviews-v1", sidecarAnnotation, "reviews", core_v1.PodRunning),
+		pod("reviews-v2", sidecarAnnotation, "reviews", core_v1.PodRunning),
+		pod("ratings-v1", sidecarAnnotation, "ratings", core_v1.PodRunning),
+		pod("details-v1", sidecarAnnotation, "details", core_v1.PodRunning),
+		pod("gateway", nil, "istio-proxy", core_v1.PodRunning),
+		pod("no-sidecar", nil, "productpage", core_v1.PodRunning),
+		pod("pending", sidecarAnnotation, "reviews", core_v1.PodPending),
+	}, nil)
+
+	dump := func(spiffeID string, expiresIn time.Duration) []byte {
+		leaf, ca := fakeCertificates(t, spiffeID, now.Add(expiresIn))
+		return fakeSecretsConfigDump(t, append(leaf, ca...), ca)
+	}
+	layer := NewWithBackends(k8s, nil, nil)
+	layer.Proxy.proxyAdmin = fakeProxyAdmin{responses: map[string][]byte{
+		"bookinfo/reviews-v1/config_dump": dump("spiffe://cluster.local/ns/bookinfo/sa/reviews", 12*time.Hour),
+		"bookinfo/reviews-v2/config_dump": dump("spiffe://cluster.local/ns/bookinfo/sa/reviews", 10*time.Minute),
+		"bookinfo/ratings-v1/config_dump": dump("spiffe://other.domain/ns/bookinfo/sa/ratings", 12*time.Hour),
+		"bookinfo/gateway/config_dump":    dump("spiffe://cluster.local/ns/bookinfo/sa/gateway", 12*time.Hour),
+	}}
+
+	certs, err := layer.Proxy.GetNamespaceCertificates("bookinfo", time.Hour)
+	assert.NoError(err)
+	assert.Equal("cluster.local", certs.TrustDomain)
+	assert.Equal(int64(3600), certs.ExpiringWithin)
+
+	assert.Len(certs.Pods, 5)
+	status := map[string]int{}
+	for i, p := range certs.Pods {
+		status[p.Pod] = i
+	}
+	assert.NotContains(status, "no-sidecar")
+	assert.NotContains(status, "pending")
+
+	reviewsV1 := certs.Pods[status["reviews-v1"]]
+	assert.False(reviewsV1.ExpiringSoon)
+	assert.False(reviewsV1.TrustDomainMismatch)
+	assert.Equal("bookinfo", reviewsV1.Certificates.Namespace)
+	assert.Equal("reviews-v1", reviewsV1.Certificates.Pod)
+
+	assert.True(certs.Pods[status["reviews-v2"]].ExpiringSoon)
+	assert.True(certs.Pods[status["ratings-v1"]].TrustDomainMismatch)
+	assert.NotNil(certs.Pods[status["gateway"]].Certificates)
+
+	details := certs.Pods[status["details-v1"]]
+	assert.Nil(details.Certificates)
+	assert.Equal("connection refused", details.Error)
+}
@@ -0,0 +1,484 @@
+package business
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kiali/kiali/models"
+)
+
+// ProxyConfigFilter filters the summaries of the configuration of a proxy. Empty fields do not filter.
+type ProxyConfigFilter struct {
+	// Port of the listeners and of the clusters
+	Port uint32
+	// Direction of the clusters: inbound or outbound
+	Direction string
+	// FQDN of the service of the clusters
+	FQDN string
+	// VirtualService of the routes, as name or namespace/name
+	VirtualService string
+	// Cluster of the endpoints
+	Cluster string
+}
+
+// envoyConfigDump is the part of the config_dump of a proxy summarized by Kiali
+type envoyConfigDump struct {
+	Configs []struct {
+		// ListenersConfigDump
+		StaticListeners []struct {
+			Listener envoyListener `json:"listener"`
+		} `json:"static_listeners"`
+		DynamicListeners []struct {
+			ActiveState *struct {
+				Listener envoyListener `json:"listener"`
+			} `json:"active_state"`
+		} `json:"dynamic_listeners"`
+		// RoutesConfigDump
+		StaticRouteConfigs []struct {
+			RouteConfig envoyRouteConfig `json:"route_config"`
+		} `json:"static_route_configs"`
+		DynamicRouteConfigs []struct {
+			RouteConfig envoyRouteConfig `json:"route_config"`
+		} `json:"dynamic_route_configs"`
+		// ClustersConfigDump
+		StaticClusters []struct {
+			Cluster envoyCluster `json:"cluster"`
+		} `json:"static_clusters"`
+		DynamicActiveClusters []struct {
+			Cluster envoyCluster `json:"cluster"`
+		} `json:"dynamic_active_clusters"`
+	} `json:"configs"`
+}
+
+type envoyAddress struct {
+	SocketAddress struct {
+		Address   string `json:"address"`
+		PortValue uint32 `json:"port_value"`
+	} `json:"socket_address"`
+}
+
+type envoyListener struct {
+	Name             string       `json:"name"`
+	Address          envoyAddress `json:"address"`
+	TrafficDirection string       `json:"traffic_direction"`
+	FilterChains     []struct {
+		FilterChainMatch struct {
+			DestinationPort   uint32   `json:"destination_port"`
+			ServerNames       []string `json:"server_names"`
+			TransportProtocol string   `json:"transport_protocol"`
+		} `json:"filter_chain_match"`
+		Filters []struct {
+			Name        string `json:"name"`
+			TypedConfig struct {
+				// HTTP connection manager
+				Rds *struct {
+					RouteConfigName string `json:"route_config_name"`
+				} `json:"rds"`
+				RouteConfig *struct {
+					Name string `json:"name"`
+				} `json:"route_config"`
+				// TCP proxy
+				Cluster          string `json:"cluster"`
+				WeightedClusters *struct {
+					Clusters []struct {
+						Name string `json:"name"`
+					} `json:"clusters"`
+				} `json:"weighted_clusters"`
+			} `json:"typed_config"`
+		} `json:"filters"`
+	} `json:"filter_chains"`
+}
+
+type envoyMetadata struct {
+	FilterMetadata struct {
+		Istio struct {
+			Config string `json:"config"`
+		} `json:"istio"`
+	} `json:"filter_metadata"`
+}
+
+type envoyRouteConfig struct {
+	Name         string `json:"name"`
+	VirtualHosts []struct {
+		Name    string   `json:"name"`
+		Domains []string `json:"domains"`
+		Routes  []struct {
+			Name  string `json:"name"`
+			Match struct {
+				Prefix    *string `json:"prefix"`
+				Path      *string `json:"path"`
+				Regex     *string `json:"regex"`
+				SafeRegex *struct {
+					Regex string `json:"regex"`
+				} `json:"safe_regex"`
+			} `json:"match"`
+			Route *struct {
+				Cluster          string `json:"cluster"`
+				WeightedClusters *struct {
+					Clusters []struct {
+						Name   string `json:"name"`
+						Weight uint32 `json:"weight"`
+					} `json:"clusters"`
+				} `json:"weighted_clusters"`
+			} `json:"route"`
+			Redirect       interface{}   `json:"redirect"`
+			DirectResponse interface{}   `json:"direct_response"`
+			Metadata       envoyMetadata `json:"metadata"`
+		} `json:"routes"`
+	} `json:"virtual_hosts"`
+}
+
+type envoyCluster struct {
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	ConnectTimeout string `json:"connect_timeout"`
+	// Clusters with a custom discovery type, i.e. the original destination ones, report no type
+	ClusterType *struct {
+		Name string `json:"name"`
+	} `json:"cluster_type"`
+	CircuitBreakers *struct {
+		Thresholds []struct {
+			Priority           string  `json:"priority"`
+			MaxConnections     *uint32 `json:"max_connections"`
+			MaxPendingRequests *uint32 `json:"max_pending_requests"`
+			MaxRequests        *uint32 `json:"max_requests"`
+			MaxRetries         *uint32 `json:"max_retries"`
+		} `json:"thresholds"`
+	} `json:"circuit_breakers"`
+	OutlierDetection *struct {
+		Consecutive5xx     *uint32 `json:"consecutive_5xx"`
+		Interval           string  `json:"interval"`
+		BaseEjectionTime   string  `json:"base_ejection_time"`
+		MaxEjectionPercent *uint32 `json:"max_ejection_percent"`
+	} `json:"outlier_detection"`
+	Metadata envoyMetadata `json:"metadata"`
+}
+
+// envoyClusterStatuses is the response of the clusters endpoint in JSON format
+type envoyClusterStatuses struct {
+	ClusterStatuses []struct {
+		Name         string `json:"name"`
+		HostStatuses []struct {
+			Address      envoyAddress `json:"address"`
+			HealthStatus struct {
+				EdsHealthStatus           string `json:"eds_health_status"`
+				FailedActiveHealthCheck   bool   `json:"failed_active_health_check"`
+				FailedOutlierCheck        bool   `json:"failed_outlier_check"`
+				FailedActiveDegradedCheck bool   `json:"failed_active_degraded_check"`
+			} `json:"health_status"`
+			Weight uint32 `json:"weight"`
+		} `json:"host_statuses"`
+	} `json:"cluster_statuses"`
+}
+
+// GetProxyConfigDump returns the raw config_dump of the proxy of a pod
+func (in *ProxyService) GetProxyConfigDump(namespace, pod string) (json.RawMessage, error) {
+	if _, err := in.getProxyPod(namespace, pod); err != nil {
+		return nil, err
+	}
+	dump, err := in.getProxyAdmin(namespace, pod, "config_dump", nil)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(dump), nil
+}
+
+// GetProxyListeners returns the listeners of the proxy of a pod. Filtering by port keeps the listeners on the port
+// and the filter chains of the other listeners matching the port, i.e. the ones of the virtual inbound listener.
+func (in *ProxyService) GetProxyListeners(namespace, pod string, filter ProxyConfigFilter) ([]models.ProxyListener, error) {
+	dump, err := in.getConfigDump(namespace, pod)
+	if err != nil {
+		return nil, err
+	}
+	return parseProxyListeners(dump, filter), nil
+}
+
+// GetProxyRoutes returns the routes of the route configurations of the proxy of a pod
+func (in *ProxyService) GetProxyRoutes(namespace, pod string, filter ProxyConfigFilter) ([]models.ProxyRoute, error) {
+	dump, err := in.getConfigDump(namespace, pod)
+	if err != nil {
+		return nil, err
+	}
+	return parseProxyRoutes(dump, filter), nil
+}
+
+// GetProxyClusters returns the clusters of the proxy of a pod with their circuit breaker settings
+func (in *ProxyService) GetProxyClusters(namespace, pod string, filter ProxyConfigFilter) ([]models.ProxyCluster, error) {
+	dump, err := in.getConfigDump(namespace, pod)
+	if err != nil {
+		return nil, err
+	}
+	return parseProxyClusters(dump, filter), nil
+}
+
+// GetProxyEndpoints returns the endpoints of the clusters of the proxy of a pod with their health
+func (in *ProxyService) GetProxyEndpoints(namespace, pod string, filter ProxyConfigFilter) ([]models.ProxyEndpoint, error) {
+	if _, err := in.getProxyPod(namespace, pod); err != nil {
+		return nil, err
+	}
+	response, err := in.getProxyAdmin(namespace, pod, "clusters", map[string]string{"format": "json"})
+	if err != nil {
+		return nil, err
+	}
+	statuses := envoyClusterStatuses{}
+	if err := json.Unmarshal(response, &statuses); err != nil {
+		return nil, err
+	}
+	return proxyEndpoints(statuses, filter), nil
+}
+
+func (in *ProxyService) getConfigDump(namespace, pod string) (*envoyConfigDump, error) {
+	raw, err := in.GetProxyConfigDump(namespace, pod)
+	if err != nil {
+		return nil, err
+	}
+	dump := &envoyConfigDump{}
+	if err := json.Unmarshal(raw, dump); err != nil {
+		return nil, err
+	}
+	return dump, nil
+}
+
+func parseProxyListeners(dump *envoyConfigDump, filter ProxyConfigFilter) []models.ProxyListener {
+	listeners := []envoyListener{}
+	for _, c := range dump.Configs {
+		for _, l := range c.StaticListeners {
+			listeners = append(listeners, l.Listener)
+		}
+		for _, l := range c.DynamicListeners {
+			if l.ActiveState != nil {
+				listeners = append(listeners, l.ActiveState.Listener)
+			}
+		}
+	}
+
+	result := []models.ProxyListener{}
+	for _, l := range listeners {
+		listener := models.ProxyListener{
+			Name:             l.Name,
+			Address:          l.Address.SocketAddress.Address,
+			Port:             l.Address.SocketAddress.PortValue,
+			TrafficDirection: l.TrafficDirection,
+			FilterChains:     []models.ProxyFilterChain{},
+		}
+		portMatches := filter.Port == 0 || filter.Port == listener.Port
+		for _, fc := range l.FilterChains {
+			if !portMatches && fc.FilterChainMatch.DestinationPort != filter.Port {
+				continue
+			}
+			chain := models.ProxyFilterChain{
+				DestinationPort:   fc.FilterChainMatch.DestinationPort,
+				ServerNames:       fc.FilterChainMatch.ServerNames,
+				TransportProtocol: fc.FilterChainMatch.TransportProtocol,
+			}
+			for _, f := range fc.Filters {
+				tc := f.TypedConfig
+				switch {
+				case tc.Rds != nil:
+					chain.RouteConfig = tc.Rds.RouteConfigName
+				case tc.RouteConfig != nil:
+					chain.RouteConfig = tc.RouteConfig.Name
+				case tc.Cluster != "":
+					chain.Clusters = append(chain.Clusters, tc.Cluster)
+				case tc.WeightedClusters != nil:
+					for _, wc := range tc.WeightedClusters.Clusters {
+						chain.Clusters = append(chain.Clusters, wc.Name)
+					}
+				}
+			}
+			listener.FilterChains = append(listener.FilterChains, chain)
+		}
+		if portMatches || len(listener.FilterChains) > 0 {
+			result = append(result, listener)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Port < result[j].Port
+	})
+	return result
+}
+
+func parseProxyRoutes(dump *envoyConfigDump, filter ProxyConfigFilter) []models.ProxyRoute {
+	routeConfigs := []envoyRouteConfig{}
+	for _, c := range dump.Configs {
+		for _, rc := range c.StaticRouteConfigs {
+			routeConfigs = append(routeConfigs, rc.RouteConfig)
+		}
+		for _, rc := range c.DynamicRouteConfigs {
+			routeConfigs = append(routeConfigs, rc.RouteConfig)
+		}
+	}
+
+	result := []models.ProxyRoute{}
+	for _, rc := range routeConfigs {
+		for _, vh := range rc.VirtualHosts {
+			for _, r := range vh.Routes {
+				route := models.ProxyRoute{
+					RouteConfig:    rc.Name,
+					VirtualHost:    vh.Name,
+					Domains:        vh.Domains,
+					Name:           r.Name,
+					Destinations:   []models.ProxyRouteDestination{},
+					VirtualService: istioConfigReference(r.Metadata),
+				}
+				if !virtualServiceMatches(route.VirtualService, filter.VirtualService) {
+					continue
+				}
+				switch {
+				case r.Match.Prefix != nil:
+					route.Match = "prefix " + *r.Match.Prefix
+				case r.Match.Path != nil:
+					route.Match = "path " + *r.Match.Path
+				case r.Match.SafeRegex != nil:
+					route.Match = "regex " + r.Match.SafeRegex.Regex
+				case r.Match.Regex != nil:
+					route.Match = "regex " + *r.Match.Regex
+				}
+				switch {
+				case r.Route != nil && r.Route.WeightedClusters != nil:
+					for _, wc := range r.Route.WeightedClusters.Clusters {
+						route.Destinations = append(route.Destinations, models.ProxyRouteDestination{Cluster: wc.Name, Weight: wc.Weight})
+					}
+				case r.Route != nil:
+					route.Destinations = append(route.Destinations, models.ProxyRouteDestination{Cluster: r.Route.Cluster, Weight: 100})
+				case r.Redirect != nil:
+					route.Action = "redirect"
+				case r.DirectResponse != nil:
+					route.Action = "direct_response"
+				}
+				result = append(result, route)
+			}
+		}
+	}
+	return result
+}
+
+func parseProxyClusters(dump *envoyConfigDump, filter ProxyConfigFilter) []models.ProxyCluster {
+	clusters := []envoyCluster{}
+	for _, c := range dump.Configs {
+		for _, cl := range c.StaticClusters {
+			clusters = append(clusters, cl.Cluster)
+		}
+		for _, cl := range c.DynamicActiveClusters {
+			clusters = append(clusters, cl.Cluster)
+		}
+	}
+
+	result := []models.ProxyCluster{}
+	for _, c := range clusters {
+		cluster := models.ProxyCluster{
+			Name:            c.Name,
+			Type:            c.Type,
+			ConnectTimeout:  c.ConnectTimeout,
+			DestinationRule: istioConfigReference(c.Metadata),
+		}
+		cluster.Direction, cluster.Port, cluster.Subset, cluster.FQDN = parseClusterName(c.Name)
+		if !clusterMatches(cluster.Name, filter) {
+			continue
+		}
+		if cluster.Type == "" && c.ClusterType != nil {
+			cluster.Type = c.ClusterType.Name
+		}
+		if c.CircuitBreakers != nil {
+			for _, t := range c.CircuitBreakers.Thresholds {
+				priority := t.Priority
+				if priority == "" {
+					priority = "DEFAULT"
+				}
+				cluster.CircuitBreakers = append(cluster.CircuitBreakers, models.CircuitBreakerThresholds{
+					Priority:           priority,
+					MaxConnections:     t.MaxConnections,
+					MaxPendingRequests: t.MaxPendingRequests,
+					MaxRequests:        t.MaxRequests,
+					MaxRetries:         t.MaxRetries,
+				})
+			}
+		}
+		if od := c.OutlierDetection; od != nil {
+			cluster.OutlierDetection = &models.OutlierDetection{
+				Consecutive5xx:     od.Consecutive5xx,
+				Interval:           od.Interval,
+				BaseEjectionTime:   od.BaseEjectionTime,
+				MaxEjectionPercent: od.MaxEjectionPercent,
+			}
+		}
+		result = append(result, cluster)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func proxyEndpoints(statuses envoyClusterStatuses, filter ProxyConfigFilter) []models.ProxyEndpoint {
+	result := []models.ProxyEndpoint{}
+	for _, cs := range statuses.ClusterStatuses {
+		if filter.Cluster != "" && cs.Name != filter.Cluster {
+			continue
+		}
+		if !clusterMatches(cs.Name, filter) {
+			continue
+		}
+		for _, hs := range cs.HostStatuses {
+			health := hs.HealthStatus
+			endpoint := models.ProxyEndpoint{
+				Cluster:         cs.Name,
+				Address:         hs.Address.SocketAddress.Address,
+				Port:            hs.Address.SocketAddress.PortValue,
+				Health:          "HEALTHY",
+				EdsHealthStatus: health.EdsHealthStatus,
+				OutlierEjected:  health.FailedOutlierCheck,
+				Weight:          hs.Weight,
+			}
+			edsHealthy := health.EdsHealthStatus == "" || health.EdsHealthStatus == "HEALTHY"
+			if !edsHealthy || health.FailedActiveHealthCheck || health.FailedOutlierCheck || health.FailedActiveDegradedCheck {
+				endpoint.Health = "UNHEALTHY"
+			}
+			result = append(result, endpoint)
+		}
+	}
+	return result
+}
+
+// parseClusterName returns the direction, port, subset and FQDN of an Istio cluster name,
+// i.e. outbound|9080|v2|reviews.bookinfo.svc.cluster.local
+func parseClusterName(name string) (string, uint32, string, string) {
+	parts := strings.Split(name, "|")
+	if len(parts) != 4 {
+		return "", 0, "", ""
+	}
+	port, _ := strconv.ParseUint(parts[1], 10, 32)
+	return parts[0], uint32(port), parts[2], parts[3]
+}
+
+// clusterMatches returns whether an Istio cluster matches the port, direction and FQDN of the filter
+func clusterMatches(name string, filter ProxyConfigFilter) bool {
+	direction, port, _, fqdn := parseClusterName(name)
+	return (filter.Port == 0 || filter.Port == port) &&
+		(filter.Direction == "" || filter.Direction == direction) &&
+		(filter.FQDN == "" || filter.FQDN == fqdn)
+}
+
+// istioConfigReference returns the Istio config an Envoy resource was generated from, as namespace/name. The
+// metadata reference it as /apis/networking.istio.io/v1alpha3/namespaces/bookinfo/virtual-service/reviews
+func istioConfigReference(metadata envoyMetadata) string {
+	parts := strings.Split(metadata.FilterMetadata.Istio.Config, "/")
+	for i := 0; i < len(parts)-3; i++ {
+		if parts[i] == "namespaces" {
+			return parts[i+1] + "/" + parts[len(parts)-1]
+		}
+	}
+	return ""
+}
+
+func virtualServiceMatches(reference, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	if strings.Contains(filter, "/") {
+		return reference == filter
+	}
+	return reference != "" && reference[strings.Index(reference, "/")+1:] == filter
+}
@@ -0,0 +1,313 @@
+package business
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+const fakeEnvoyConfigDump = `{
+  "configs": [
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.ClustersConfigDump",
+      "static_clusters": [
+        {"cluster": {"name": "prometheus_stats", "type": "STATIC", "connect_timeout": "0.250s"}}
+      ],
+      "dynamic_active_clusters": [
+        {
+          "cluster": {
+            "name": "outbound|9080|v2|reviews.bookinfo.svc.cluster.local",
+            "type": "EDS",
+            "connect_timeout": "10s",
+            "circuit_breakers": {
+              "thresholds": [
+                {"max_connections": 100, "max_pending_requests": 10, "max_requests": 4294967295, "max_retries": 4294967295}
+              ]
+            },
+            "outlier_detection": {"consecutive_5xx": 5, "interval": "10s", "base_ejection_time": "30s", "max_ejection_percent": 100},
+            "metadata": {"filter_metadata": {"istio": {"config": "/apis/networking.istio.io/v1alpha3/namespaces/bookinfo/destination-rule/reviews"}}}
+          }
+        },
+        {
+          "cluster": {
+            "name": "inbound|9080|http|productpage.bookinfo.svc.cluster.local",
+            "type": "STATIC",
+            "connect_timeout": "10s"
+          }
+        },
+        {
+          "cluster": {
+            "name": "PassthroughCluster",
+            "connect_timeout": "10s",
+            "cluster_type": {"name": "envoy.cluster.original_dst"}
+          }
+        }
+      ]
+    },
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.ListenersConfigDump",
+      "dynamic_listeners": [
+        {
+          "name": "virtualInbound",
+          "active_state": {
+            "listener": {
+              "name": "virtualInbound",
+              "address": {"socket_address": {"address": "0.0.0.0", "port_value": 15006}},
+              "traffic_direction": "INBOUND",
+              "filter_chains": [
+                {
+                  "filter_chain_match": {"destination_port": 9080, "transport_protocol": "tls"},
+                  "filters": [
+                    {"name": "envoy.filters.network.http_connection_manager", "typed_config": {"route_config": {"name": "inbound|9080|http|productpage.bookinfo.svc.cluster.local"}}}
+                  ]
+                },
+                {
+                  "filter_chain_match": {"destination_port": 15021},
+                  "filters": [
+                    {"name": "envoy.filters.network.tcp_proxy", "typed_config": {"cluster": "agent"}}
+                  ]
+                }
+              ]
+            }
+          }
+        },
+        {
+          "name": "0.0.0.0_9080",
+          "active_state": {
+            "listener": {
+              "name": "0.0.0.0_9080",
+              "address": {"socket_address": {"address": "0.0.0.0", "port_value": 9080}},
+              "traffic_direction": "OUTBOUND",
+              "filter_chains": [
+                {
+                  "filters": [
+                    {"name": "envoy.filters.network.http_connection_manager", "typed_config": {"rds": {"route_config_name": "9080"}}}
+                  ]
+                }
+              ]
+            }
+          }
+        },
+        {
+          "name": "10.0.0.20_3306",
+          "active_state": {
+            "listener": {
+              "name": "10.0.0.20_3306",
+              "address": {"socket_address": {"address": "10.0.0.20", "port_value": 3306}},
+              "traffic_direction": "OUTBOUND",
+              "filter_chains": [
+                {
+                  "filters": [
+                    {"name": "envoy.filters.network.tcp_proxy", "typed_config": {"weighted_clusters": {"clusters": [{"name": "outbound|3306|v1|mysql.bookinfo.svc.cluster.local", "weight": 50}, {"name": "outbound|3306|v2|mysql.bookinfo.svc.cluster.local", "weight": 50}]}}}
+                  ]
+                }
+              ]
+            }
+          }
+        }
+      ]
+    },
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.RoutesConfigDump",
+      "dynamic_route_configs": [
+        {
+          "route_config": {
+            "name": "9080",
+            "virtual_hosts": [
+              {
+                "name": "reviews.bookinfo.svc.cluster.local:9080",
+                "domains": ["reviews.bookinfo.svc.cluster.local", "reviews"],
+                "routes": [
+                  {
+                    "name": "jason",
+                    "match": {"prefix": "/", "headers": [{"name": "end-user", "exact_match": "jason"}]},
+                    "route": {"cluster": "outbound|9080|v2|reviews.bookinfo.svc.cluster.local"},
+                    "metadata": {"filter_metadata": {"istio": {"config": "/apis/networking.istio.io/v1alpha3/namespaces/bookinfo/virtual-service/reviews"}}}
+                  },
+                  {
+                    "match": {"safe_regex": {"regex": "/v[0-9]+/.*"}},
+                    "route": {"weighted_clusters": {"clusters": [{"name": "outbound|9080|v1|reviews.bookinfo.svc.cluster.local", "weight": 80}, {"name": "outbound|9080|v3|reviews.bookinfo.svc.cluster.local", "weight": 20}]}},
+                    "metadata": {"filter_metadata": {"istio": {"config": "/apis/networking.istio.io/v1alpha3/namespaces/bookinfo/virtual-service/reviews"}}}
+                  }
+                ]
+              },
+              {
+                "name": "ratings.bookinfo.svc.cluster.local:9080",
+                "domains": ["ratings.bookinfo.svc.cluster.local"],
+                "routes": [
+                  {"name": "default", "match": {"prefix": "/"}, "route": {"cluster": "outbound|9080||ratings.bookinfo.svc.cluster.local"}}
+                ]
+              },
+              {
+                "name": "block_all",
+                "domains": ["*"],
+                "routes": [
+                  {"name": "block_all", "match": {"prefix": "/"}, "direct_response": {"status": 502}}
+                ]
+              }
+            ]
+          }
+        }
+      ]
+    }
+  ]
+}`
+
+const fakeEnvoyClusters = `{
+  "cluster_statuses": [
+    {
+      "name": "outbound|9080|v2|reviews.bookinfo.svc.cluster.local",
+      "host_statuses": [
+        {"address": {"socket_address": {"address": "10.1.0.12", "port_value": 9080}}, "health_status": {"eds_health_status": "HEALTHY"}, "weight": 1},
+        {"address": {"socket_address": {"address": "10.1.0.13", "port_value": 9080}}, "health_status": {"eds_health_status": "HEALTHY", "failed_outlier_check": true}, "weight": 1}
+      ]
+    },
+    {
+      "name": "outbound|9080||ratings.bookinfo.svc.cluster.local",
+      "host_statuses": [
+        {"address": {"socket_address": {"address": "10.1.0.20", "port_value": 9080}}, "health_status": {"eds_health_status": "UNHEALTHY"}, "weight": 1}
+      ]
+    },
+    {
+      "name": "inbound|9080|http|productpage.bookinfo.svc.cluster.local",
+      "host_statuses": [
+        {"address": {"socket_address": {"address": "127.0.0.1", "port_value": 9080}}, "health_status": {"eds_health_status": "HEALTHY"}, "weight": 1}
+      ]
+    }
+  ]
+}`
+
+func fakeParsedConfigDump(t *testing.T) *envoyConfigDump {
+	dump := &envoyConfigDump{}
+	assert.NoError(t, json.Unmarshal([]byte(fakeEnvoyConfigDump), dump))
+	return dump
+}
+
+func TestParseProxyListeners(t *testing.T) {
+	assert := assert.New(t)
+
+	listeners := parseProxyListeners(fakeParsedConfigDump(t), ProxyConfigFilter{})
+	assert.Len(listeners, 3)
+	assert.Equal("10.0.0.20_3306", listeners[0].Name)
+	assert.Equal([]string{"outbound|3306|v1|mysql.bookinfo.svc.cluster.local", "outbound|3306|v2|mysql.bookinfo.svc.cluster.local"}, listeners[0].FilterChains[0].Clusters)
+	assert.Equal("0.0.0.0_9080", listeners[1].Name)
+	assert.Equal("OUTBOUND", listeners[1].TrafficDirection)
+	assert.Equal("9080", listeners[1].FilterChains[0].RouteConfig)
+	assert.Equal("virtualInbound", listeners[2].Name)
+	assert.Len(listeners[2].FilterChains, 2)
+
+	// The chains of the virtual inbound listener on the port are kept
+	listeners = parseProxyListeners(fakeParsedConfigDump(t), ProxyConfigFilter{Port: 9080})
+	assert.Len(listeners, 2)
+	assert.Equal("0.0.0.0_9080", listeners[0].Name)
+	assert.Equal("virtualInbound", listeners[1].Name)
+	assert.Len(listeners[1].FilterChains, 1)
+	assert.Equal(uint32(9080), listeners[1].FilterChains[0].DestinationPort)
+	assert.Equal("tls", listeners[1].FilterChains[0].TransportProtocol)
+	assert.Equal("inbound|9080|http|productpage.bookinfo.svc.cluster.local", listeners[1].FilterChains[0].RouteConfig)
+}
+
+func TestParseProxyRoutes(t *testing.T) {
+	assert := assert.New(t)
+
+	routes := parseProxyRoutes(fakeParsedConfigDump(t), ProxyConfigFilter{})
+	assert.Len(routes, 4)
+
+	assert.Equal("9080", routes[0].RouteConfig)
+	assert.Equal("reviews.bookinfo.svc.cluster.local:9080", routes[0].VirtualHost)
+	assert.Equal("jason", routes[0].Name)
+	assert.Equal("prefix /", routes[0].Match)
+	assert.Equal("bookinfo/reviews", routes[0].VirtualService)
+	assert.Equal([]models.ProxyRouteDestination{{Cluster: "outbound|9080|v2|reviews.bookinfo.svc.cluster.local", Weight: 100}}, routes[0].Destinations)
+
+	assert.Equal("regex /v[0-9]+/.*", routes[1].Match)
+	assert.Len(routes[1].Destinations, 2)
+	assert.Equal(uint32(80), routes[1].Destinations[0].Weight)
+
+	assert.Empty(routes[2].VirtualService)
+	assert.Equal("direct_response", routes[3].Action)
+	assert.Empty(routes[3].Destinations)
+
+	for _, vs := range []string{"reviews", "bookinfo/reviews"} {
+		routes = parseProxyRoutes(fakeParsedConfigDump(t), ProxyConfigFilter{VirtualService: vs})
+		assert.Len(routes, 2)
+	}
+	assert.Empty(parseProxyRoutes(fakeParsedConfigDump(t), ProxyConfigFilter{VirtualService: "other/reviews"}))
+}
+
+func TestParseProxyClusters(t *testing.T) {
+	assert := assert.New(t)
+
+	clusters := parseProxyClusters(fakeParsedConfigDump(t), ProxyConfigFilter{})
+	assert.Len(clusters, 4)
+	assert.Equal("PassthroughCluster", clusters[0].Name)
+	assert.Equal("envoy.cluster.original_dst", clusters[0].Type)
+	assert.Equal("inbound", clusters[1].Direction)
+
+	clusters = parseProxyClusters(fakeParsedConfigDump(t), ProxyConfigFilter{Direction: "outbound", Port: 9080})
+	assert.Len(clusters, 1)
+	reviews := clusters[0]
+	assert.Equal("reviews.bookinfo.svc.cluster.local", reviews.FQDN)
+	assert.Equal("v2", reviews.Subset)
+	assert.Equal(uint32(9080), reviews.Port)
+	assert.Equal("EDS", reviews.Type)
+	assert.Equal("bookinfo/reviews", reviews.DestinationRule)
+	assert.Len(reviews.CircuitBreakers, 1)
+	assert.Equal("DEFAULT", reviews.CircuitBreakers[0].Priority)
+	assert.Equal(uint32(100), *reviews.CircuitBreakers[0].MaxConnections)
+	assert.Equal(uint32(10), *reviews.CircuitBreakers[0].MaxPendingRequests)
+	assert.Equal(uint32(5), *reviews.OutlierDetection.Consecutive5xx)
+	assert.Equal("30s", reviews.OutlierDetection.BaseEjectionTime)
+}
+
+func TestGetProxyEndpoints(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := new(kubetest.K8SClientMock)
+	k8s.On("IsOpenShift").Return(false)
+	k8s.On("IsMaistraApi").Return(false)
+	k8s.On("GetNamespace", "bookinfo").Return(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}}, nil)
+	k8s.On("GetPod", "bookinfo", "productpage-v1").Return(&core_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        "productpage-v1",
+			Namespace:   "bookinfo",
+			Annotations: map[string]string{conf.ExternalServices.Istio.IstioSidecarAnnotation: `{"containers":["istio-proxy"]}`},
+		},
+		Spec: core_v1.PodSpec{Containers: []core_v1.Container{{Name: "productpage"}, {Name: "istio-proxy"}}},
+	}, nil)
+	k8s.On("GetPod", "bookinfo", "no-sidecar").Return(&core_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "no-sidecar", Namespace: "bookinfo"},
+		Spec:       core_v1.PodSpec{Containers: []core_v1.Container{{Name: "productpage"}}},
+	}, nil)
+
+	layer := NewWithBackends(k8s, nil, nil)
+	layer.Proxy.proxyAdmin = fakeProxyAdmin{responses: map[string][]byte{
+		"bookinfo/productpage-v1/clusters": []byte(fakeEnvoyClusters),
+	}}
+
+	endpoints, err := layer.Proxy.GetProxyEndpoints("bookinfo", "productpage-v1", ProxyConfigFilter{Direction: "outbound"})
+	assert.NoError(err)
+	assert.Len(endpoints, 3)
+	assert.Equal("10.1.0.12", endpoints[0].Address)
+	assert.Equal("HEALTHY", endpoints[0].Health)
+	assert.Equal("UNHEALTHY", endpoints[1].Health)
+	assert.True(endpoints[1].OutlierEjected)
+	assert.Equal("UNHEALTHY", endpoints[2].Health)
+	assert.False(endpoints[2].OutlierEjected)
+
+	endpoints, err = layer.Proxy.GetProxyEndpoints("bookinfo", "productpage-v1", ProxyConfigFilter{Cluster: "outbound|9080||ratings.bookinfo.svc.cluster.local"})
+	assert.NoError(err)
+	assert.Len(endpoints, 1)
+
+	_, err = layer.Proxy.GetProxyEndpoints("bookinfo", "no-sidecar", ProxyConfigFilter{})
+	assert.Error(err)
+}
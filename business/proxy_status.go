@@ -0,0 +1,183 @@
+package business
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// ErrProxyStatusDisabled is returned when the sync status of the proxies is read but is not enabled
+var ErrProxyStatusDisabled = errors.New("proxy status is not enabled")
+
+// proxyStatusTTL is how long the sync status of the proxies is kept. It is read on every graph request, and each
+// read queries all the istiod pods.
+const proxyStatusTTL = 10 * time.Second
+
+// proxyStatusCache keeps the sync status of the proxies by client, as each client reads istiod with its own token
+var proxyStatusCache = struct {
+	sync.Mutex
+	entries map[kubernetes.IstiodDebugClient]proxyStatusEntry
+}{entries: map[kubernetes.IstiodDebugClient]proxyStatusEntry{}}
+
+type proxyStatusEntry struct {
+	statuses map[string]models.ProxyStatus
+	fetched  time.Time
+}
+
+// istiodSyncStatus is an entry of the syncz debug endpoint of istiod, the nonces of the last configuration sent
+// to a proxy and acknowledged by it, by xDS type
+type istiodSyncStatus struct {
+	ProxyID       string `json:"proxy"`
+	IstioVersion  string `json:"istio_version"`
+	ClusterSent   string `json:"cluster_sent"`
+	ClusterAcked  string `json:"cluster_acked"`
+	ListenerSent  string `json:"listener_sent"`
+	ListenerAcked string `json:"listener_acked"`
+	RouteSent     string `json:"route_sent"`
+	RouteAcked    string `json:"route_acked"`
+	EndpointSent  string `json:"endpoint_sent"`
+	EndpointAcked string `json:"endpoint_acked"`
+}
+
+// GetNamespaceProxyStatus returns the sync status of the proxies of a namespace and the workloads of the stale ones
+func (in *ProxyService) GetNamespaceProxyStatus(namespace string) (*models.NamespaceProxyStatus, error) {
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err := in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return nil, err
+	}
+
+	statuses, err := in.getProxyStatuses()
+	if err != nil {
+		return nil, err
+	}
+	workloads, err := fetchWorkloads(in.businessLayer, namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	return namespaceProxyStatus(namespace, workloads, statuses), nil
+}
+
+// setProxyStatus sets the sync status of the proxies of the pods of a workload. It is best effort, the status is
+// left empty when istiod can't be queried.
+func (in *ProxyService) setProxyStatus(namespace string, workload *models.Workload) {
+	if !config.Get().ExternalServices.Istio.ProxyStatusEnabled || !workload.IstioSidecar || len(workload.Pods) == 0 {
+		return
+	}
+	statuses, err := in.getProxyStatuses()
+	if err != nil {
+		log.Debugf("Proxy status of workload %s/%s could not be read: %v", namespace, workload.Name, err)
+		return
+	}
+	for _, pod := range workload.Pods {
+		if status, found := statuses[pod.Name+"."+namespace]; found {
+			pod.ProxyStatus = &status
+		}
+	}
+}
+
+// getProxyStatuses returns the sync status of the proxies connected to every istiod, by proxy id as <pod>.<namespace>
+func (in *ProxyService) getProxyStatuses() (map[string]models.ProxyStatus, error) {
+	if !config.Get().ExternalServices.Istio.ProxyStatusEnabled {
+		return nil, ErrProxyStatusDisabled
+	}
+	if in.istiodDebug == nil {
+		return nil, fmt.Errorf("the debug endpoints of istiod are not reachable with the current client")
+	}
+
+	// the cache is not locked while istiod is queried, concurrent reads of an expired entry query it again
+	proxyStatusCache.Lock()
+	entry, found := proxyStatusCache.entries[in.istiodDebug]
+	proxyStatusCache.Unlock()
+	if found && time.Since(entry.fetched) < proxyStatusTTL {
+		return entry.statuses, nil
+	}
+
+	statuses, err := in.fetchProxyStatuses()
+	if err != nil {
+		return nil, err
+	}
+
+	proxyStatusCache.Lock()
+	defer proxyStatusCache.Unlock()
+	now := time.Now()
+	for client, entry := range proxyStatusCache.entries {
+		if now.Sub(entry.fetched) >= proxyStatusTTL {
+			delete(proxyStatusCache.entries, client)
+		}
+	}
+	proxyStatusCache.entries[in.istiodDebug] = proxyStatusEntry{statuses: statuses, fetched: now}
+	return statuses, nil
+}
+
+func (in *ProxyService) fetchProxyStatuses() (map[string]models.ProxyStatus, error) {
+	responses, err := in.istiodDebug.GetIstiodDebug("debug/syncz")
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]models.ProxyStatus{}
+	for istiod, response := range responses {
+		syncz := []istiodSyncStatus{}
+		if err := json.Unmarshal(response, &syncz); err != nil {
+			return nil, err
+		}
+		for _, s := range syncz {
+			result[s.ProxyID] = models.ProxyStatus{
+				Istiod:       istiod,
+				IstioVersion: s.IstioVersion,
+				CDS:          xdsStatus(s.ClusterSent, s.ClusterAcked),
+				LDS:          xdsStatus(s.ListenerSent, s.ListenerAcked),
+				EDS:          xdsStatus(s.EndpointSent, s.EndpointAcked),
+				RDS:          xdsStatus(s.RouteSent, s.RouteAcked),
+			}
+		}
+	}
+	return result, nil
+}
+
+func namespaceProxyStatus(namespace string, workloads models.Workloads, statuses map[string]models.ProxyStatus) *models.NamespaceProxyStatus {
+	result := &models.NamespaceProxyStatus{
+		Namespace:      namespace,
+		Proxies:        []models.PodProxyStatus{},
+		StaleWorkloads: []string{},
+	}
+	for _, w := range workloads {
+		stale := false
+		for _, pod := range w.Pods {
+			status, found := statuses[pod.Name+"."+namespace]
+			if !found {
+				continue
+			}
+			result.Proxies = append(result.Proxies, models.PodProxyStatus{ProxyStatus: status, Pod: pod.Name, Workload: w.Name})
+			stale = stale || status.IsStale()
+		}
+		if stale {
+			result.StaleWorkloads = append(result.StaleWorkloads, w.Name)
+		}
+	}
+	sort.Slice(result.Proxies, func(i, j int) bool {
+		return result.Proxies[i].Pod < result.Proxies[j].Pod
+	})
+	sort.Strings(result.StaleWorkloads)
+	return result
+}
+
+// xdsStatus compares the nonces of the last configuration sent and acknowledged, as istioctl proxy-status does
+func xdsStatus(sent, acked string) string {
+	if sent == "" {
+		return models.ProxyNotSent
+	}
+	if sent == acked {
+		return models.ProxySynced
+	}
+	return models.ProxyStale
+}
@@ -0,0 +1,158 @@
+package business
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+type fakeIstiodDebug struct {
+	responses map[string][]byte
+	calls     int
+}
+
+func (f *fakeIstiodDebug) GetIstiodDebug(path string) (map[string][]byte, error) {
+	f.calls++
+	if len(f.responses) == 0 {
+		return nil, fmt.Errorf("no running istiod pod found")
+	}
+	return f.responses, nil
+}
+
+const fakeSyncz = `[
+	{
+		"proxy": "reviews-v1-545db77b95-2bqfl.bookinfo",
+		"istio_version": "1.7.0",
+		"cluster_sent": "n1",
+		"cluster_acked": "n1",
+		"listener_sent": "n2",
+		"listener_acked": "n2",
+		"route_sent": "n3",
+		"route_acked": "n2",
+		"endpoint_sent": "n4",
+		"endpoint_acked": "n4"
+	},
+	{
+		"proxy": "reviews-v2-7bf8c9648f-6bbgt.bookinfo",
+		"istio_version": "1.7.0",
+		"cluster_sent": "n1",
+		"cluster_acked": "n1",
+		"listener_sent": "n2",
+		"listener_acked": "n2",
+		"route_sent": "n3",
+		"route_acked": "n3"
+	}
+]`
+
+func TestXdsStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(models.ProxySynced, xdsStatus("n1", "n1"))
+	assert.Equal(models.ProxyStale, xdsStatus("n2", "n1"))
+	assert.Equal(models.ProxyStale, xdsStatus("n1", ""))
+	assert.Equal(models.ProxyNotSent, xdsStatus("", ""))
+}
+
+func TestGetProxyStatuses(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	k8s := new(kubetest.K8SClientMock)
+	k8s.On("IsOpenShift").Return(false)
+	k8s.On("IsMaistraApi").Return(false)
+
+	layer := NewWithBackends(k8s, nil, nil)
+	_, err := layer.Proxy.getProxyStatuses()
+	assert.Error(err)
+
+	istiodDebug := &fakeIstiodDebug{responses: map[string][]byte{
+		"istiod-5c8c5b8c8-7xkzp": []byte(fakeSyncz),
+		"istiod-5c8c5b8c8-q2wmv": []byte(`[{"proxy": "productpage-v1-6987489c74-x6v4b.bookinfo", "cluster_sent": "n1", "cluster_acked": "n1"}]`),
+	}}
+	layer.Proxy.istiodDebug = istiodDebug
+	statuses, err := layer.Proxy.getProxyStatuses()
+	assert.NoError(err)
+	assert.Len(statuses, 3)
+
+	// istiod is queried once while the statuses are cached
+	_, err = layer.Proxy.getProxyStatuses()
+	assert.NoError(err)
+	assert.Equal(1, istiodDebug.calls)
+
+	reviewsV1 := statuses["reviews-v1-545db77b95-2bqfl.bookinfo"]
+	assert.Equal("istiod-5c8c5b8c8-7xkzp", reviewsV1.Istiod)
+	assert.Equal("1.7.0", reviewsV1.IstioVersion)
+	assert.Equal(models.ProxySynced, reviewsV1.CDS)
+	assert.Equal(models.ProxySynced, reviewsV1.LDS)
+	assert.Equal(models.ProxyStale, reviewsV1.RDS)
+	assert.Equal(models.ProxySynced, reviewsV1.EDS)
+	assert.True(reviewsV1.IsStale())
+
+	reviewsV2 := statuses["reviews-v2-7bf8c9648f-6bbgt.bookinfo"]
+	assert.Equal(models.ProxyNotSent, reviewsV2.EDS)
+	assert.False(reviewsV2.IsStale())
+
+	productpage := statuses["productpage-v1-6987489c74-x6v4b.bookinfo"]
+	assert.Equal("istiod-5c8c5b8c8-q2wmv", productpage.Istiod)
+	assert.Equal(models.ProxyNotSent, productpage.LDS)
+}
+
+func TestGetProxyStatusesDisabled(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.ProxyStatusEnabled = false
+	config.Set(conf)
+
+	k8s := new(kubetest.K8SClientMock)
+	k8s.On("IsOpenShift").Return(false)
+	k8s.On("IsMaistraApi").Return(false)
+
+	istiodDebug := &fakeIstiodDebug{responses: map[string][]byte{"istiod-5c8c5b8c8-7xkzp": []byte(fakeSyncz)}}
+	layer := NewWithBackends(k8s, nil, nil)
+	layer.Proxy.istiodDebug = istiodDebug
+
+	_, err := layer.Proxy.getProxyStatuses()
+	assert.Equal(ErrProxyStatusDisabled, err)
+	assert.Zero(istiodDebug.calls)
+}
+
+func TestNamespaceProxyStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	workload := func(name string, pods ...string) *models.Workload {
+		w := &models.Workload{}
+		w.Name = name
+		for _, p := range pods {
+			w.Pods = append(w.Pods, &models.Pod{Name: p})
+		}
+		return w
+	}
+	workloads := models.Workloads{
+		workload("reviews-v2", "reviews-v2-7bf8c9648f-6bbgt"),
+		workload("reviews-v1", "reviews-v1-545db77b95-2bqfl", "reviews-v1-545db77b95-8tkrx"),
+		workload("ratings-v1", "ratings-v1-6c9dbf6b45-rl7bd"),
+	}
+	statuses := map[string]models.ProxyStatus{
+		"reviews-v1-545db77b95-2bqfl.bookinfo": {CDS: models.ProxySynced, LDS: models.ProxySynced, EDS: models.ProxySynced, RDS: models.ProxySynced},
+		"reviews-v1-545db77b95-8tkrx.bookinfo": {CDS: models.ProxySynced, LDS: models.ProxyStale, EDS: models.ProxySynced, RDS: models.ProxySynced},
+		"reviews-v2-7bf8c9648f-6bbgt.bookinfo": {CDS: models.ProxySynced, LDS: models.ProxySynced, EDS: models.ProxyNotSent, RDS: models.ProxySynced},
+		"reviews-v2-7bf8c9648f-6bbgt.other":    {CDS: models.ProxyStale, LDS: models.ProxyStale, EDS: models.ProxyStale, RDS: models.ProxyStale},
+	}
+
+	status := namespaceProxyStatus("bookinfo", workloads, statuses)
+	assert.Equal("bookinfo", status.Namespace)
+	assert.Equal([]string{"reviews-v1"}, status.StaleWorkloads)
+
+	assert.Len(status.Proxies, 3)
+	assert.Equal("reviews-v1-545db77b95-2bqfl", status.Proxies[0].Pod)
+	assert.Equal("reviews-v1", status.Proxies[0].Workload)
+	assert.Equal("reviews-v1-545db77b95-8tkrx", status.Proxies[1].Pod)
+	assert.Equal(models.ProxyStale, status.Proxies[1].LDS)
+	assert.Equal("reviews-v2-7bf8c9648f-6bbgt", status.Proxies[2].Pod)
+	assert.Equal("reviews-v2", status.Proxies[2].Workload)
+}
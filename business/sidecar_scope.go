@@ -0,0 +1,271 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// SidecarScopeService computes the effective Sidecar of the workloads
+type SidecarScopeService struct {
+	k8s           kubernetes.IstioClientInterface
+	businessLayer *Layer
+}
+
+// SidecarScopes computes the effective Sidecar of the workloads of a namespace. The services and ServiceEntries
+// of the mesh are read on demand and kept, so it is meant to be used for a single request.
+type SidecarScopes struct {
+	namespace      string
+	sidecars       []kubernetes.IstioObject
+	rootSidecars   []kubernetes.IstioObject
+	service        *SidecarScopeService
+	namespaces     []string
+	services       map[string][]core_v1.Service
+	serviceEntries map[string][]kubernetes.IstioObject
+}
+
+// GetSidecarScope returns the effective Sidecar of a workload, the services it makes reachable and its invalid hosts
+func (in *SidecarScopeService) GetSidecarScope(namespace, workload string) (*models.SidecarScope, error) {
+	wk, err := in.businessLayer.Workload.GetWorkload(namespace, workload, false)
+	if err != nil {
+		return nil, err
+	}
+	scopes, err := in.GetSidecarScopes(namespace)
+	if err != nil {
+		return nil, err
+	}
+	return scopes.Compute(workload, wk.Labels)
+}
+
+// GetSidecarScopes reads the Sidecars applying to the workloads of a namespace
+func (in *SidecarScopeService) GetSidecarScopes(namespace string) (*SidecarScopes, error) {
+	sidecars, err := in.k8s.GetSidecars(namespace)
+	if err != nil {
+		return nil, err
+	}
+	rootSidecars := []kubernetes.IstioObject{}
+	if rootNs := config.Get().IstioNamespace; namespace != rootNs {
+		if rootSidecars, err = in.k8s.GetSidecars(rootNs); err != nil {
+			return nil, err
+		}
+	}
+	return &SidecarScopes{
+		namespace:      namespace,
+		sidecars:       sidecars,
+		rootSidecars:   rootSidecars,
+		service:        in,
+		services:       map[string][]core_v1.Service{},
+		serviceEntries: map[string][]kubernetes.IstioObject{},
+	}, nil
+}
+
+// Compute returns the effective Sidecar of a workload of the namespace, the services and ServiceEntries of the mesh
+// it makes reachable and its invalid egress hosts.
+func (s *SidecarScopes) Compute(workload string, workloadLabels map[string]string) (*models.SidecarScope, error) {
+	scope := s.Effective(workload, workloadLabels)
+
+	namespaces, err := s.getNamespaces()
+	if err != nil {
+		return nil, err
+	}
+	matched := make([]bool, len(scope.Hosts))
+	for _, ns := range namespaces {
+		services, serviceEntries, err := s.getServices(ns)
+		if err != nil {
+			return nil, err
+		}
+		for _, svc := range services {
+			host := fmt.Sprintf("%s.%s.%s", svc.Name, svc.Namespace, config.Get().ExternalServices.Istio.IstioIdentityDomain)
+			if s.reaches(scope, matched, ns, host) {
+				scope.Services = append(scope.Services, models.SidecarScopeService{Name: svc.Name, Namespace: svc.Namespace, Host: host})
+			}
+		}
+		for _, se := range serviceEntries {
+			reachable := models.SidecarScopeServiceEntry{Name: se.GetObjectMeta().Name, Namespace: ns, Hosts: []string{}}
+			if hosts, ok := se.GetSpec()["hosts"].([]interface{}); ok {
+				for _, h := range hosts {
+					if host, ok := h.(string); ok && s.reaches(scope, matched, ns, host) {
+						reachable.Hosts = append(reachable.Hosts, host)
+					}
+				}
+			}
+			if len(reachable.Hosts) > 0 {
+				scope.ServiceEntries = append(scope.ServiceEntries, reachable)
+			}
+		}
+	}
+
+	for i := range scope.Hosts {
+		h := &scope.Hosts[i]
+		parts := strings.Split(h.Host, "/")
+		switch {
+		case len(parts) != 2:
+			h.Valid, h.Message = false, "Invalid host format, expected namespace/dnsName"
+		case parts[0] == "~" || parts[1] == "*":
+		case !matched[i]:
+			h.Valid, h.Message = false, "No Service or ServiceEntry found for the host"
+		}
+	}
+	return scope, nil
+}
+
+// Effective returns the effective Sidecar of a workload of the namespace and its egress hosts, without looking up
+// what they reach. Istio applies, by precedence, the Sidecar selecting the workload, the Sidecar of the namespace
+// without selector and the Sidecar of the root namespace without selector. When several Sidecars apply at the same
+// level the oldest one is used.
+func (s *SidecarScopes) Effective(workload string, workloadLabels map[string]string) *models.SidecarScope {
+	scope := &models.SidecarScope{
+		Namespace:      s.namespace,
+		Workload:       workload,
+		Hosts:          []models.SidecarScopeHost{},
+		Services:       []models.SidecarScopeService{},
+		ServiceEntries: []models.SidecarScopeServiceEntry{},
+	}
+
+	selecting, namespaceWide := []kubernetes.IstioObject{}, []kubernetes.IstioObject{}
+	for _, sc := range s.sidecars {
+		if !sc.HasWorkloadSelectorLabels() {
+			namespaceWide = append(namespaceWide, sc)
+		} else if selector := sidecarSelector(sc); selector != nil && selector.Matches(labels.Set(workloadLabels)) {
+			selecting = append(selecting, sc)
+		}
+	}
+	rootWide := []kubernetes.IstioObject{}
+	for _, sc := range s.rootSidecars {
+		if !sc.HasWorkloadSelectorLabels() {
+			rootWide = append(rootWide, sc)
+		}
+	}
+
+	var sidecar kubernetes.IstioObject
+	for _, level := range []struct {
+		name     string
+		sidecars []kubernetes.IstioObject
+	}{
+		{models.SidecarScopeWorkload, selecting},
+		{models.SidecarScopeNamespace, namespaceWide},
+		{models.SidecarScopeRoot, rootWide},
+	} {
+		if len(level.sidecars) == 0 {
+			continue
+		}
+		sort.SliceStable(level.sidecars, func(i, j int) bool {
+			return level.sidecars[i].GetObjectMeta().CreationTimestamp.Time.Before(level.sidecars[j].GetObjectMeta().CreationTimestamp.Time)
+		})
+		sidecar = level.sidecars[0]
+		scope.Sidecar = &models.SidecarScopeSidecar{
+			Name:      sidecar.GetObjectMeta().Name,
+			Namespace: sidecar.GetObjectMeta().Namespace,
+			Level:     level.name,
+		}
+		for _, other := range level.sidecars[1:] {
+			scope.Conflicts = append(scope.Conflicts, other.GetObjectMeta().Namespace+"/"+other.GetObjectMeta().Name)
+		}
+		break
+	}
+
+	if sidecar != nil {
+		if otp, ok := sidecar.GetSpec()["outboundTrafficPolicy"].(map[string]interface{}); ok {
+			scope.OutboundTrafficPolicy, _ = otp["mode"].(string)
+		}
+		for _, host := range sidecarEgressHosts(sidecar) {
+			scope.Hosts = append(scope.Hosts, models.SidecarScopeHost{Host: host, Valid: true})
+		}
+	}
+	return scope
+}
+
+// reaches returns true when the host is in the scope, and marks the egress hosts matching it
+func (s *SidecarScopes) reaches(scope *models.SidecarScope, matched []bool, namespace, host string) bool {
+	if scope.Sidecar == nil {
+		return true
+	}
+	reachable := false
+	for i, h := range scope.Hosts {
+		if models.SidecarHostMatches(h.Host, s.namespace, namespace, host) {
+			matched[i], reachable = true, true
+		}
+	}
+	return reachable
+}
+
+func (s *SidecarScopes) getNamespaces() ([]string, error) {
+	if s.namespaces != nil {
+		return s.namespaces, nil
+	}
+	namespaces, err := s.service.businessLayer.Namespace.GetNamespaces()
+	if err != nil {
+		return nil, err
+	}
+	s.namespaces = make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		s.namespaces = append(s.namespaces, ns.Name)
+	}
+	sort.Strings(s.namespaces)
+	return s.namespaces, nil
+}
+
+func (s *SidecarScopes) getServices(namespace string) ([]core_v1.Service, []kubernetes.IstioObject, error) {
+	if _, ok := s.services[namespace]; !ok {
+		services, err := s.service.k8s.GetServices(namespace, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		serviceEntries, err := s.service.k8s.GetServiceEntries(namespace)
+		if err != nil {
+			return nil, nil, err
+		}
+		s.services[namespace], s.serviceEntries[namespace] = services, serviceEntries
+	}
+	return s.services[namespace], s.serviceEntries[namespace], nil
+}
+
+func sidecarSelector(sc kubernetes.IstioObject) labels.Selector {
+	ws, ok := sc.GetSpec()["workloadSelector"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	wsLabels, ok := ws["labels"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	set := labels.Set{}
+	for k, v := range wsLabels {
+		if s, ok := v.(string); ok {
+			set[k] = s
+		}
+	}
+	return labels.SelectorFromSet(set)
+}
+
+// sidecarEgressHosts returns the hosts of all the egress listeners of a Sidecar, without duplicates. As in Istio, a
+// Sidecar without egress listeners, i.e. only setting the ingress or the outboundTrafficPolicy, reaches */*.
+func sidecarEgressHosts(sc kubernetes.IstioObject) []string {
+	hosts := []string{}
+	seen := map[string]bool{}
+	egress, _ := sc.GetSpec()["egress"].([]interface{})
+	if len(egress) == 0 {
+		return []string{"*/*"}
+	}
+	for _, e := range egress {
+		listener, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		listenerHosts, _ := listener["hosts"].([]interface{})
+		for _, h := range listenerHosts {
+			if host, ok := h.(string); ok && !seen[host] {
+				seen[host] = true
+				hosts = append(hosts, host)
+			}
+		}
+	}
+	return hosts
+}
@@ -0,0 +1,117 @@
+package business
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestComputeSidecarScope(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	rootDefault := data.AddHostsToSidecar([]interface{}{"*/*"}, data.CreateSidecar("default", "istio-system"))
+	nsDefault := data.AddHostsToSidecar([]interface{}{"./*", "istio-system/*"}, data.CreateSidecar("default", "bookinfo"))
+	reviews := data.AddHostsToSidecar([]interface{}{"./ratings.bookinfo.svc.cluster.local", "./unknown.example.com", "bad-host", "external/*.example.com"},
+		data.AddSelectorToSidecar(map[string]interface{}{"labels": map[string]interface{}{"app": "reviews"}},
+			data.CreateSidecar("reviews", "bookinfo")))
+	reviewsNew := data.AddSelectorToSidecar(map[string]interface{}{"labels": map[string]interface{}{"app": "reviews"}},
+		data.CreateSidecar("reviews-new", "bookinfo"))
+	meta := reviewsNew.GetObjectMeta()
+	meta.CreationTimestamp = meta_v1.NewTime(time.Now())
+	reviewsNew.SetObjectMeta(meta)
+
+	k8s := new(kubetest.K8SClientMock)
+	k8s.On("IsOpenShift").Return(false)
+	k8s.On("IsMaistraApi").Return(false)
+	k8s.On("GetNamespaces").Return([]core_v1.Namespace{
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "external"}},
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+	}, nil)
+	k8s.On("GetSidecars", "bookinfo").Return([]kubernetes.IstioObject{reviewsNew, nsDefault, reviews}, nil)
+	k8s.On("GetSidecars", "istio-system").Return([]kubernetes.IstioObject{rootDefault}, nil)
+	k8s.On("GetSidecars", "other").Return([]kubernetes.IstioObject{}, nil)
+	k8s.On("GetServices", "bookinfo", mock.Anything).Return([]core_v1.Service{
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "details", Namespace: "bookinfo"}},
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "ratings", Namespace: "bookinfo"}},
+	}, nil)
+	k8s.On("GetServices", "external", mock.Anything).Return([]core_v1.Service{}, nil)
+	k8s.On("GetServices", "istio-system", mock.Anything).Return([]core_v1.Service{
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "istiod", Namespace: "istio-system"}},
+	}, nil)
+	k8s.On("GetServiceEntries", "bookinfo").Return([]kubernetes.IstioObject{}, nil)
+	k8s.On("GetServiceEntries", "external").Return([]kubernetes.IstioObject{
+		data.CreateEmptyMeshExternalServiceEntry("api", "external", []string{"api.example.com", "api.example.org"}),
+	}, nil)
+	k8s.On("GetServiceEntries", "istio-system").Return([]kubernetes.IstioObject{}, nil)
+
+	layer := NewWithBackends(k8s, nil, nil)
+	scopes, err := layer.SidecarScope.GetSidecarScopes("bookinfo")
+	assert.NoError(err)
+
+	// the oldest Sidecar selecting the workload wins
+	scope, err := scopes.Compute("reviews-v1", map[string]string{"app": "reviews"})
+	assert.NoError(err)
+	assert.Equal(&models.SidecarScopeSidecar{Name: "reviews", Namespace: "bookinfo", Level: models.SidecarScopeWorkload}, scope.Sidecar)
+	assert.Equal([]string{"bookinfo/reviews-new"}, scope.Conflicts)
+	assert.Equal([]models.SidecarScopeService{{Name: "ratings", Namespace: "bookinfo", Host: "ratings.bookinfo.svc.cluster.local"}}, scope.Services)
+	assert.Equal([]models.SidecarScopeServiceEntry{{Name: "api", Namespace: "external", Hosts: []string{"api.example.com"}}}, scope.ServiceEntries)
+	assert.Equal([]models.SidecarScopeHost{
+		{Host: "./ratings.bookinfo.svc.cluster.local", Valid: true},
+		{Host: "./unknown.example.com", Valid: false, Message: "No Service or ServiceEntry found for the host"},
+		{Host: "bad-host", Valid: false, Message: "Invalid host format, expected namespace/dnsName"},
+		{Host: "external/*.example.com", Valid: true},
+	}, scope.Hosts)
+	assert.False(scope.IsReachable("bookinfo", "details.bookinfo.svc.cluster.local"))
+
+	// other workloads get the Sidecar of the namespace
+	scope, err = scopes.Compute("details-v1", map[string]string{"app": "details"})
+	assert.NoError(err)
+	assert.Equal(models.SidecarScopeNamespace, scope.Sidecar.Level)
+	assert.Empty(scope.Conflicts)
+	assert.Len(scope.Services, 3)
+	assert.Empty(scope.ServiceEntries)
+
+	// without Sidecars in the namespace the one of the root namespace applies
+	scopes, err = layer.SidecarScope.GetSidecarScopes("other")
+	assert.NoError(err)
+	scope = scopes.Effective("productpage-v1", map[string]string{"app": "productpage"})
+	assert.Equal(&models.SidecarScopeSidecar{Name: "default", Namespace: "istio-system", Level: models.SidecarScopeRoot}, scope.Sidecar)
+	assert.True(scope.IsReachable("bookinfo", "details.bookinfo.svc.cluster.local"))
+}
+
+func TestSidecarWithoutEgressReachesEveryHost(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	registryOnly := data.CreateSidecar("default", "istio-system")
+	registryOnly.GetSpec()["outboundTrafficPolicy"] = map[string]interface{}{"mode": "REGISTRY_ONLY"}
+
+	k8s := new(kubetest.K8SClientMock)
+	k8s.On("IsOpenShift").Return(false)
+	k8s.On("IsMaistraApi").Return(false)
+	k8s.On("GetSidecars", "bookinfo").Return([]kubernetes.IstioObject{}, nil)
+	k8s.On("GetSidecars", "istio-system").Return([]kubernetes.IstioObject{registryOnly}, nil)
+
+	layer := NewWithBackends(k8s, nil, nil)
+	scopes, err := layer.SidecarScope.GetSidecarScopes("bookinfo")
+	assert.NoError(err)
+
+	scope := scopes.Effective("productpage-v1", map[string]string{"app": "productpage"})
+	assert.Equal(&models.SidecarScopeSidecar{Name: "default", Namespace: "istio-system", Level: models.SidecarScopeRoot}, scope.Sidecar)
+	assert.Equal("REGISTRY_ONLY", scope.OutboundTrafficPolicy)
+	assert.Equal([]models.SidecarScopeHost{{Host: "*/*", Valid: true}}, scope.Hosts)
+	assert.True(scope.IsReachable("bookinfo", "details.bookinfo.svc.cluster.local"))
+	assert.True(scope.IsReachable("other", "api.other.svc.cluster.local"))
+}
@@ -7,10 +7,12 @@ import (
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
 )
 
 type TLSService struct {
 	k8s             kubernetes.IstioClientInterface
+	prom            prometheus.ClientInterface
 	businessLayer   *Layer
 	enabledAutoMtls *bool
 }
@@ -20,6 +22,8 @@ const (
 	MTLSPartiallyEnabled = "MTLS_PARTIALLY_ENABLED"
 	MTLSNotEnabled       = "MTLS_NOT_ENABLED"
 	MTLSDisabled         = "MTLS_DISABLED"
+	// MTLSConflict is the status of the traffic rejected because the client and the server TLS modes do not match
+	MTLSConflict = "MTLS_CONFLICT"
 )
 
 func (in *TLSService) MeshWidemTLSStatus(namespaces []string) (models.MTLSStatus, error) {
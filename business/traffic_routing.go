@@ -0,0 +1,320 @@
+package business
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// WizardLabel marks the Istio objects generated by the traffic routing wizard
+const WizardLabel = "kiali_wizard"
+
+// ApplyTrafficRouting generates the DestinationRule and the VirtualService routing the traffic of a service as
+// requested, and validates them against the current state of the namespace. Unless it is a dry run, the objects
+// are created when they are valid, the DestinationRule is deleted back if the VirtualService can't be created.
+func (in *IstioConfigService) ApplyTrafficRouting(namespace, service string, request models.TrafficRoutingRequest, dryRun bool) (models.TrafficRouting, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "ApplyTrafficRouting")
+	defer promtimer.ObserveNow(&err)
+
+	routing := models.TrafficRouting{}
+
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err = in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return routing, err
+	}
+
+	svc, err := in.k8s.GetService(namespace, service)
+	if err != nil {
+		return routing, err
+	}
+	versions := []string{}
+	if selector := labels.Set(svc.Spec.Selector).String(); selector != "" {
+		var ws models.Workloads
+		if ws, err = fetchWorkloads(in.businessLayer, namespace, selector); err != nil {
+			return routing, err
+		}
+		versions = workloadVersions(ws)
+	}
+
+	dr, vs, err := buildTrafficRouting(namespace, service, versions, request)
+	if err != nil {
+		return routing, err
+	}
+	routing.DestinationRule = &models.DestinationRule{}
+	routing.DestinationRule.Parse(dr)
+	routing.VirtualService = &models.VirtualService{}
+	routing.VirtualService.Parse(vs)
+
+	current, err := in.businessLayer.Validations.fetchDryRunObjects(namespace)
+	if err != nil {
+		return routing, err
+	}
+	for resourceType, obj := range map[string]kubernetes.IstioObject{DestinationRules: dr, VirtualServices: vs} {
+		if current.find(resourceType, namespace, obj.GetObjectMeta().Name) != nil {
+			err = errors2.NewAlreadyExists(schema.GroupResource{Group: kubernetes.NetworkingGroupVersion.Group, Resource: resourceType}, obj.GetObjectMeta().Name)
+			return routing, err
+		}
+	}
+	validations := in.businessLayer.Validations.runDryRunCheckers(namespace, current.with(DestinationRules, dr).with(VirtualServices, vs))
+	routing.Validations = models.IstioValidations{}
+	for objectType, name := range map[string]string{"destinationrule": dr.GetObjectMeta().Name, "virtualservice": vs.GetObjectMeta().Name} {
+		routing.Validations.MergeValidations(validations.FilterByKey(objectType, name))
+	}
+
+	if dryRun {
+		return routing, nil
+	}
+	for _, v := range routing.Validations {
+		if !v.Valid {
+			return routing, nil
+		}
+	}
+	routing.Changes, err = in.createTrafficRouting(namespace, dr, vs)
+	if err != nil {
+		return routing, err
+	}
+	routing.Applied = true
+	return routing, nil
+}
+
+// createTrafficRouting creates the DestinationRule and then the VirtualService, so the subsets exist when the
+// routes are applied. The DestinationRule is deleted when the VirtualService fails. The changes attempted are
+// returned so they can be audited.
+func (in *IstioConfigService) createTrafficRouting(namespace string, dr, vs kubernetes.IstioObject) ([]models.TrafficRoutingChange, error) {
+	api := GetIstioAPI(DestinationRules)
+	drJson, err := json.Marshal(dr)
+	if err != nil {
+		return nil, err
+	}
+	vsJson, err := json.Marshal(vs)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []models.TrafficRoutingChange{}
+	_, err = in.k8s.CreateIstioObject(api, namespace, DestinationRules, string(drJson))
+	changes = append(changes, models.TrafficRoutingChange{Operation: models.AuditCreate, ObjectType: DestinationRules, Name: dr.GetObjectMeta().Name, Object: drJson, Error: err})
+	if err != nil {
+		return changes, err
+	}
+	_, err = in.k8s.CreateIstioObject(api, namespace, VirtualServices, string(vsJson))
+	changes = append(changes, models.TrafficRoutingChange{Operation: models.AuditCreate, ObjectType: VirtualServices, Name: vs.GetObjectMeta().Name, Object: vsJson, Error: err})
+	if err != nil {
+		rollbackErr := in.k8s.DeleteIstioObject(api, namespace, DestinationRules, dr.GetObjectMeta().Name)
+		changes = append(changes, models.TrafficRoutingChange{Operation: models.AuditDelete, ObjectType: DestinationRules, Name: dr.GetObjectMeta().Name, Error: rollbackErr})
+		if rollbackErr != nil {
+			log.Errorf("DestinationRule [%s/%s] could not be deleted after the VirtualService failed: %v", namespace, dr.GetObjectMeta().Name, rollbackErr)
+		}
+		return changes, err
+	}
+
+	// Cache is stopped after a Create/Update/Delete operation to force a refresh
+	if kialiCache != nil {
+		kialiCache.RefreshNamespace(namespace)
+	}
+	return changes, nil
+}
+
+// workloadVersions returns the sorted values of the version label of the workloads
+func workloadVersions(ws models.Workloads) []string {
+	versionLabel := config.Get().IstioLabels.VersionLabelName
+	found := map[string]bool{}
+	versions := []string{}
+	for _, w := range ws {
+		if v, ok := w.Labels[versionLabel]; ok && !found[v] {
+			found[v] = true
+			versions = append(versions, v)
+		}
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// buildTrafficRouting generates the DestinationRule, with a subset per version, and the VirtualService of a request
+func buildTrafficRouting(namespace, service string, versions []string, request models.TrafficRoutingRequest) (kubernetes.IstioObject, kubernetes.IstioObject, error) {
+	conf := config.Get()
+	host := fmt.Sprintf("%s.%s.%s", service, namespace, conf.ExternalServices.Istio.IstioIdentityDomain)
+
+	known := map[string]bool{}
+	for _, v := range versions {
+		known[v] = true
+	}
+	checkVersion := func(version string) error {
+		if !known[version] {
+			return errors2.NewBadRequest(fmt.Sprintf("version [%s] not found in the workloads of service [%s]", version, service))
+		}
+		return nil
+	}
+
+	wizard := "timeouts"
+	switch {
+	case len(request.MatchRoutes) > 0:
+		wizard = "request_routing"
+	case len(request.WeightedRoutes) > 0:
+		wizard = "weighted_routing"
+	case request.FaultInjection != nil:
+		wizard = "fault_injection"
+	}
+	meta := meta_v1.ObjectMeta{Name: service, Namespace: namespace, Labels: map[string]string{WizardLabel: wizard}}
+
+	// options applied to every route
+	options := map[string]interface{}{}
+	if f := request.FaultInjection; f != nil {
+		fault := map[string]interface{}{}
+		if f.Delay != nil {
+			if f.Delay.Percentage < 0 || f.Delay.Percentage > 100 || f.Delay.FixedDelay == "" {
+				return nil, nil, errors2.NewBadRequest("delay fault needs a percentage between 0 and 100 and a fixed delay")
+			}
+			fault["delay"] = map[string]interface{}{
+				"percentage": map[string]interface{}{"value": f.Delay.Percentage},
+				"fixedDelay": f.Delay.FixedDelay,
+			}
+		}
+		if f.Abort != nil {
+			if f.Abort.Percentage < 0 || f.Abort.Percentage > 100 || f.Abort.HttpStatus <= 0 {
+				return nil, nil, errors2.NewBadRequest("abort fault needs a percentage between 0 and 100 and an HTTP status")
+			}
+			fault["abort"] = map[string]interface{}{
+				"percentage": map[string]interface{}{"value": f.Abort.Percentage},
+				"httpStatus": f.Abort.HttpStatus,
+			}
+		}
+		if len(fault) > 0 {
+			options["fault"] = fault
+		}
+	}
+	if request.Timeout != "" {
+		options["timeout"] = request.Timeout
+	}
+	if r := request.Retries; r != nil {
+		if r.Attempts <= 0 {
+			return nil, nil, errors2.NewBadRequest("retries need a positive number of attempts")
+		}
+		retries := map[string]interface{}{"attempts": r.Attempts}
+		if r.PerTryTimeout != "" {
+			retries["perTryTimeout"] = r.PerTryTimeout
+		}
+		if r.RetryOn != "" {
+			retries["retryOn"] = r.RetryOn
+		}
+		options["retries"] = retries
+	}
+	newRoute := func(route []interface{}) map[string]interface{} {
+		http := map[string]interface{}{"route": route}
+		for k, v := range options {
+			http[k] = v
+		}
+		return http
+	}
+	destination := func(subset string) map[string]interface{} {
+		d := map[string]interface{}{"host": host}
+		if subset != "" {
+			d["subset"] = subset
+		}
+		return d
+	}
+
+	httpRoutes := []interface{}{}
+	for _, mr := range request.MatchRoutes {
+		if err := checkVersion(mr.Version); err != nil {
+			return nil, nil, err
+		}
+		if len(mr.Headers) == 0 && mr.Cookie == nil {
+			return nil, nil, errors2.NewBadRequest(fmt.Sprintf("route to version [%s] has no header or cookie to match", mr.Version))
+		}
+		headers := map[string]interface{}{}
+		for _, h := range mr.Headers {
+			match := map[string]interface{}{}
+			if h.Exact != "" {
+				match["exact"] = h.Exact
+			}
+			if h.Prefix != "" {
+				match["prefix"] = h.Prefix
+			}
+			if h.Regex != "" {
+				match["regex"] = h.Regex
+			}
+			if h.Name == "" || len(match) != 1 {
+				return nil, nil, errors2.NewBadRequest("a header match needs a name and one of exact, prefix or regex")
+			}
+			headers[h.Name] = match
+		}
+		if c := mr.Cookie; c != nil {
+			if c.Name == "" {
+				return nil, nil, errors2.NewBadRequest("a cookie match needs a name")
+			}
+			// the cookie is matched in the Cookie header, wherever it is placed
+			headers["cookie"] = map[string]interface{}{
+				"regex": fmt.Sprintf("^(.*?;)?\\s*(%s=%s)(;.*)?$", regexp.QuoteMeta(c.Name), regexp.QuoteMeta(c.Value)),
+			}
+		}
+		route := newRoute([]interface{}{map[string]interface{}{"destination": destination(mr.Version)}})
+		route["match"] = []interface{}{map[string]interface{}{"headers": headers}}
+		httpRoutes = append(httpRoutes, route)
+	}
+
+	if len(request.WeightedRoutes) > 0 {
+		sum := 0
+		weighted := []interface{}{}
+		for _, wr := range request.WeightedRoutes {
+			if err := checkVersion(wr.Version); err != nil {
+				return nil, nil, err
+			}
+			if wr.Weight < 0 || wr.Weight > 100 {
+				return nil, nil, errors2.NewBadRequest(fmt.Sprintf("weight of version [%s] must be between 0 and 100", wr.Version))
+			}
+			sum += wr.Weight
+			weighted = append(weighted, map[string]interface{}{"destination": destination(wr.Version), "weight": wr.Weight})
+		}
+		if sum != 100 {
+			return nil, nil, errors2.NewBadRequest(fmt.Sprintf("weights must sum 100, they sum %d", sum))
+		}
+		httpRoutes = append(httpRoutes, newRoute(weighted))
+	} else {
+		// the traffic not matched keeps being balanced between all the versions
+		httpRoutes = append(httpRoutes, newRoute([]interface{}{map[string]interface{}{"destination": destination("")}}))
+	}
+
+	subsets := []interface{}{}
+	for _, v := range versions {
+		subsets = append(subsets, map[string]interface{}{
+			"name":   v,
+			"labels": map[string]interface{}{conf.IstioLabels.VersionLabelName: v},
+		})
+	}
+	apiVersion := apiToVersion[GetIstioAPI(DestinationRules)]
+	dr := &kubernetes.GenericIstioObject{
+		TypeMeta:   meta_v1.TypeMeta{Kind: kubernetes.PluralType[DestinationRules], APIVersion: apiVersion},
+		ObjectMeta: *meta.DeepCopy(),
+		Spec:       map[string]interface{}{"host": host, "subsets": subsets},
+	}
+	vs := &kubernetes.GenericIstioObject{
+		TypeMeta:   meta_v1.TypeMeta{Kind: kubernetes.PluralType[VirtualServices], APIVersion: apiVersion},
+		ObjectMeta: *meta.DeepCopy(),
+		Spec:       map[string]interface{}{"hosts": []interface{}{host}, "http": httpRoutes},
+	}
+	// the objects are read back as they would be fetched from the cluster, so the checkers see the same types
+	return asFetched(dr), asFetched(vs), nil
+}
+
+func asFetched(obj *kubernetes.GenericIstioObject) kubernetes.IstioObject {
+	fetched := &kubernetes.GenericIstioObject{}
+	if content, err := json.Marshal(obj); err == nil && json.Unmarshal(content, fetched) == nil {
+		return fetched
+	}
+	return obj
+}
@@ -0,0 +1,154 @@
+package business
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func TestBuildWeightedTrafficRouting(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	request := models.TrafficRoutingRequest{
+		WeightedRoutes: []models.WeightedRoute{{Version: "v1", Weight: 90}, {Version: "v2", Weight: 10}},
+		Timeout:        "2s",
+		Retries:        &models.RetryPolicy{Attempts: 3, PerTryTimeout: "1s"},
+	}
+	dr, vs, err := buildTrafficRouting("bookinfo", "reviews", []string{"v1", "v2", "v3"}, request)
+	assert.NoError(err)
+
+	assert.Equal("DestinationRule", dr.GetTypeMeta().Kind)
+	assert.Equal("weighted_routing", dr.GetObjectMeta().Labels[WizardLabel])
+	assert.Equal("reviews.bookinfo.svc.cluster.local", dr.GetSpec()["host"])
+	subsets := dr.GetSpec()["subsets"].([]interface{})
+	assert.Len(subsets, 3)
+	assert.Equal(map[string]interface{}{"name": "v3", "labels": map[string]interface{}{"version": "v3"}}, subsets[2])
+
+	assert.Equal("VirtualService", vs.GetTypeMeta().Kind)
+	assert.Equal([]interface{}{"reviews.bookinfo.svc.cluster.local"}, vs.GetSpec()["hosts"])
+	http := vs.GetSpec()["http"].([]interface{})
+	assert.Len(http, 1)
+	route := http[0].(map[string]interface{})
+	assert.Equal("2s", route["timeout"])
+	assert.Equal(map[string]interface{}{"attempts": float64(3), "perTryTimeout": "1s"}, route["retries"])
+	destinations := route["route"].([]interface{})
+	assert.Len(destinations, 2)
+	assert.Equal(map[string]interface{}{
+		"destination": map[string]interface{}{"host": "reviews.bookinfo.svc.cluster.local", "subset": "v2"},
+		"weight":      float64(10),
+	}, destinations[1])
+}
+
+func TestBuildMatchTrafficRouting(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	request := models.TrafficRoutingRequest{
+		MatchRoutes: []models.MatchRoute{
+			{Version: "v2", Headers: []models.HeaderMatch{{Name: "end-user", Exact: "jason"}}},
+			{Version: "v3", Cookie: &models.CookieMatch{Name: "user", Value: "tester"}},
+		},
+		FaultInjection: &models.FaultInjection{Abort: &models.AbortFault{Percentage: 10, HttpStatus: 503}},
+	}
+	_, vs, err := buildTrafficRouting("bookinfo", "reviews", []string{"v1", "v2", "v3"}, request)
+	assert.NoError(err)
+	assert.Equal("request_routing", vs.GetObjectMeta().Labels[WizardLabel])
+
+	http := vs.GetSpec()["http"].([]interface{})
+	assert.Len(http, 3)
+	first := http[0].(map[string]interface{})
+	assert.Equal([]interface{}{map[string]interface{}{"headers": map[string]interface{}{
+		"end-user": map[string]interface{}{"exact": "jason"},
+	}}}, first["match"])
+	assert.Equal(map[string]interface{}{"abort": map[string]interface{}{
+		"percentage": map[string]interface{}{"value": float64(10)}, "httpStatus": float64(503),
+	}}, first["fault"])
+	second := http[1].(map[string]interface{})
+	assert.Equal([]interface{}{map[string]interface{}{"headers": map[string]interface{}{
+		"cookie": map[string]interface{}{"regex": `^(.*?;)?\s*(user=tester)(;.*)?$`},
+	}}}, second["match"])
+	// the traffic not matched goes to the service, without subset
+	last := http[2].(map[string]interface{})
+	assert.Nil(last["match"])
+	assert.Equal([]interface{}{map[string]interface{}{
+		"destination": map[string]interface{}{"host": "reviews.bookinfo.svc.cluster.local"},
+	}}, last["route"])
+	assert.NotNil(last["fault"])
+}
+
+func TestBuildTrafficRoutingErrors(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	versions := []string{"v1", "v2"}
+	for _, request := range []models.TrafficRoutingRequest{
+		{WeightedRoutes: []models.WeightedRoute{{Version: "v1", Weight: 50}, {Version: "v2", Weight: 40}}},
+		{WeightedRoutes: []models.WeightedRoute{{Version: "v1", Weight: 50}, {Version: "v9", Weight: 50}}},
+		{MatchRoutes: []models.MatchRoute{{Version: "v2"}}},
+		{MatchRoutes: []models.MatchRoute{{Version: "v2", Headers: []models.HeaderMatch{{Name: "end-user", Exact: "a", Prefix: "b"}}}}},
+		{Retries: &models.RetryPolicy{}},
+		{FaultInjection: &models.FaultInjection{Delay: &models.DelayFault{Percentage: 120, FixedDelay: "5s"}}},
+	} {
+		_, _, err := buildTrafficRouting("bookinfo", "reviews", versions, request)
+		assert.Error(err)
+	}
+}
+
+func TestCreateTrafficRoutingRollback(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	dr, vs, err := buildTrafficRouting("bookinfo", "reviews", []string{"v1"}, models.TrafficRoutingRequest{})
+	assert.NoError(err)
+
+	k8s := new(kubetest.K8SClientMock)
+	k8s.On("CreateIstioObject", kubernetes.NetworkingGroupVersion.Group, "bookinfo", DestinationRules, mock.AnythingOfType("string")).Return(dr, nil)
+	k8s.On("CreateIstioObject", kubernetes.NetworkingGroupVersion.Group, "bookinfo", VirtualServices, mock.AnythingOfType("string")).Return(vs, errors.New("forbidden"))
+	k8s.On("DeleteIstioObject", kubernetes.NetworkingGroupVersion.Group, "bookinfo", DestinationRules, "reviews").Return(nil)
+
+	service := IstioConfigService{k8s: k8s}
+	changes, err := service.createTrafficRouting("bookinfo", dr, vs)
+	assert.EqualError(err, "forbidden")
+	k8s.AssertCalled(t, "DeleteIstioObject", kubernetes.NetworkingGroupVersion.Group, "bookinfo", DestinationRules, "reviews")
+
+	assert.Len(changes, 3)
+	assert.Equal(models.AuditCreate, changes[0].Operation)
+	assert.Equal(DestinationRules, changes[0].ObjectType)
+	assert.NoError(changes[0].Error)
+	assert.Equal(models.AuditCreate, changes[1].Operation)
+	assert.Equal(VirtualServices, changes[1].ObjectType)
+	assert.EqualError(changes[1].Error, "forbidden")
+	assert.Equal(models.AuditDelete, changes[2].Operation)
+	assert.Equal(DestinationRules, changes[2].ObjectType)
+	assert.Equal("reviews", changes[2].Name)
+	assert.NoError(changes[2].Error)
+}
+
+func TestCreateTrafficRoutingFirstFailure(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	dr, vs, err := buildTrafficRouting("bookinfo", "reviews", []string{"v1"}, models.TrafficRoutingRequest{})
+	assert.NoError(err)
+
+	k8s := new(kubetest.K8SClientMock)
+	k8s.On("CreateIstioObject", kubernetes.NetworkingGroupVersion.Group, "bookinfo", DestinationRules, mock.AnythingOfType("string")).Return(dr, errors.New("forbidden"))
+
+	service := IstioConfigService{k8s: k8s}
+	changes, err := service.createTrafficRouting("bookinfo", dr, vs)
+	assert.EqualError(err, "forbidden")
+	k8s.AssertNotCalled(t, "CreateIstioObject", kubernetes.NetworkingGroupVersion.Group, "bookinfo", VirtualServices, mock.AnythingOfType("string"))
+
+	// only the attempted create of the DestinationRule is reported
+	assert.Len(changes, 1)
+	assert.Equal(DestinationRules, changes[0].ObjectType)
+	assert.EqualError(changes[0].Error, "forbidden")
+}
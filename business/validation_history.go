@@ -0,0 +1,193 @@
+package business
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kiali/kiali/business/history"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// ErrValidationHistoryDisabled is returned when the history is read but the periodic validation is not enabled
+var ErrValidationHistoryDisabled = errors.New("validation history is not enabled")
+
+// validationHistory is the store of the periodic validations, nil while the history is not started
+var validationHistory struct {
+	sync.RWMutex
+	store *history.Store
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+// ValidationHistoryService reads the results of the periodic validations of the namespaces
+type ValidationHistoryService struct {
+	businessLayer *Layer
+}
+
+// StartValidationHistory opens the history store and validates the configured namespaces periodically, with the
+// business layer returned by getLayer. It does nothing when the history is not enabled.
+func StartValidationHistory(getLayer func() (*Layer, error)) error {
+	cfg := config.Get().Validations.History
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Interval <= 0 {
+		return fmt.Errorf("validation history interval must be positive: %d", cfg.Interval)
+	}
+	store, err := history.Open(cfg.StorePath)
+	if err != nil {
+		return err
+	}
+
+	validationHistory.Lock()
+	validationHistory.store = store
+	validationHistory.stop = make(chan struct{})
+	validationHistory.done = make(chan struct{})
+	stop, done := validationHistory.stop, validationHistory.done
+	validationHistory.Unlock()
+
+	log.Infof("Validating namespaces every %d seconds, history kept in [%s]", cfg.Interval, cfg.StorePath)
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(time.Duration(cfg.Interval) * time.Second)
+		defer ticker.Stop()
+		for {
+			if layer, err := getLayer(); err != nil {
+				log.Errorf("Periodic validation could not get the business layer: %v", err)
+			} else {
+				recordValidations(layer, store, cfg, time.Now())
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// StopValidationHistory stops the periodic validation and closes the history store, once the validation in
+// progress, if any, is recorded
+func StopValidationHistory() {
+	validationHistory.Lock()
+	store, stop, done := validationHistory.store, validationHistory.stop, validationHistory.done
+	validationHistory.store = nil
+	validationHistory.Unlock()
+	if store == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+	if err := store.Close(); err != nil {
+		log.Errorf("Error closing the validation history: %v", err)
+	}
+}
+
+// recordValidations validates the namespaces and records the results at the given time. A namespace failing
+// to validate is skipped, its history has a gap.
+func recordValidations(layer *Layer, store *history.Store, cfg config.ValidationHistoryConfig, at time.Time) {
+	namespaces := cfg.Namespaces
+	if len(namespaces) == 0 {
+		nss, err := layer.Namespace.GetNamespaces()
+		if err != nil {
+			log.Errorf("Periodic validation could not list the namespaces: %v", err)
+			return
+		}
+		for _, ns := range nss {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+
+	for _, ns := range namespaces {
+		validations, err := layer.Validations.GetValidations(ns, "")
+		if err != nil {
+			log.Errorf("Periodic validation of namespace [%s] failed: %v", ns, err)
+			continue
+		}
+		summary, err := store.Record(ns, at, validations)
+		if err != nil {
+			log.Errorf("Validations of namespace [%s] could not be recorded: %v", ns, err)
+			continue
+		}
+		internalmetrics.SetValidationSummary(ns, summary.Errors, summary.Warnings)
+	}
+
+	if cfg.Retention > 0 {
+		if err := store.Prune(at.AddDate(0, 0, -cfg.Retention)); err != nil {
+			log.Errorf("Validation history could not be pruned: %v", err)
+		}
+	}
+}
+
+// GetNewFindings returns the findings first seen since the given time, of the given severity when not empty.
+// All the accessible namespaces are read when namespaces is empty.
+func (in *ValidationHistoryService) GetNewFindings(namespaces []string, since time.Time, severity models.SeverityLevel) ([]models.ValidationFinding, error) {
+	return in.readFindings(namespaces, severity, func(store *history.Store, nss []string) ([]models.ValidationFinding, error) {
+		return store.NewFindings(nss, since)
+	})
+}
+
+// GetResolvedFindings returns the findings resolved since the given time, of the given severity when not empty.
+// All the accessible namespaces are read when namespaces is empty.
+func (in *ValidationHistoryService) GetResolvedFindings(namespaces []string, since time.Time, severity models.SeverityLevel) ([]models.ValidationFinding, error) {
+	return in.readFindings(namespaces, severity, func(store *history.Store, nss []string) ([]models.ValidationFinding, error) {
+		return store.ResolvedFindings(nss, since)
+	})
+}
+
+// GetTrend returns the validation summaries of a namespace recorded since the given time
+func (in *ValidationHistoryService) GetTrend(namespace string, since time.Time) (models.ValidationTrend, error) {
+	if _, err := in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return models.ValidationTrend{}, err
+	}
+	validationHistory.RLock()
+	defer validationHistory.RUnlock()
+	if validationHistory.store == nil {
+		return models.ValidationTrend{}, ErrValidationHistoryDisabled
+	}
+	return validationHistory.store.Trend(namespace, since)
+}
+
+func (in *ValidationHistoryService) readFindings(namespaces []string, severity models.SeverityLevel,
+	read func(*history.Store, []string) ([]models.ValidationFinding, error)) ([]models.ValidationFinding, error) {
+	// only the namespaces the user has access to are read
+	if len(namespaces) == 0 {
+		nss, err := in.businessLayer.Namespace.GetNamespaces()
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range nss {
+			namespaces = append(namespaces, ns.Name)
+		}
+	} else {
+		for _, ns := range namespaces {
+			if _, err := in.businessLayer.Namespace.GetNamespace(ns); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	validationHistory.RLock()
+	defer validationHistory.RUnlock()
+	if validationHistory.store == nil {
+		return nil, ErrValidationHistoryDisabled
+	}
+	findings, err := read(validationHistory.store, namespaces)
+	if err != nil || severity == "" {
+		return findings, err
+	}
+	filtered := make([]models.ValidationFinding, 0, len(findings))
+	for _, f := range findings {
+		if f.Severity == severity {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered, nil
+}
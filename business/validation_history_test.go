@@ -0,0 +1,25 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+)
+
+func TestStartValidationHistoryInterval(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	conf.Validations.History.Enabled = true
+	conf.Validations.History.StorePath = t.TempDir() + "/history.db"
+
+	getLayer := func() (*Layer, error) { return nil, nil }
+	for _, interval := range []int{0, -60} {
+		conf.Validations.History.Interval = interval
+		config.Set(conf)
+		assert.Error(StartValidationHistory(getLayer))
+	}
+	// the history is not started
+	assert.Nil(validationHistory.store)
+}
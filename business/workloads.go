@@ -107,6 +107,8 @@ func (in *WorkloadService) GetWorkload(namespace string, workloadName string, in
 		workload.SetServices(services)
 	}
 
+	in.businessLayer.Proxy.setProxyStatus(namespace, workload)
+
 	wg.Wait()
 	workload.Runtimes = runtimes
 
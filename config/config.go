@@ -70,6 +70,7 @@ var rwMutex sync.RWMutex
 // Server configuration
 type Server struct {
 	Address                    string               `yaml:",omitempty"`
+	Audit                      AuditConfig          `yaml:"audit,omitempty"`
 	AuditLog                   bool                 `yaml:"audit_log,omitempty"` // When true, allows additional audit logging on Write operations
 	CORSAllowAll               bool                 `yaml:"cors_allow_all,omitempty"`
 	Credentials                security.Credentials `yaml:",omitempty"`
@@ -81,6 +82,21 @@ type Server struct {
 	WebRoot                    string               `yaml:"web_root,omitempty"`
 }
 
+// AuditConfig defines where the audit events of the Write operations are written, when AuditLog is enabled
+type AuditConfig struct {
+	// Events are written as JSON lines in this file, rotated when it reaches MaxSizeMB. Empty disables the file
+	FilePath   string             `yaml:"file_path,omitempty"`
+	MaxBackups int                `yaml:"max_backups,omitempty"`
+	MaxSizeMB  int                `yaml:"max_size_mb,omitempty"`
+	Webhook    AuditWebhookConfig `yaml:"webhook,omitempty"`
+}
+
+// AuditWebhookConfig defines an URL the audit events are posted to, as JSON
+type AuditWebhookConfig struct {
+	Timeout int    `yaml:"timeout,omitempty"` // seconds
+	URL     string `yaml:"url,omitempty"`
+}
+
 // Auth provides authentication data for external services
 type Auth struct {
 	CAFile             string `yaml:"ca_file"`
@@ -140,10 +156,17 @@ type TracingConfig struct {
 
 // IstioConfig describes configuration used for istio links
 type IstioConfig struct {
+	// Port of the admin interface of the proxies, reached through the pods proxy of the API server
+	EnvoyAdminPort         int    `yaml:"envoy_admin_port,omitempty"`
 	IstioStatusEnabled     bool   `yaml:"istio_status_enabled,omitempty"`
 	IstioIdentityDomain    string `yaml:"istio_identity_domain,omitempty"`
 	IstioSidecarAnnotation string `yaml:"istio_sidecar_annotation,omitempty"`
-	UrlServiceVersion      string `yaml:"url_service_version"`
+	// Port of the debug endpoints of the istiod pods, selected in the Istio namespace by the label selector
+	IstiodDebugPort     int    `yaml:"istiod_debug_port,omitempty"`
+	IstiodLabelSelector string `yaml:"istiod_label_selector,omitempty"`
+	// When false, the sync status of the proxies is not read from the istiod debug endpoints
+	ProxyStatusEnabled bool   `yaml:"proxy_status_enabled,omitempty"`
+	UrlServiceVersion  string `yaml:"url_service_version"`
 }
 
 // ThreeScaleConfig describes configuration used for 3Scale adapter
@@ -266,6 +289,66 @@ type DeploymentConfig struct {
 	Namespace            string   `yaml:"namespace,omitempty"` // Kiali deployment namespace
 }
 
+// ValidationsConfig provides details on the Istio config validations
+type ValidationsConfig struct {
+	// Name of the ConfigMap, in the Kiali deployment namespace, holding the custom validation rules.
+	// Custom rules are disabled when empty.
+	CustomRulesConfigMap string `yaml:"custom_rules_config_map,omitempty"`
+	// Fetch the jwksUri of the RequestAuthentications to check they serve a JSON Web Key Set
+	FetchJwks bool `yaml:"fetch_jwks,omitempty"`
+	// Codes of the checks suppressed in every namespace, i.e. KIA1106
+	Ignore []string `yaml:"ignore,omitempty"`
+	// Codes of the checks suppressed per namespace
+	IgnoreNamespaces map[string][]string `yaml:"ignore_namespaces,omitempty"`
+	// Periodic validation of the namespaces, keeping its results over time
+	History ValidationHistoryConfig `yaml:"history,omitempty"`
+	// Validating admission webhook for the Istio objects
+	Admission AdmissionWebhookConfig `yaml:"admission,omitempty"`
+}
+
+// AdmissionWebhookConfig configures the validating admission webhook. It is served on its own TLS listener,
+// only to the clients presenting a certificate signed by the client CA, i.e. the Kubernetes API server,
+// as it validates the objects with the Kiali Service Account.
+type AdmissionWebhookConfig struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	Port    int  `yaml:"port,omitempty"`
+	// Certificate and key served to the API server
+	CertFile       string `yaml:"cert_file,omitempty"`
+	PrivateKeyFile string `yaml:"private_key_file,omitempty"`
+	// CA of the client certificates accepted
+	ClientCAFile string `yaml:"client_ca_file,omitempty"`
+}
+
+// ValidationHistoryConfig configures the periodic validation of the namespaces. Its summaries and findings
+// are kept in a BoltDB file, to know when a misconfiguration first appeared and when it was resolved.
+type ValidationHistoryConfig struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// Seconds between two validations of the namespaces
+	Interval int `yaml:"interval,omitempty"`
+	// Namespaces validated, all the accessible namespaces when empty
+	Namespaces []string `yaml:"namespaces,omitempty"`
+	// Days the summaries and the resolved findings are kept
+	Retention int `yaml:"retention,omitempty"`
+	// Path of the BoltDB file
+	StorePath string `yaml:"store_path,omitempty"`
+}
+
+// MultiClusterConfig lists the remote clusters of the mesh, reached through the endpoints of the .global ServiceEntries
+type MultiClusterConfig struct {
+	Clusters []RemoteCluster `yaml:"clusters,omitempty"`
+}
+
+// RemoteCluster is a cluster of the mesh other than the one of Kiali
+type RemoteCluster struct {
+	Name string `yaml:"name"`
+	// Addresses of the gateway of the cluster, as used in the endpoints of the .global ServiceEntries
+	GatewayAddresses []string `yaml:"gateway_addresses,omitempty"`
+	// Path of a kubeconfig file to read the Services of the cluster. Remote Services are not checked when empty.
+	Kubeconfig string `yaml:"kubeconfig,omitempty"`
+	// Context of the kubeconfig to use, the current context when empty
+	Context string `yaml:"context,omitempty"`
+}
+
 // IstioComponentNamespaces holds the component-specific Istio namespaces. Any missing component
 // defaults to the namespace configured for IstioNamespace (which itself defaults to 'istio-system').
 type IstioComponentNamespaces map[string]string
@@ -286,7 +369,9 @@ type Config struct {
 	IstioNamespace           string                   `yaml:"istio_namespace,omitempty"` // default component namespace
 	KubernetesConfig         KubernetesConfig         `yaml:"kubernetes_config,omitempty"`
 	LoginToken               LoginToken               `yaml:"login_token,omitempty"`
+	MultiCluster             MultiClusterConfig       `yaml:"multi_cluster,omitempty"`
 	Server                   Server                   `yaml:",omitempty"`
+	Validations              ValidationsConfig        `yaml:"validations,omitempty"`
 }
 
 // NewConfig creates a default Config struct
@@ -331,9 +416,13 @@ func NewConfig() (c *Config) {
 				Enabled: true,
 			},
 			Istio: IstioConfig{
+				EnvoyAdminPort:         15000,
 				IstioStatusEnabled:     true,
 				IstioIdentityDomain:    "svc.cluster.local",
 				IstioSidecarAnnotation: "sidecar.istio.io/status",
+				IstiodDebugPort:        15014,
+				IstiodLabelSelector:    "app=istiod",
+				ProxyStatusEnabled:     true,
 				UrlServiceVersion:      "http://istiod:15014/version",
 			},
 			Prometheus: PrometheusConfig{
@@ -362,7 +451,7 @@ func NewConfig() (c *Config) {
 			Burst:                       200,
 			CacheDuration:               5 * 60,
 			CacheEnabled:                true,
-			CacheIstioTypes:             []string{"DestinationRule", "Gateway", "ServiceEntry", "VirtualService"},
+			CacheIstioTypes:             []string{"DestinationRule", "EnvoyFilter", "Gateway", "ServiceEntry", "VirtualService"},
 			CacheNamespaces:             []string{".*"},
 			CacheTokenNamespaceDuration: 10,
 			ExcludeWorkloads:            []string{"CronJob", "DeploymentConfig", "Job", "ReplicationController"},
@@ -373,6 +462,14 @@ func NewConfig() (c *Config) {
 			SigningKey:        "kiali",
 		},
 		Server: Server{
+			Audit: AuditConfig{
+				FilePath:   "/tmp/kiali/audit.log",
+				MaxBackups: 5,
+				MaxSizeMB:  10,
+				Webhook: AuditWebhookConfig{
+					Timeout: 5,
+				},
+			},
 			AuditLog: true,
 			Credentials: security.Credentials{
 				Username:   getDefaultStringFromFile(LoginSecretUsername, ""),
@@ -385,6 +482,16 @@ func NewConfig() (c *Config) {
 			StaticContentRootDirectory: "/opt/kiali/console",
 			WebRoot:                    "/",
 		},
+		Validations: ValidationsConfig{
+			Admission: AdmissionWebhookConfig{
+				Port: 8443,
+			},
+			History: ValidationHistoryConfig{
+				Interval:  10 * 60,
+				Retention: 30,
+				StorePath: "/tmp/kiali/validation_history.db",
+			},
+		},
 	}
 
 	return
@@ -45,7 +45,7 @@ type ContainerParam struct {
 	Name string `json:"container"`
 }
 
-// swagger:parameters istioConfigList workloadList workloadDetails serviceDetails spansList tracesList errorTraces tracesDetail workloadValidations appList serviceMetrics appMetrics workloadMetrics istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype serviceList appDetails graphApp graphAppVersion graphNamespace graphService graphWorkload namespaceMetrics customDashboard appDashboard serviceDashboard workloadDashboard istioConfigCreate istioConfigCreateSubtype namespaceTls podDetails podLogs getThreeScaleService postThreeScaleService patchThreeScaleService deleteThreeScaleService namespaceValidations getIter8Experiments postIter8Experiments patchIter8Experiments deleteIter8Experiments
+// swagger:parameters istioConfigList workloadList workloadDetails serviceDetails spansList tracesList errorTraces tracesDetail workloadValidations appList serviceMetrics appMetrics workloadMetrics istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype serviceList appDetails graphApp graphAppVersion graphNamespace graphService graphWorkload namespaceMetrics customDashboard appDashboard serviceDashboard workloadDashboard istioConfigCreate istioConfigCreateSubtype namespaceTls workloadTls workloadAuthorizationSimulation podDetails podLogs podProxyCertificates namespaceProxyCertificates namespaceProxyStatus podProxyConfigDump podProxyConfig getThreeScaleService postThreeScaleService patchThreeScaleService deleteThreeScaleService namespaceValidations namespaceValidationTrend workloadSidecarScope serviceTrafficRouting getIter8Experiments postIter8Experiments patchIter8Experiments deleteIter8Experiments
 type NamespaceParam struct {
 	// The namespace name.
 	//
@@ -91,7 +91,7 @@ type ObjectSubtypeParam struct {
 	Name string `json:"object_subtype"`
 }
 
-// swagger:parameters podDetails podLogs
+// swagger:parameters podDetails podLogs podProxyCertificates podProxyConfigDump podProxyConfig
 type PodParam struct {
 	// The pod name.
 	//
@@ -100,7 +100,7 @@ type PodParam struct {
 	Name string `json:"pod"`
 }
 
-// swagger:parameters serviceDetails spansList tracesList errorTraces tracesDetail serviceMetrics graphService serviceDashboard getThreeScaleService patchThreeScaleService deleteThreeScaleService
+// swagger:parameters serviceDetails spansList tracesList errorTraces tracesDetail serviceMetrics graphService serviceDashboard getThreeScaleService patchThreeScaleService deleteThreeScaleService serviceTrafficRouting
 type ServiceParam struct {
 	// The service name.
 	//
@@ -127,7 +127,7 @@ type DashboardParam struct {
 	Name string `json:"dashboard"`
 }
 
-// swagger:parameters workloadDetails workloadValidations workloadMetrics graphWorkload workloadDashboard
+// swagger:parameters workloadDetails workloadValidations workloadMetrics graphWorkload workloadDashboard workloadSidecarScope workloadTls workloadAuthorizationSimulation
 type WorkloadParam struct {
 	// The workload name.
 	//
@@ -210,6 +210,98 @@ type QueryTimeParam struct {
 	Name string `json:"queryTime"`
 }
 
+// swagger:parameters validationHistoryNew validationHistoryResolved auditEvents meshTlsMatrix
+type ValidationHistoryNamespacesParam struct {
+	// Comma-separated list of namespaces to read. All the namespaces accessible to the client by default.
+	//
+	// in: query
+	// required: false
+	Name string `json:"namespaces"`
+}
+
+// swagger:parameters validationHistoryNew validationHistoryResolved
+type ValidationHistorySeverityParam struct {
+	// Severity of the findings: error or warning. All the findings by default.
+	//
+	// in: query
+	// required: false
+	Name string `json:"severity"`
+}
+
+// swagger:parameters validationHistoryNew validationHistoryResolved namespaceValidationTrend auditEvents
+type ValidationHistorySinceParam struct {
+	// Unix time (seconds) since when the findings or summaries are returned. Default is one day ago.
+	//
+	// in: query
+	// required: false
+	Name string `json:"since"`
+}
+
+// swagger:parameters auditEvents
+type AuditUserParam struct {
+	// Returns only the events of this user.
+	//
+	// in: query
+	// required: false
+	Name string `json:"user"`
+}
+
+// swagger:parameters auditEvents
+type AuditLimitParam struct {
+	// Maximum number of events returned.
+	//
+	// in: query
+	// required: false
+	// default: 100
+	Name int `json:"limit"`
+}
+
+// swagger:parameters istioConfigCreate istioConfigCreateSubtype istioConfigUpdate istioConfigUpdateSubtype serviceTrafficRouting istioConfigImport
+type IstioConfigDryRunParam struct {
+	// When true the change is not applied, the validations that would result from it are returned.
+	//
+	// in: query
+	// required: false
+	// default: false
+	Name bool `json:"dryRun"`
+}
+
+// swagger:parameters istioConfigExport
+type IstioConfigExportNamespacesParam struct {
+	// Comma-separated list of namespaces to export.
+	//
+	// in: query
+	// required: true
+	Name string `json:"namespaces"`
+}
+
+// swagger:parameters istioConfigImport
+type IstioConfigImportNamespaceParam struct {
+	// Namespace the objects of the bundle are imported into. The namespaces of the bundle by default.
+	//
+	// in: query
+	// required: false
+	Name string `json:"namespace"`
+}
+
+// swagger:parameters workloadAuthorizationSimulation
+type AuthorizationSimulationParam struct {
+	// The source and the attributes of the simulated request
+	//
+	// in: body
+	// required: true
+	Body models.AuthorizationSimulation
+}
+
+// swagger:parameters serviceTrafficRouting
+type TrafficRoutingParam struct {
+	// The routing of the traffic of the service to its versions
+	//
+	// in: body
+	// required: true
+	Body models.TrafficRoutingRequest
+}
+
 /////////////////////
 // SWAGGER PARAMETERS - METRICS
 // - keep this alphabetized
@@ -304,6 +396,74 @@ type RateFuncParam struct {
 	Name string `json:"rateFunc"`
 }
 
+// swagger:parameters podProxyConfig
+type ProxyConfigResourceParam struct {
+	// The proxy configuration to summarize: listeners, routes, clusters or endpoints
+	//
+	// in: path
+	// required: true
+	Name string `json:"resource"`
+}
+
+// swagger:parameters podProxyConfig
+type ProxyConfigFilterParam struct {
+	// Port of the listeners, clusters and endpoints
+	//
+	// in: query
+	// required: false
+	Port string `json:"port"`
+	// Direction of the clusters and endpoints: inbound or outbound
+	//
+	// in: query
+	// required: false
+	Direction string `json:"direction"`
+	// FQDN of the service of the clusters and endpoints
+	//
+	// in: query
+	// required: false
+	FQDN string `json:"fqdn"`
+	// VirtualService of the routes, as name or namespace/name
+	//
+	// in: query
+	// required: false
+	VirtualService string `json:"virtualService"`
+	// Cluster of the endpoints
+	//
+	// in: query
+	// required: false
+	Cluster string `json:"cluster"`
+}
+
+// swagger:parameters namespaceProxyCertificates
+type CertExpiringWithinParam struct {
+	// Remaining validity under which a certificate is flagged as expiring soon, as a duration
+	//
+	// in: query
+	// required: false
+	// default: 1h
+	Name string `json:"expiringWithin"`
+}
+
+// swagger:parameters meshTlsMatrix
+type TlsMatrixRateIntervalParam struct {
+	// Interval of the telemetry compared with the mTLS status. Empty to not compare.
+	//
+	// in: query
+	// required: false
+	// default: 10m
+	Name string `json:"rateInterval"`
+}
+
+// swagger:parameters istioControlPlaneStatus
+type ControlPlaneRateIntervalParam struct {
+	// Interval of the rates of the istiod metrics.
+	//
+	// in: query
+	// required: false
+	// default: 10m
+	Name string `json:"rateInterval"`
+}
+
 // swagger:parameters serviceMetrics appMetrics workloadMetrics customDashboard appDashboard serviceDashboard workloadDashboard
 type RateIntervalParam struct {
 	// Interval used for rate and histogram calculation.
@@ -510,6 +670,34 @@ type workloadHealthResponse struct {
 	Body models.WorkloadHealth
 }
 
+// workloadSidecarScopeResponse is the effective Sidecar of a workload and the services it makes reachable
+// swagger:response workloadSidecarScopeResponse
+type workloadSidecarScopeResponse struct {
+	// in:body
+	Body models.SidecarScope
+}
+
+// validationFindingsResponse is a list of validation findings, oldest first
+// swagger:response validationFindingsResponse
+type validationFindingsResponse struct {
+	// in:body
+	Body []models.ValidationFinding
+}
+
+// auditEventsResponse is a list of audit events, most recent first
+// swagger:response auditEventsResponse
+type auditEventsResponse struct {
+	// in:body
+	Body []models.AuditEvent
+}
+
+// namespaceValidationTrendResponse is the validation summaries of a namespace over time
+// swagger:response namespaceValidationTrendResponse
+type namespaceValidationTrendResponse struct {
+	// in:body
+	Body models.ValidationTrend
+}
+
 // namespaceAppHealthResponse is a map of app name x health
 // swagger:response namespaceAppHealthResponse
 type namespaceAppHealthResponse struct {
@@ -573,6 +761,34 @@ type IstioConfigDetailsResponse struct {
 	Body models.IstioConfigDetails
 }
 
+// Validations that would result from the create or the update of an Istio Object
+// swagger:response istioConfigDryRunResponse
+type IstioConfigDryRunResponse struct {
+	// in:body
+	Body models.IstioConfigDryRun
+}
+
+// Result of the import of a bundle of Istio objects
+// swagger:response istioConfigImportResponse
+type IstioConfigImportResponse struct {
+	// in:body
+	Body models.IstioConfigImport
+}
+
+// Istio config generated to route the traffic of a service, with its validations
+// swagger:response trafficRoutingResponse
+type TrafficRoutingResponse struct {
+	// in:body
+	Body models.TrafficRouting
+}
+
+// Decision of the AuthorizationPolicies for a simulated request
+// swagger:response authorizationSimulationResponse
+type AuthorizationSimulationResponse struct {
+	// in:body
+	Body models.AuthorizationSimulationResult
+}
+
 // Detailed information of an specific app
 // swagger:response appDetails
 type AppDetailsResponse struct {
@@ -622,6 +838,55 @@ type NamespaceTlsResponse struct {
 	Body models.MTLSStatus
 }
 
+// Return the mTLS status of the traffic between the workloads of the namespaces
+// swagger:response meshTlsMatrixResponse
+type MeshTlsMatrixResponse struct {
+	// in:body
+	Body models.MTLSMatrix
+}
+
+// Return the effective mTLS mode accepted by a specific Workload
+// swagger:response workloadTlsResponse
+type WorkloadTlsResponse struct {
+	// in:body
+	Body models.WorkloadMTLS
+}
+
+// Return the certificates loaded by the proxy of a specific Pod
+// swagger:response podProxyCertificatesResponse
+type PodProxyCertificatesResponse struct {
+	// in:body
+	Body models.ProxyCertificates
+}
+
+// Return the raw config_dump of the proxy of a specific Pod
+// swagger:response podProxyConfigDumpResponse
+type PodProxyConfigDumpResponse struct {
+	// in:body
+	Body map[string]interface{}
+}
+
+// Return the listeners, routes, clusters or endpoints of the proxy of a specific Pod
+// swagger:response podProxyConfigResponse
+type PodProxyConfigResponse struct {
+	// in:body
+	Body []interface{}
+}
+
+// Return the certificate status of the proxies of a specific Namespace
+// swagger:response namespaceProxyCertificatesResponse
+type NamespaceProxyCertificatesResponse struct {
+	// in:body
+	Body models.NamespaceCertificates
+}
+
+// Return the sync status of the proxies of a specific Namespace
+// swagger:response namespaceProxyStatusResponse
+type NamespaceProxyStatusResponse struct {
+	// in:body
+	Body models.NamespaceProxyStatus
+}
+
 // Return the validation status of a specific Namespace
 // swagger:response namespaceValidationSummaryResponse
 type NamespaceValidationSummaryResponse struct {
@@ -705,3 +970,10 @@ type IstioStatusResponse struct {
 	// in: body
 	Body business.IstioComponentStatus
 }
+
+// Return the health of the revisions of the control plane
+// swagger:response istioControlPlaneStatusResponse
+type IstioControlPlaneStatusResponse struct {
+	// in: body
+	Body models.ControlPlaneStatus
+}
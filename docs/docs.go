@@ -33,6 +33,213 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/graph/detail/namespace/{namespace}/app/{app}/duration/{duration}": {
+            "get": {
+                "description": "查询 app/workload/service 的上下游流量表格, 包括请求速率, 错误率, 响应时间, 协议, mTLS 比例, 主要的响应码和响应标志",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graph"
+                ],
+                "summary": "graph-node-detail",
+                "operationId": "GetNodeDetail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "app 名称",
+                        "name": "app",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "时长",
+                        "name": "duration",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/table.NodeDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.responseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/graph/detail/namespace/{namespace}/app/{app}/version/{version}/duration/{duration}": {
+            "get": {
+                "description": "查询 app/workload/service 的上下游流量表格, 包括请求速率, 错误率, 响应时间, 协议, mTLS 比例, 主要的响应码和响应标志",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graph"
+                ],
+                "summary": "graph-node-detail",
+                "operationId": "GetNodeDetail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "app 名称",
+                        "name": "app",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "app 版本",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "时长",
+                        "name": "duration",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/table.NodeDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.responseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/graph/detail/namespace/{namespace}/service/{service}/duration/{duration}": {
+            "get": {
+                "description": "查询 app/workload/service 的上下游流量表格, 包括请求速率, 错误率, 响应时间, 协议, mTLS 比例, 主要的响应码和响应标志",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graph"
+                ],
+                "summary": "graph-node-detail",
+                "operationId": "GetNodeDetail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "服务名称",
+                        "name": "service",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "时长",
+                        "name": "duration",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/table.NodeDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.responseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/graph/detail/namespace/{namespace}/workload/{workload}/duration/{duration}": {
+            "get": {
+                "description": "查询 app/workload/service 的上下游流量表格, 包括请求速率, 错误率, 响应时间, 协议, mTLS 比例, 主要的响应码和响应标志",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graph"
+                ],
+                "summary": "graph-node-detail",
+                "operationId": "GetNodeDetail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "workload 名称",
+                        "name": "workload",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "时长",
+                        "name": "duration",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/table.NodeDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.responseError"
+                        }
+                    }
+                }
+            }
+        },
         "/graph/namespace/{namespace}/duration/{duration}/deadEdges/{deadEdges}/passThrough/{passThrough}/graphType/{graphType}": {
             "post": {
                 "description": "通过namespace来查询流量视图",
@@ -85,6 +292,144 @@ var doc = `{
                         "description": "是否需要加多集群的线",
                         "name": "passThrough",
                         "in": "path"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否按外部 host 拆分出口流量",
+                        "name": "egress",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GraphNamespacesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.responseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/graph/namespace/{namespace}/duration/{duration}/deadEdges/{deadEdges}/passThrough/{passThrough}/graphType/{graphType}/egress/{egress}": {
+            "post": {
+                "description": "通过namespace来查询流量视图",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graph"
+                ],
+                "summary": "graph-namespace",
+                "operationId": "GetNamespaces",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "时长",
+                        "name": "duration",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "视图类型",
+                        "name": "graphType",
+                        "in": "path"
+                    },
+                    {
+                        "description": "集群信息",
+                        "name": "cluster",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NamespacesRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否去掉没有流量的线",
+                        "name": "deadEdges",
+                        "in": "path"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否需要加多集群的线",
+                        "name": "passThrough",
+                        "in": "path"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否按外部 host 拆分出口流量",
+                        "name": "egress",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GraphNamespacesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.responseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/graph/namespace/{namespace}/gateway/{gateway}/duration/{duration}/deadEdges/{deadEdges}": {
+            "get": {
+                "description": "通过 gateway 来查询流量视图, 包括绑定的 VirtualService 的 host 和路由",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graph"
+                ],
+                "summary": "graph-Gateway",
+                "operationId": "GetGateway",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "gateway 所在的命名空间",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "gateway 名称",
+                        "name": "gateway",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "时长",
+                        "name": "duration",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否去掉没有流量的线",
+                        "name": "deadEdges",
+                        "in": "path"
                     }
                 ],
                 "responses": {
@@ -156,6 +501,89 @@ var doc = `{
                         "description": "是否需要加多集群的线",
                         "name": "passThrough",
                         "in": "path"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否按外部 host 拆分出口流量",
+                        "name": "egress",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GraphNamespacesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.responseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/graph/namespace/{namespace}/service/{service}/duration/{duration}/deadEdges/{deadEdges}/passThrough/{passThrough}/egress/{egress}": {
+            "post": {
+                "description": "通过node来查询流量视图",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graph"
+                ],
+                "summary": "graph-Node",
+                "operationId": "GetNode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "命名空间",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "时长",
+                        "name": "duration",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "集群信息",
+                        "name": "cluster",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NamespacesRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "service 名称",
+                        "name": "service",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否去掉没有流量的线",
+                        "name": "deadEdges",
+                        "in": "path"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否需要加多集群的线",
+                        "name": "passThrough",
+                        "in": "path"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否按外部 host 拆分出口流量",
+                        "name": "egress",
+                        "in": "path"
                     }
                 ],
                 "responses": {
@@ -223,6 +651,131 @@ var doc = `{
                     "type": "string"
                 }
             }
+        },
+        "table.NodeDetail": {
+            "type": "object",
+            "properties": {
+                "cluster": {
+                    "type": "string"
+                },
+                "inbound": {
+                    "description": "traffic received by the node, one row per source and protocol",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/table.Row"
+                    }
+                },
+                "node": {
+                    "$ref": "#/definitions/table.Peer"
+                },
+                "outbound": {
+                    "description": "traffic sent by the node, one row per destination and protocol",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/table.Row"
+                    }
+                }
+            }
+        },
+        "table.Peer": {
+            "type": "object",
+            "properties": {
+                "app": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "nodeType": {
+                    "type": "string"
+                },
+                "service": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                },
+                "workload": {
+                    "type": "string"
+                }
+            }
+        },
+        "table.ResponseCode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "percent": {
+                    "type": "number"
+                },
+                "rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "table.ResponseFlag": {
+            "type": "object",
+            "properties": {
+                "flags": {
+                    "type": "string"
+                },
+                "percent": {
+                    "type": "number"
+                },
+                "rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "table.Row": {
+            "type": "object",
+            "properties": {
+                "errorPercent": {
+                    "description": "not reported for tcp",
+                    "type": "number"
+                },
+                "flags": {
+                    "description": "top response flags by rate",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/table.ResponseFlag"
+                    }
+                },
+                "mtlsPercent": {
+                    "type": "number"
+                },
+                "peer": {
+                    "$ref": "#/definitions/table.Peer"
+                },
+                "protocol": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "responseCodes": {
+                    "description": "top response codes by rate",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/table.ResponseCode"
+                    }
+                },
+                "responseTimes": {
+                    "description": "in millis, keyed by quantile, i.e. p95",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "unit": {
+                    "description": "rps or bps",
+                    "type": "string"
+                }
+            }
         }
     }
 }`
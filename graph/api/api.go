@@ -8,8 +8,10 @@ import (
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/graph"
 	"github.com/kiali/kiali/graph/config/cytoscape"
+	"github.com/kiali/kiali/graph/config/table"
 	"github.com/kiali/kiali/graph/telemetry/istio"
 	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/prometheus"
 	"github.com/kiali/kiali/prometheus/internalmetrics"
 )
@@ -23,6 +25,8 @@ type GraphApi struct {
 type GraphApiInterface interface {
 	RegistryHandle(span opentracing.Span, loads map[string]interface{}) (edges []*cytoscape.EdgeWrapper, err error)
 	NodeRegistryHandle(span opentracing.Span, loads map[string]interface{}) (edges []*cytoscape.EdgeWrapper, err error)
+	GatewayRegistryHandle(span opentracing.Span, loads map[string]interface{}) error
+	NodeDetailHandle(span opentracing.Span) (detail table.NodeDetail, err error)
 }
 
 func NewGraphApi(option graph.Option, span opentracing.Span) (*GraphApi, error) {
@@ -61,6 +65,17 @@ func (g *GraphApi) NodeRegistryHandle(span opentracing.Span, loads map[string]in
 	return edges, err
 }
 
+func (g *GraphApi) GatewayRegistryHandle(span opentracing.Span, loads map[string]interface{}) error {
+	return graphGatewayCluster(g.business, g.options, span, loads)
+}
+
+// NodeDetailHandle 单个集群的某个节点的上下游流量表格
+func (g *GraphApi) NodeDetailHandle(span opentracing.Span) (detail table.NodeDetail, err error) {
+	nodeDetailSpan := opentracing.StartSpan("get node detail", opentracing.FollowsFrom(span.Context()))
+	defer nodeDetailSpan.Finish()
+	return GraphNodeDetail(g.business, g.options)
+}
+
 // graphNamespacesCluster 单个集群的namespaces 级别的流量视图
 func graphNamespacesCluster(business *business.Layer, o graph.Options, span opentracing.Span, loads map[string]interface{}) {
 	graphNamespacesSpan := opentracing.StartSpan("get graph", opentracing.FollowsFrom(span.Context()))
@@ -89,6 +104,18 @@ func graphNodeCluster(business *business.Layer, o graph.Options, span opentracin
 	return nil
 }
 
+//graphGatewayCluster 单个集群的 某个 gateway 级别的流量视图
+func graphGatewayCluster(business *business.Layer, o graph.Options, span opentracing.Span, loads map[string]interface{}) error {
+	graphGatewaySpan := opentracing.StartSpan("get gateway graph", opentracing.FollowsFrom(span.Context()))
+	defer graphGatewaySpan.Finish()
+	_, payload, err := GraphGateway(business, o)
+	if err != nil {
+		return err
+	}
+	loads[o.Context] = payload
+	return nil
+}
+
 //passEdges 获取当前集群跨集群的线
 func passEdges(businessNoAuth *business.Layer, o graph.Options, optionSpan opentracing.Span) (edges []*cytoscape.EdgeWrapper, err error) {
 	passSpan := opentracing.StartSpan("pass Through", opentracing.ChildOf(optionSpan.Context()))
@@ -157,6 +184,65 @@ func GraphNode(business *business.Layer, o graph.Options) (code int, config inte
 	return code, config, nil
 }
 
+// GraphGateway generates a graph rooted at the Gateway given in the node options
+func GraphGateway(business *business.Layer, o graph.Options) (code int, config interface{}, err error) {
+	if o.NodeOptions.Gateway == "" || o.NodeOptions.Namespace == "" {
+		return 500, nil, fmt.Errorf("gateway graph requires a gateway and its namespace")
+	}
+
+	// time how long it takes to generate this graph
+	promtimer := internalmetrics.GetGraphGenerationTimePrometheusTimer(o.GetGraphKind(), o.TelemetryOptions.GraphType, o.InjectServiceNodes)
+	defer promtimer.ObserveDuration()
+
+	routes, err := business.IstioConfig.GetGatewayRoutes(o.NodeOptions.Namespace, o.NodeOptions.Gateway)
+	if err != nil {
+		return 500, nil, err
+	}
+
+	switch o.TelemetryVendor {
+	case graph.VendorIstio:
+		prom, err := prometheus.NewClientNoAuth(business.PromAddress)
+		if err != nil {
+			return 500, nil, err
+		}
+		code, config = graphGatewayIstio(business, prom, o, routes)
+	default:
+		return 500, nil, fmt.Errorf("TelemetryVendor [%s] not supported", o.TelemetryVendor)
+	}
+	// update metrics
+	internalmetrics.SetGraphNodes(o.GetGraphKind(), o.TelemetryOptions.GraphType, o.InjectServiceNodes, 0)
+
+	return code, config, nil
+}
+
+// GraphNodeDetail generates the inbound and outbound tables of the node given in the node options
+func GraphNodeDetail(business *business.Layer, o graph.Options) (detail table.NodeDetail, err error) {
+	if len(o.Namespaces) != 1 {
+		return detail, fmt.Errorf("node detail does not support the 'namespaces' query parameter or the 'all' namespace")
+	}
+
+	promtimer := internalmetrics.GetGraphGenerationTimePrometheusTimer(o.GetGraphKind(), o.TelemetryOptions.GraphType, o.InjectServiceNodes)
+	defer promtimer.ObserveDuration()
+
+	switch o.TelemetryVendor {
+	case graph.VendorIstio:
+		prom, err := prometheus.NewClientNoAuth(business.PromAddress)
+		if err != nil {
+			return detail, err
+		}
+		globalInfo := graph.NewAppenderGlobalInfo()
+		globalInfo.Context = o.Context
+		globalInfo.Business = business
+		globalInfo.PromClient = prom
+		trafficMap := istio.BuildNodeDetailTrafficMap(o.TelemetryOptions, prom, globalInfo, istio.DefaultDetailQuantiles)
+		detail = table.NewNodeDetail(trafficMap, o)
+	default:
+		return detail, fmt.Errorf("TelemetryVendor [%s] not supported", o.TelemetryVendor)
+	}
+
+	return detail, nil
+}
+
 //passThrough 线
 func passThroughEdges(o graph.Options, business *business.Layer) (edge []*cytoscape.EdgeWrapper, err error) {
 	prom, err := prometheus.NewClientNoAuth(business.PromAddress)
@@ -224,6 +310,18 @@ func graphNodeIstio(business *business.Layer, client *prometheus.Client, o graph
 	return code, config
 }
 
+// graphGatewayIstio provides a test hook that accepts mock clients
+func graphGatewayIstio(business *business.Layer, client *prometheus.Client, o graph.Options, routes *models.GatewayRoutes) (code int, config interface{}) {
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.Context = o.Context
+	globalInfo.Business = business
+	globalInfo.PromClient = client
+	trafficMap := istio.BuildGatewayTrafficMap(o.TelemetryOptions, client, globalInfo, routes)
+	code, config = generateGraph(trafficMap, o)
+
+	return code, config
+}
+
 func generateGraph(trafficMap graph.TrafficMap, o graph.Options) (int, cytoscape.Config) {
 	log.Tracef("Generating config for [%s] graph...", o.ConfigVendor)
 
@@ -69,20 +69,26 @@ type NodeData struct {
 	IstioSidecar    bool                `json:"istioSidecar,omitempty"`
 	Version         string              `json:"version,omitempty"`
 	Service         string              `json:"service,omitempty"`         // requested service for NodeTypeService
-	DestServices    []graph.ServiceName `json:"destServices,omitempty"`    // requested services for [dest] node
-	Traffic         []ProtocolTraffic   `json:"traffic,omitempty"`         // traffic rates for all detected protocols
-	HasCB           bool                `json:"hasCB,omitempty"`           // true (has circuit breaker) | false
-	HasMissingSC    bool                `json:"hasMissingSC,omitempty"`    // true (has missing sidecar) | false
-	HasVS           bool                `json:"hasVS,omitempty"`           // true (has route rule) | false
-	IsDead          bool                `json:"isDead,omitempty"`          // true (has no pods) | false
-	IsGroup         string              `json:"isGroup,omitempty"`         // set to the grouping type, current values: [ 'app', 'version' ]
-	IsInaccessible  bool                `json:"isInaccessible,omitempty"`  // true if the node exists in an inaccessible namespace
-	IsMisconfigured string              `json:"isMisconfigured,omitempty"` // set to misconfiguration list, current values: [ 'labels' ]
-	IsOutside       bool                `json:"isOutside,omitempty"`       // true | false
-	IsRoot          bool                `json:"isRoot,omitempty"`          // true | false
-	IsServiceEntry  string              `json:"isServiceEntry,omitempty"`  // set to the location, current values: [ 'MESH_EXTERNAL', 'MESH_INTERNAL' ]
-	IsUnused        bool                `json:"isUnused,omitempty"`        // true | false
-	Context         string              `json:"context,omitempty"`
+	DestServices      []graph.ServiceName `json:"destServices,omitempty"`      // requested services for [dest] node
+	Traffic           []ProtocolTraffic   `json:"traffic,omitempty"`           // traffic rates for all detected protocols
+	EgressHosts       []graph.EgressHost  `json:"egressHosts,omitempty"`       // external hosts reached through an egress cluster node
+	GatewayHosts      []string            `json:"gatewayHosts,omitempty"`      // hosts exposed by a gateway node
+	HasCB             bool                `json:"hasCB,omitempty"`             // true (has circuit breaker) | false
+	HasMissingSC      bool                `json:"hasMissingSC,omitempty"`      // true (has missing sidecar) | false
+	HasMissingService bool                `json:"hasMissingService,omitempty"` // true (route host has no service) | false
+	HasStaleProxy     bool                `json:"hasStaleProxy,omitempty"`     // true (has a proxy with stale config) | false
+	HasVS             bool                `json:"hasVS,omitempty"`             // true (has route rule) | false
+	IsDead            bool                `json:"isDead,omitempty"`            // true (has no pods) | false
+	IsEgressCluster   bool                `json:"isEgressCluster,omitempty"`   // true (PassthroughCluster or BlackHoleCluster) | false
+	IsGateway         string              `json:"isGateway,omitempty"`         // set to the <namespace>/<name> of the gateway
+	IsGroup           string              `json:"isGroup,omitempty"`           // set to the grouping type, current values: [ 'app', 'version' ]
+	IsInaccessible    bool                `json:"isInaccessible,omitempty"`    // true if the node exists in an inaccessible namespace
+	IsMisconfigured   string              `json:"isMisconfigured,omitempty"`   // set to misconfiguration list, current values: [ 'labels' ]
+	IsOutside         bool                `json:"isOutside,omitempty"`         // true | false
+	IsRoot            bool                `json:"isRoot,omitempty"`            // true | false
+	IsServiceEntry    string              `json:"isServiceEntry,omitempty"`    // set to the location, current values: [ 'MESH_EXTERNAL', 'MESH_INTERNAL' ]
+	IsUnused          bool                `json:"isUnused,omitempty"`          // true | false
+	Context           string              `json:"context,omitempty"`
 }
 
 type EdgeData struct {
@@ -92,9 +98,19 @@ type EdgeData struct {
 	Target string `json:"target"` // child node ID
 
 	// App Fields (not required by Cytoscape)
-	Traffic      ProtocolTraffic `json:"traffic,omitempty"`      // traffic rates for the edge protocol
-	ResponseTime string          `json:"responseTime,omitempty"` // in millis
-	IsMTLS       string          `json:"isMTLS,omitempty"`       // set to the percentage of traffic using a mutual TLS connection
+	Traffic               ProtocolTraffic                  `json:"traffic,omitempty"`               // traffic rates for the edge protocol
+	ResponseTime          string                           `json:"responseTime,omitempty"`          // in millis
+	IsMTLS                string                           `json:"isMTLS,omitempty"`                // set to the percentage of traffic using a mutual TLS connection
+	SourcePrincipals      []string                         `json:"sourcePrincipals,omitempty"`      // source principals seen by the destination proxy
+	DestPrincipals        []string                         `json:"destPrincipals,omitempty"`        // destination principals seen by the destination proxy
+	AuthorizationPolicies []graph.AuthorizationPolicyMatch `json:"authorizationPolicies,omitempty"` // AuthorizationPolicies applying to the destination workload
+	HasDenyViolation      bool                             `json:"hasDenyViolation,omitempty"`      // true (traffic a DENY policy should block) | false
+	HasPlaintextInStrict  bool                             `json:"hasPlaintextInStrict,omitempty"`  // true (plaintext traffic into a STRICT namespace) | false
+	EgressHosts           []graph.EgressHost               `json:"egressHosts,omitempty"`           // external hosts reached through the edge, for egress cluster edges
+	GatewayRoutes         []string                         `json:"gatewayRoutes,omitempty"`         // gateway routes (<namespace>/<virtualService>/<path>) behind the edge
+	IsUnusedRoute         bool                             `json:"isUnusedRoute,omitempty"`         // true (declared gateway route with no traffic) | false
+	TrafficFaults         []*graph.TrafficFault            `json:"trafficFaults,omitempty"`         // faults interpreted from the response flags, i.e. fault injection or circuit breaker overflow
+	IsOutOfSidecarScope   string                           `json:"isOutOfSidecarScope,omitempty"`   // set to the <namespace>/<name> of the Sidecar of the source not exposing the destination, its traffic likely ends in the BlackHoleCluster
 }
 
 type NodeWrapper struct {
@@ -294,6 +310,11 @@ func buildConfig(trafficMap graph.TrafficMap, nodes *[]*NodeWrapper, edges *[]*E
 			nd.HasMissingSC = val.(bool)
 		}
 
+		// set proxy sync checks, if available
+		if val, ok := n.Metadata[graph.HasStaleProxy]; ok {
+			nd.HasStaleProxy = val.(bool)
+		}
+
 		// check if node is misconfigured
 		if val, ok := n.Metadata[graph.IsMisconfigured]; ok {
 			nd.IsMisconfigured = val.(string)
@@ -317,6 +338,27 @@ func buildConfig(trafficMap graph.TrafficMap, nodes *[]*NodeWrapper, edges *[]*E
 			nd.IsServiceEntry = val.(string)
 		}
 
+		// node may be a gateway
+		if val, ok := n.Metadata[graph.IsGateway]; ok {
+			nd.IsGateway = val.(string)
+		}
+		if val, ok := n.Metadata[graph.GatewayHosts]; ok {
+			nd.GatewayHosts = val.([]string)
+		}
+
+		// node may be a route destination with no backing service
+		if val, ok := n.Metadata[graph.HasMissingService]; ok {
+			nd.HasMissingService = val.(bool)
+		}
+
+		// node may be an egress cluster, broken down by external host
+		if val, ok := n.Metadata[graph.IsEgressCluster]; ok {
+			nd.IsEgressCluster = val.(bool)
+		}
+		if val, ok := n.Metadata[graph.EgressHosts]; ok {
+			nd.EgressHosts = egressHosts(val.(graph.EgressHostsMetadata))
+		}
+
 		nw := NodeWrapper{
 			Data: nd,
 		}
@@ -347,7 +389,8 @@ func buildConfig(trafficMap graph.TrafficMap, nodes *[]*NodeWrapper, edges *[]*E
 			//todo 加一个变量如果 没有流量数据就不显示
 			// 后面需要加的功能
 
-			if o.DeadEdges || len(ed.Traffic.Rates) != 0 {
+			// declared gateway routes are kept to flag them as unused
+			if o.DeadEdges || len(ed.Traffic.Rates) != 0 || ed.IsUnusedRoute {
 				*edges = append(*edges, &ew)
 			}
 			//*edges = append(*edges, &ew)
@@ -355,6 +398,21 @@ func buildConfig(trafficMap graph.TrafficMap, nodes *[]*NodeWrapper, edges *[]*E
 	}
 }
 
+// egressHosts returns the egress hosts sorted by protocol and host, so the output is stable
+func egressHosts(ehm graph.EgressHostsMetadata) []graph.EgressHost {
+	hosts := make([]graph.EgressHost, 0, len(ehm))
+	for _, h := range ehm {
+		hosts = append(hosts, *h)
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		if hosts[i].Protocol != hosts[j].Protocol {
+			return hosts[i].Protocol < hosts[j].Protocol
+		}
+		return hosts[i].Host < hosts[j].Host
+	})
+	return hosts
+}
+
 func addNodeTelemetry(n *graph.Node, nd *NodeData) {
 	for _, p := range graph.Protocols {
 		protocolTraffic := ProtocolTraffic{Protocol: p.Name}
@@ -383,6 +441,36 @@ func addEdgeTelemetry(e *graph.Edge, ed *EdgeData) {
 		responseTime := val.(float64)
 		ed.ResponseTime = fmt.Sprintf("%.0f", responseTime)
 	}
+	if val, ok := e.Metadata[graph.GatewayRoutes]; ok {
+		ed.GatewayRoutes = val.([]string)
+	}
+	if val, ok := e.Metadata[graph.IsUnusedRoute]; ok {
+		ed.IsUnusedRoute = val.(bool)
+	}
+	if val, ok := e.Metadata[graph.EgressHosts]; ok {
+		ed.EgressHosts = egressHosts(val.(graph.EgressHostsMetadata))
+	}
+	if val, ok := e.Metadata[graph.SourcePrincipals]; ok {
+		ed.SourcePrincipals = val.([]string)
+	}
+	if val, ok := e.Metadata[graph.DestPrincipals]; ok {
+		ed.DestPrincipals = val.([]string)
+	}
+	if val, ok := e.Metadata[graph.AuthorizationPolicies]; ok {
+		ed.AuthorizationPolicies = val.([]graph.AuthorizationPolicyMatch)
+	}
+	if val, ok := e.Metadata[graph.HasDenyViolation]; ok {
+		ed.HasDenyViolation = val.(bool)
+	}
+	if val, ok := e.Metadata[graph.HasPlaintextInStrict]; ok {
+		ed.HasPlaintextInStrict = val.(bool)
+	}
+	if val, ok := e.Metadata[graph.TrafficFaults]; ok {
+		ed.TrafficFaults = val.(graph.TrafficFaultsMetadata)
+	}
+	if val, ok := e.Metadata[graph.IsOutOfSidecarScope]; ok {
+		ed.IsOutOfSidecarScope = val.(string)
+	}
 
 	// an edge represents traffic for at most one protocol
 	for _, p := range graph.Protocols {
@@ -522,6 +610,7 @@ func generateGroupCompoundNodes(appBox map[string][]*NodeData, nodes *[]*NodeWra
 
 				// copy some member attributes to to the compound node (aka app box)
 				nd.HasMissingSC = nd.HasMissingSC || n.HasMissingSC
+				nd.HasStaleProxy = nd.HasStaleProxy || n.HasStaleProxy
 				nd.IsInaccessible = nd.IsInaccessible || n.IsInaccessible
 				nd.IsOutside = nd.IsOutside || n.IsOutside
 			}
@@ -0,0 +1,243 @@
+// Package table provides conversion from our graph to a tabular node detail: every inbound and
+// outbound peer of an app, workload or service together with its traffic figures. It is meant for
+// consumers that do not render a graph, i.e. the CLI or the chatops bot.
+//
+// 节点详情表格: 列出某个 app/workload/service 的所有上游和下游, 以及对应的流量数据
+package table
+
+import (
+	"math"
+	"sort"
+
+	"github.com/kiali/kiali/graph"
+)
+
+// maxTopEntries is the number of response codes and response flags reported per row
+const maxTopEntries = 5
+
+type NodeDetail struct {
+	Cluster  string `json:"cluster"`
+	Node     Peer   `json:"node"`
+	Inbound  []Row  `json:"inbound"`  // traffic received by the node, one row per source and protocol
+	Outbound []Row  `json:"outbound"` // traffic sent by the node, one row per destination and protocol
+}
+
+type Peer struct {
+	Id        string `json:"id"`
+	NodeType  string `json:"nodeType"`
+	Namespace string `json:"namespace"`
+	App       string `json:"app,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Workload  string `json:"workload,omitempty"`
+	Service   string `json:"service,omitempty"`
+}
+
+type Row struct {
+	Peer          Peer               `json:"peer"`
+	Protocol      string             `json:"protocol"`
+	Rate          float64            `json:"rate"`
+	Unit          string             `json:"unit"`                    // rps or bps
+	ErrorPercent  float64            `json:"errorPercent"`            // not reported for tcp
+	ResponseTimes map[string]float64 `json:"responseTimes,omitempty"` // in millis, keyed by quantile, i.e. p95
+	MTLSPercent   float64            `json:"mtlsPercent"`
+	ResponseCodes []ResponseCode     `json:"responseCodes"` // top response codes by rate
+	Flags         []ResponseFlag     `json:"flags"`         // top response flags by rate
+}
+
+type ResponseCode struct {
+	Code    string  `json:"code"`
+	Rate    float64 `json:"rate"`
+	Percent float64 `json:"percent"`
+}
+
+type ResponseFlag struct {
+	Flags   string  `json:"flags"`
+	Rate    float64 `json:"rate"`
+	Percent float64 `json:"percent"`
+}
+
+// NewNodeDetail returns the inbound and outbound tables of the node requested in the options
+func NewNodeDetail(trafficMap graph.TrafficMap, o graph.Options) NodeDetail {
+	detail := NodeDetail{
+		Cluster:  o.Context,
+		Inbound:  []Row{},
+		Outbound: []Row{},
+	}
+
+	targets := make(map[string]bool)
+	for id, n := range trafficMap {
+		if isTarget(n, o.NodeOptions) {
+			targets[id] = true
+			if detail.Node.Id == "" || id < detail.Node.Id {
+				detail.Node = newPeer(n)
+			}
+		}
+	}
+
+	// An edge between two targets, i.e. between two versions of the app, is both sent and received
+	for _, n := range trafficMap {
+		for _, e := range n.Edges {
+			if targets[e.Source.ID] {
+				if row, ok := newRow(e, e.Dest); ok {
+					detail.Outbound = append(detail.Outbound, row)
+				}
+			}
+			if targets[e.Dest.ID] {
+				if row, ok := newRow(e, e.Source); ok {
+					detail.Inbound = append(detail.Inbound, row)
+				}
+			}
+		}
+	}
+
+	sortRows(detail.Inbound)
+	sortRows(detail.Outbound)
+	return detail
+}
+
+// isTarget returns true when the node is the requested node. An app node may be split into several
+// version nodes, all of them are targets.
+func isTarget(n *graph.Node, no graph.NodeOptions) bool {
+	if n.Namespace != no.Namespace {
+		return false
+	}
+	switch {
+	case no.Service != "":
+		return n.NodeType == graph.NodeTypeService && n.Service == no.Service
+	case no.Workload != "":
+		return n.Workload == no.Workload && n.NodeType != graph.NodeTypeService
+	case no.App != "":
+		return n.App == no.App && n.NodeType != graph.NodeTypeService && (no.Version == "" || n.Version == no.Version)
+	}
+	return false
+}
+
+func newPeer(n *graph.Node) Peer {
+	return Peer{
+		Id:        n.ID,
+		NodeType:  n.NodeType,
+		Namespace: n.Namespace,
+		App:       n.App,
+		Version:   n.Version,
+		Workload:  n.Workload,
+		Service:   n.Service,
+	}
+}
+
+// newRow returns the row of an edge, false when the edge has no known protocol
+func newRow(e *graph.Edge, peer *graph.Node) (Row, bool) {
+	protocolName, ok := e.Metadata[graph.ProtocolKey].(string)
+	if !ok {
+		return Row{}, false
+	}
+	var protocol *graph.Protocol
+	for i := range graph.Protocols {
+		if graph.Protocols[i].Name == protocolName {
+			protocol = &graph.Protocols[i]
+			break
+		}
+	}
+	if protocol == nil {
+		return Row{}, false
+	}
+
+	row := Row{
+		Peer:          newPeer(peer),
+		Protocol:      protocolName,
+		Unit:          protocol.UnitShort,
+		ResponseCodes: []ResponseCode{},
+		Flags:         []ResponseFlag{},
+	}
+	errRate := 0.0
+	for _, r := range protocol.EdgeRates {
+		val, ok := e.Metadata[r.Name].(float64)
+		if !ok {
+			continue
+		}
+		switch {
+		case r.IsTotal:
+			row.Rate = val
+		case r.IsErr:
+			errRate += val
+		}
+	}
+	if row.Rate > 0 {
+		row.ErrorPercent = round(errRate * 100 / row.Rate)
+	}
+	if val, ok := e.Metadata[graph.IsMTLS].(float64); ok {
+		row.MTLSPercent = round(val)
+	}
+	if responseTimes, ok := e.Metadata[graph.ResponseTimes].(map[string]float64); ok {
+		row.ResponseTimes = responseTimes
+	}
+	if responses, ok := e.Metadata[protocol.EdgeResponses].(graph.Responses); ok {
+		row.ResponseCodes, row.Flags = topResponses(responses)
+	}
+	return row, true
+}
+
+// topResponses returns the response codes and response flags carrying the most traffic
+func topResponses(responses graph.Responses) ([]ResponseCode, []ResponseFlag) {
+	codes := []ResponseCode{}
+	flagRates := make(map[string]float64)
+	total := 0.0
+	for code, detail := range responses {
+		codeRate := 0.0
+		for flags, val := range detail.Flags {
+			codeRate += val
+			flagRates[flags] += val
+		}
+		codes = append(codes, ResponseCode{Code: code, Rate: codeRate})
+		total += codeRate
+	}
+	flags := []ResponseFlag{}
+	for f, val := range flagRates {
+		flags = append(flags, ResponseFlag{Flags: f, Rate: val})
+	}
+	if total > 0 {
+		for i := range codes {
+			codes[i].Percent = round(codes[i].Rate * 100 / total)
+		}
+		for i := range flags {
+			flags[i].Percent = round(flags[i].Rate * 100 / total)
+		}
+	}
+
+	sort.Slice(codes, func(i, j int) bool {
+		if codes[i].Rate != codes[j].Rate {
+			return codes[i].Rate > codes[j].Rate
+		}
+		return codes[i].Code < codes[j].Code
+	})
+	sort.Slice(flags, func(i, j int) bool {
+		if flags[i].Rate != flags[j].Rate {
+			return flags[i].Rate > flags[j].Rate
+		}
+		return flags[i].Flags < flags[j].Flags
+	})
+	if len(codes) > maxTopEntries {
+		codes = codes[:maxTopEntries]
+	}
+	if len(flags) > maxTopEntries {
+		flags = flags[:maxTopEntries]
+	}
+	return codes, flags
+}
+
+// sortRows sorts by descending rate, ties by peer and protocol so the output is stable
+func sortRows(rows []Row) {
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Rate != rows[j].Rate {
+			return rows[i].Rate > rows[j].Rate
+		}
+		if rows[i].Peer.Id != rows[j].Peer.Id {
+			return rows[i].Peer.Id < rows[j].Peer.Id
+		}
+		return rows[i].Protocol < rows[j].Protocol
+	})
+}
+
+// round to one decimal, like the percentages of the graph
+func round(val float64) float64 {
+	return math.Round(val*10) / 10
+}
@@ -0,0 +1,134 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/graph"
+)
+
+func TestNewNodeDetail(t *testing.T) {
+	assert := assert.New(t)
+
+	trafficMap := nodeDetailTestTraffic()
+	o := graph.Options{}
+	o.Context = "cluster-a"
+	o.NodeOptions = graph.NodeOptions{Namespace: "bookinfo", Service: "reviews"}
+
+	detail := NewNodeDetail(trafficMap, o)
+	assert.Equal("cluster-a", detail.Cluster)
+	assert.Equal(graph.NodeTypeService, detail.Node.NodeType)
+	assert.Equal("reviews", detail.Node.Service)
+
+	assert.Len(detail.Inbound, 1)
+	in := detail.Inbound[0]
+	assert.Equal("productpage", in.Peer.App)
+	assert.Equal("http", in.Protocol)
+	assert.Equal("rps", in.Unit)
+	assert.Equal(10.0, in.Rate)
+	assert.Equal(20.0, in.ErrorPercent)
+	assert.Equal(100.0, in.MTLSPercent)
+	assert.Equal(map[string]float64{"p50": 10.0, "p95": 40.0}, in.ResponseTimes)
+	assert.Equal([]ResponseCode{
+		{Code: "200", Rate: 8.0, Percent: 80.0},
+		{Code: "503", Rate: 2.0, Percent: 20.0},
+	}, in.ResponseCodes)
+	assert.Equal([]ResponseFlag{
+		{Flags: "-", Rate: 8.5, Percent: 85.0},
+		{Flags: "UO", Rate: 1.5, Percent: 15.0},
+	}, in.Flags)
+
+	// outbound rows are sorted by rate
+	assert.Len(detail.Outbound, 2)
+	assert.Equal("reviews-v2", detail.Outbound[0].Peer.Workload)
+	assert.Equal(6.0, detail.Outbound[0].Rate)
+	assert.Equal("reviews-v1", detail.Outbound[1].Peer.Workload)
+	assert.Equal(0.0, detail.Outbound[1].ErrorPercent)
+}
+
+func TestNewNodeDetailApp(t *testing.T) {
+	assert := assert.New(t)
+
+	trafficMap := nodeDetailTestTraffic()
+	o := graph.Options{}
+	o.NodeOptions = graph.NodeOptions{Namespace: "bookinfo", App: "reviews", Version: "v2"}
+
+	detail := NewNodeDetail(trafficMap, o)
+	assert.Equal("reviews-v2", detail.Node.Workload)
+	assert.Len(detail.Inbound, 1)
+	assert.Equal(graph.NodeTypeService, detail.Inbound[0].Peer.NodeType)
+	assert.Empty(detail.Outbound)
+}
+
+func TestNewNodeDetailEdgeBetweenTargets(t *testing.T) {
+	assert := assert.New(t)
+
+	trafficMap := nodeDetailTestTraffic()
+	reviewsV1 := trafficMap[graph.NewNode("bookinfo", "", "bookinfo", "reviews-v1", "reviews", "v1", graph.GraphTypeWorkload).ID]
+	reviewsV2 := trafficMap[graph.NewNode("bookinfo", "", "bookinfo", "reviews-v2", "reviews", "v2", graph.GraphTypeWorkload).ID]
+	e := reviewsV1.AddEdge(reviewsV2)
+	e.Metadata[graph.ProtocolKey] = "http"
+	e.Metadata["http"] = 3.0
+
+	// both versions are targets of the app, the edge is in both tables
+	o := graph.Options{}
+	o.NodeOptions = graph.NodeOptions{Namespace: "bookinfo", App: "reviews"}
+	detail := NewNodeDetail(trafficMap, o)
+
+	assert.Len(detail.Outbound, 1)
+	assert.Equal("reviews-v2", detail.Outbound[0].Peer.Workload)
+	assert.Equal(3.0, detail.Outbound[0].Rate)
+	assert.Len(detail.Inbound, 3)
+	inboundPeers := []string{}
+	for _, row := range detail.Inbound {
+		inboundPeers = append(inboundPeers, row.Peer.Id)
+	}
+	assert.Contains(inboundPeers, reviewsV1.ID)
+}
+
+func TestTopResponses(t *testing.T) {
+	assert := assert.New(t)
+
+	responses := graph.Responses{}
+	for i, code := range []string{"200", "201", "202", "204", "400", "404"} {
+		responses[code] = &graph.ResponseDetail{Flags: graph.ResponseFlags{"-": float64(i + 1)}}
+	}
+	codes, flags := topResponses(responses)
+	assert.Len(codes, maxTopEntries)
+	assert.Equal("404", codes[0].Code)
+	assert.Equal("201", codes[4].Code)
+	assert.Equal([]ResponseFlag{{Flags: "-", Rate: 21.0, Percent: 100.0}}, flags)
+}
+
+func nodeDetailTestTraffic() graph.TrafficMap {
+	productpage := graph.NewNode("bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeWorkload)
+	reviews := graph.NewNode("bookinfo", "reviews", "", "", "", "", graph.GraphTypeWorkload)
+	reviewsV1 := graph.NewNode("bookinfo", "", "bookinfo", "reviews-v1", "reviews", "v1", graph.GraphTypeWorkload)
+	reviewsV2 := graph.NewNode("bookinfo", "", "bookinfo", "reviews-v2", "reviews", "v2", graph.GraphTypeWorkload)
+	trafficMap := graph.NewTrafficMap()
+	trafficMap[productpage.ID] = &productpage
+	trafficMap[reviews.ID] = &reviews
+	trafficMap[reviewsV1.ID] = &reviewsV1
+	trafficMap[reviewsV2.ID] = &reviewsV2
+
+	e := productpage.AddEdge(&reviews)
+	e.Metadata[graph.ProtocolKey] = "http"
+	e.Metadata["http"] = 10.0
+	e.Metadata["http5xx"] = 2.0
+	e.Metadata[graph.IsMTLS] = 100.0
+	e.Metadata[graph.ResponseTimes] = map[string]float64{"p50": 10.0, "p95": 40.0}
+	e.Metadata["httpResponses"] = graph.Responses{
+		"200": &graph.ResponseDetail{Flags: graph.ResponseFlags{"-": 8.0}},
+		"503": &graph.ResponseDetail{Flags: graph.ResponseFlags{"-": 0.5, "UO": 1.5}},
+	}
+
+	e = reviews.AddEdge(&reviewsV1)
+	e.Metadata[graph.ProtocolKey] = "http"
+	e.Metadata["http"] = 4.0
+	e = reviews.AddEdge(&reviewsV2)
+	e.Metadata[graph.ProtocolKey] = "http"
+	e.Metadata["http"] = 6.0
+
+	return trafficMap
+}
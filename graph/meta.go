@@ -13,21 +13,36 @@ func NewMetadata() Metadata {
 
 // Metadata keys to be used instead of literal strings
 const (
-	DestServices    MetadataKey = "destServices"
-	HasCB           MetadataKey = "hasCB"
-	HasMissingSC    MetadataKey = "hasMissingSC"
-	HasVS           MetadataKey = "hasVS"
-	IsDead          MetadataKey = "isDead"
-	IsEgressCluster MetadataKey = "isEgressCluster" // PassthroughCluster or BlackHoleCluster
-	IsInaccessible  MetadataKey = "isInaccessible"
-	IsMisconfigured MetadataKey = "isMisconfigured"
-	IsMTLS          MetadataKey = "isMTLS"
-	IsOutside       MetadataKey = "isOutside"
-	IsRoot          MetadataKey = "isRoot"
-	IsServiceEntry  MetadataKey = "isServiceEntry"
-	IsUnused        MetadataKey = "isUnused"
-	ProtocolKey     MetadataKey = "protocol"
-	ResponseTime    MetadataKey = "responseTime"
+	AuthorizationPolicies MetadataKey = "authorizationPolicies" // AuthorizationPolicies applying to an edge
+	DestPrincipals        MetadataKey = "destPrincipals"        // destination principals reported for an edge
+	DestServices          MetadataKey = "destServices"
+	EgressHosts           MetadataKey = "egressHosts"   // egress cluster traffic broken down by external host
+	GatewayHosts          MetadataKey = "gatewayHosts"  // hosts exposed by a gateway node
+	GatewayRoutes         MetadataKey = "gatewayRoutes" // VirtualService routes behind a gateway edge
+	HasCB                 MetadataKey = "hasCB"
+	HasDenyViolation      MetadataKey = "hasDenyViolation" // edge traffic that a DENY AuthorizationPolicy should block
+	HasMissingSC          MetadataKey = "hasMissingSC"
+	HasMissingService     MetadataKey = "hasMissingService"    // route destination host with no Service or ServiceEntry
+	HasPlaintextInStrict  MetadataKey = "hasPlaintextInStrict" // plaintext edge traffic into a STRICT mTLS namespace
+	HasStaleProxy         MetadataKey = "hasStaleProxy"        // a backing workload proxy does not acknowledge its configuration
+	HasVS                 MetadataKey = "hasVS"
+	IsDead                MetadataKey = "isDead"
+	IsEgressCluster       MetadataKey = "isEgressCluster" // PassthroughCluster or BlackHoleCluster
+	IsGateway             MetadataKey = "isGateway"       // set to the <namespace>/<name> of the gateway
+	IsInaccessible        MetadataKey = "isInaccessible"
+	IsMisconfigured       MetadataKey = "isMisconfigured"
+	IsMTLS                MetadataKey = "isMTLS"
+	IsOutOfSidecarScope   MetadataKey = "isOutOfSidecarScope" // set to the <namespace>/<name> of the Sidecar of the source not exposing the destination
+	IsOutside             MetadataKey = "isOutside"
+	IsRoot                MetadataKey = "isRoot"
+	IsServiceEntry        MetadataKey = "isServiceEntry"
+	IsUnused              MetadataKey = "isUnused"
+	IsUnusedRoute         MetadataKey = "isUnusedRoute" // declared gateway route with no traffic
+	ProtocolKey           MetadataKey = "protocol"
+	ResponseTime          MetadataKey = "responseTime"
+	ResponseTimes         MetadataKey = "responseTimes"    // response time per quantile, i.e. p50, p95, p99
+	SourcePrincipals      MetadataKey = "sourcePrincipals" // source principals reported for an edge
+	TrafficFaults         MetadataKey = "trafficFaults"    // faults reported by the envoy response flags of an edge
 )
 
 // DestServicesMetadata key=Service.Key()
@@ -43,3 +58,61 @@ func (dsm DestServicesMetadata) Add(key string, service ServiceName) DestService
 	dsm[key] = service
 	return dsm
 }
+
+// EgressHost is the traffic sent through an egress cluster (PassthroughCluster or BlackHoleCluster) to a
+// single external host.
+type EgressHost struct {
+	Host              string  `json:"host"`                   // request host, SNI or destination_service, "unknown" when not reported
+	Protocol          string  `json:"protocol"`               // http, grpc or tcp
+	Rate              float64 `json:"rate"`                   // requests per second for http/grpc, bytes per second for tcp
+	ServiceEntry      string  `json:"serviceEntry,omitempty"` // matching ServiceEntry, if any
+	NeedsServiceEntry bool    `json:"needsServiceEntry"`      // true when the host is not registered by any ServiceEntry
+}
+
+// EgressHostsMetadata key=protocol+host
+type EgressHostsMetadata map[string]*EgressHost
+
+// NewEgressHostsMetadata returns an empty EgressHostsMetadata map
+func NewEgressHostsMetadata() EgressHostsMetadata {
+	return make(map[string]*EgressHost)
+}
+
+// Add adds the rate of the given host, creating the entry when needed
+func (ehm EgressHostsMetadata) Add(host EgressHost) {
+	key := host.Protocol + " " + host.Host
+	if existing, ok := ehm[key]; ok {
+		existing.Rate += host.Rate
+		return
+	}
+	ehm[key] = &host
+}
+
+// AuthorizationPolicyMatch is an AuthorizationPolicy applying to the destination workload of an edge
+type AuthorizationPolicyMatch struct {
+	Policy      string `json:"policy"`      // <namespace>/<name>
+	Action      string `json:"action"`      // ALLOW, DENY, AUDIT or CUSTOM
+	Matches     bool   `json:"matches"`     // true when a rule matches a source principal of the edge
+	Conditional bool   `json:"conditional"` // true when the matching rules also restrict operations or conditions
+}
+
+// Kinds of TrafficFault, interpreted from the envoy response flags
+const (
+	TrafficFaultCircuitBreaker       = "circuitBreaker"       // UO: upstream overflow
+	TrafficFaultDownstreamDisconnect = "downstreamDisconnect" // DC: downstream connection termination
+	TrafficFaultInjection            = "faultInjection"       // FI: fault injected abort, DI: fault injected delay
+	TrafficFaultNoRoute              = "noRoute"              // NR: no route configured
+	TrafficFaultRetryExhausted       = "retryExhausted"       // URX: upstream retry limit exceeded
+)
+
+// TrafficFault is the edge traffic reporting a given kind of fault, together with the config likely causing it
+type TrafficFault struct {
+	Kind             string   `json:"kind"`
+	Flags            []string `json:"flags"`                      // response flags reporting the fault, i.e. UO
+	Rate             float64  `json:"rate"`                       // rate of the edge traffic reporting the fault
+	Percent          float64  `json:"percent"`                    // percentage of the edge traffic reporting the fault
+	VirtualServices  []string `json:"virtualServices,omitempty"`  // <namespace>/<name> of the VirtualServices likely causing it
+	DestinationRules []string `json:"destinationRules,omitempty"` // <namespace>/<name> of the DestinationRules likely causing it
+}
+
+// TrafficFaultsMetadata is sorted by kind
+type TrafficFaultsMetadata []*TrafficFault
@@ -41,6 +41,7 @@ const (
 )
 
 const (
+	graphKindGateway   string = "gateway"
 	graphKindNamespace string = "namespace"
 	graphKindNode      string = "node"
 )
@@ -48,6 +49,7 @@ const (
 // NodeOptions are those that apply only to node-detail graphs
 type NodeOptions struct {
 	App       string
+	Gateway   string // set for gateway graphs, the gateway lives in Namespace
 	Namespace string
 	Service   string
 	Version   string
@@ -275,6 +277,9 @@ func NewOptions(
 
 // GetGraphKind will return the kind of graph represented by the options.
 func (o *TelemetryOptions) GetGraphKind() string {
+	if o.NodeOptions.Gateway != "" {
+		return graphKindGateway
+	}
 	if o.NodeOptions.App != "" ||
 		o.NodeOptions.Version != "" ||
 		o.NodeOptions.Workload != "" ||
@@ -307,6 +312,7 @@ func getAccessibleNamespaces(token string) map[string]time.Time {
 
 type Option struct {
 	App                string `json:"app"`
+	Gateway            string `json:"gateway"`
 	Namespace          string `json:"namespace"`
 	Service            string `json:"service"`
 	Version            string `json:"version"`
@@ -344,6 +350,7 @@ func NewSimpleOption(namespaces, context, prometheusUrl string, clusters map[str
 			//"serviceEntry," +
 			"istio," +
 			"securityPolicy," +
+			"authorizationPolicy," +
 			"unusedNode," +
 			"replicasNode",
 		Namespaces:  namespaces,
@@ -380,6 +387,33 @@ func (o Option) SetApp(app, version string) Option {
 	return o
 }
 
+// SetWorkload 查询 workload 节点, 需要 workload 视图
+func (o Option) SetWorkload(workload string) Option {
+	if workload != "" {
+		o.Workload = workload
+		o.GraphType = GraphTypeWorkload
+		o.PassThrough = false
+	}
+	return o
+}
+
+// SetInjectServiceNodes 是否在 workload 之前插入 service 节点
+func (o Option) SetInjectServiceNodes(inject bool) Option {
+	o.InjectServiceNodes = strconv.FormatBool(inject)
+	return o
+}
+
+// SetAppenders 覆盖默认的 appenders, 以逗号分隔
+func (o Option) SetAppenders(appenders string) Option {
+	o.Appenders = appenders
+	return o
+}
+
+func (o Option) SetGateway(gateway string) Option {
+	o.Gateway = gateway
+	return o
+}
+
 func (o Option) SetNamespace(namespace string) Option {
 	o.Namespace = namespace
 	return o
@@ -395,9 +429,18 @@ func (o Option) SetDeadEdges(deadEdges bool) Option {
 	return o
 }
 
+// SetEgress 是否按外部 host 拆分 PassthroughCluster 和 BlackHoleCluster 的流量
+func (o Option) SetEgress(egress bool) Option {
+	if egress && o.Appenders != "" {
+		o.Appenders += ",egress"
+	}
+	return o
+}
+
 func (o *Option) NewGraphOptions(restConfig *rest.Config, address string) (Options, error) {
 	// path variables (0 or more will be set)
 	app := o.App
+	gateway := o.Gateway
 	namespace := o.Namespace
 	service := o.Service
 	version := o.Version
@@ -543,6 +586,7 @@ func (o *Option) NewGraphOptions(restConfig *rest.Config, address string) (Optio
 			},
 			NodeOptions: NodeOptions{
 				App:       app,
+				Gateway:   gateway,
 				Namespace: namespace,
 				Service:   service,
 				Version:   version,
@@ -45,10 +45,14 @@ func ParseAppenders(o graph.TelemetryOptions) []graph.Appender {
 				requestedAppenders[ResponseTimeAppenderName] = true
 			case SecurityPolicyAppenderName:
 				requestedAppenders[SecurityPolicyAppenderName] = true
+			case AuthorizationPolicyAppenderName:
+				requestedAppenders[AuthorizationPolicyAppenderName] = true
 			case SidecarsCheckAppenderName:
 				requestedAppenders[SidecarsCheckAppenderName] = true
 			case UnusedNodeAppenderName:
 				requestedAppenders[UnusedNodeAppenderName] = true
+			case EgressAppenderName:
+				requestedAppenders[EgressAppenderName] = true
 			case "":
 				// skip
 			default:
@@ -79,9 +83,22 @@ func ParseAppenders(o graph.TelemetryOptions) []graph.Appender {
 		}
 		appenders = append(appenders, a)
 	}
+	// egress is opt-in, it is never part of the "all appenders" request
+	_, egress := requestedAppenders[EgressAppenderName]
 	// 2. 负责从图中删除不需要的节点：
 	if _, ok := requestedAppenders[DeadNodeAppenderName]; ok || o.Appenders.All {
-		a := DeadNodeAppender{}
+		a := DeadNodeAppender{
+			KeepEgressClusters: egress,
+		}
+		appenders = append(appenders, a)
+	}
+	// 2.1 负责按外部 host 拆分 PassthroughCluster 和 BlackHoleCluster 的流量
+	if egress {
+		a := EgressAppender{
+			AccessibleNamespaces: o.AccessibleNamespaces,
+			Namespaces:           o.Namespaces,
+			QueryTime:            o.QueryTime,
+		}
 		appenders = append(appenders, a)
 	}
 	// 3. 负责找到服务的响应时间  ResponseTime 信息添加到图形中。
@@ -113,6 +130,16 @@ func ParseAppenders(o graph.TelemetryOptions) []graph.Appender {
 		}
 		appenders = append(appenders, a)
 	}
+	// 4.1 负责向边添加 principal 和 AuthorizationPolicy 信息
+	if _, ok := requestedAppenders[AuthorizationPolicyAppenderName]; ok || o.Appenders.All {
+		a := AuthorizationPolicyAppender{
+			GraphType:          o.GraphType,
+			InjectServiceNodes: o.InjectServiceNodes,
+			Namespaces:         o.Namespaces,
+			QueryTime:          o.QueryTime,
+		}
+		appenders = append(appenders, a)
+	}
 	// 5。 负责向 图表中添加 没有用到的节点信息
 	if _, ok := requestedAppenders[UnusedNodeAppenderName]; ok || o.Appenders.All {
 		hasNodeOptions := o.App != "" || o.Workload != "" || o.Service != ""
@@ -0,0 +1,322 @@
+package appender
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/business/checkers/authorization"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+)
+
+const (
+	AuthorizationPolicyAppenderName = "authorizationPolicy"
+	policyNone                      = "none"
+	meshSecurityConfigKey           = "meshSecurityConfig" // global vendor info
+)
+
+// AuthorizationPolicyAppender is responsible for annotating the edges with the source and destination principals
+// reported by telemetry, and the AuthorizationPolicies applying to them. It flags edges with traffic that a DENY
+// policy should block, and plaintext edges into namespaces with STRICT mTLS.
+// 负责在边上添加 source/destination principal 以及对应的 AuthorizationPolicy 信息，
+// 并标记应该被 DENY 策略拦截的流量以及 STRICT 命名空间中的明文流量
+// Name: authorizationPolicy
+type AuthorizationPolicyAppender struct {
+	GraphType          string
+	InjectServiceNodes bool
+	Namespaces         map[string]graph.NamespaceInfo
+	QueryTime          int64 // unix time in seconds
+}
+
+// securityConfig is the security configuration of a namespace
+type securityConfig struct {
+	authorizationPolicies models.AuthorizationPolicies
+	mtlsMode              string // namespace-wide PeerAuthentication mode
+}
+
+// edgeSecurity is the security telemetry reported for an edge
+type edgeSecurity struct {
+	sourcePrincipals map[string]bool
+	destPrincipals   map[string]bool
+	plaintext        float64
+	destWlNs         string
+	destWl           string
+}
+
+// Name implements Appender
+func (a AuthorizationPolicyAppender) Name() string {
+	return AuthorizationPolicyAppenderName
+}
+
+// AppendGraph implements Appender
+func (a AuthorizationPolicyAppender) AppendGraph(trafficMap graph.TrafficMap, globalInfo *graph.AppenderGlobalInfo, namespaceInfo *graph.AppenderNamespaceInfo) error {
+	if len(trafficMap) == 0 {
+		return errors.New("trafficMap is nil")
+	}
+
+	if globalInfo.PromClient == nil {
+		var err error
+		globalInfo.PromClient, err = prometheus.NewClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	if getWorkloadList(namespaceInfo) == nil {
+		workloadList, err := globalInfo.Business.Workload.GetWorkloadList(namespaceInfo.Namespace)
+		if err != nil {
+			return err
+		}
+		namespaceInfo.Vendor[workloadListKey] = &workloadList
+	}
+
+	var err error
+	meshConfig, found := globalInfo.Vendor[meshSecurityConfigKey].(*securityConfig)
+	if !found {
+		if meshConfig, err = getSecurityConfig(config.Get().IstioNamespace, globalInfo.Business); err != nil {
+			return err
+		}
+		globalInfo.Vendor[meshSecurityConfigKey] = meshConfig
+	}
+	// the policies of the istio namespace are mesh-wide, they are only evaluated once
+	nsConfig := &securityConfig{}
+	if namespaceInfo.Namespace != config.Get().IstioNamespace {
+		if nsConfig, err = getSecurityConfig(namespaceInfo.Namespace, globalInfo.Business); err != nil {
+			return err
+		}
+	}
+
+	edgeSecurityMap := a.queryEdgeSecurity(namespaceInfo.Namespace, globalInfo.PromClient)
+	applyAuthorizationPolicies(trafficMap, edgeSecurityMap, nsConfig, meshConfig, namespaceInfo)
+	return nil
+}
+
+func (a AuthorizationPolicyAppender) AppendGraphNoAuth(trafficMap graph.TrafficMap, globalInfo *graph.AppenderGlobalInfo, namespaceInfo *graph.AppenderNamespaceInfo, client *prometheus.Client) {
+}
+
+func getSecurityConfig(namespace string, layer *business.Layer) (*securityConfig, error) {
+	istioCfg, err := layer.IstioConfig.GetIstioConfigList(business.IstioConfigCriteria{
+		IncludeAuthorizationPolicies: true,
+		IncludePeerAuthentication:    true,
+		Namespace:                    namespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sc := &securityConfig{
+		authorizationPolicies: istioCfg.AuthorizationPolicies,
+	}
+	for _, pa := range istioCfg.PeerAuthentications {
+		if mode := peerAuthnNamespaceMode(pa); mode != "" {
+			sc.mtlsMode = mode
+		}
+	}
+	return sc, nil
+}
+
+// peerAuthnNamespaceMode returns the mTLS mode of a namespace-wide PeerAuthentication, empty for workload ones
+func peerAuthnNamespaceMode(pa models.PeerAuthentication) string {
+	if selector, ok := pa.Spec.Selector.(map[string]interface{}); ok {
+		if matchLabels, ok := selector["matchLabels"].(map[string]interface{}); ok && len(matchLabels) > 0 {
+			return ""
+		}
+	}
+	if mtls, ok := pa.Spec.Mtls.(map[string]interface{}); ok {
+		if mode, ok := mtls["mode"].(string); ok {
+			return mode
+		}
+	}
+	return ""
+}
+
+func (a AuthorizationPolicyAppender) queryEdgeSecurity(namespace string, client *prometheus.Client) map[string]*edgeSecurity {
+	log.Tracef("Resolving authorization policies for namespace = %v", namespace)
+	duration := a.Namespaces[namespace].Duration
+
+	// query prometheus for the principals of the traffic entering the namespace workloads (use dest telemetry because
+	// authorization is enforced by the destination proxy). Denied http requests (403) are not taken into account.
+	groupBy := fmt.Sprintf("source_workload_namespace,source_workload,source_%s,source_%s,destination_service_namespace,destination_service_name,destination_workload_namespace,destination_workload,destination_%s,destination_%s,source_principal,destination_principal,connection_security_policy", appLabel, verLabel, appLabel, verLabel)
+	httpQuery := fmt.Sprintf(`sum(rate(%s{reporter="destination",destination_workload_namespace="%v",response_code!="403"}[%vs])) by (%s) > 0`,
+		"istio_requests_total",
+		namespace,
+		int(duration.Seconds()), // range duration for the query
+		groupBy)
+	tcpQuery := fmt.Sprintf(`sum(rate(%s{reporter="destination",destination_workload_namespace="%v"}[%vs])) by (%s) > 0`,
+		"istio_tcp_sent_bytes_total",
+		namespace,
+		int(duration.Seconds()), // range duration for the query
+		groupBy)
+	query := fmt.Sprintf(`(%s) OR (%s)`, httpQuery, tcpQuery)
+	vector := promQuery(query, time.Unix(a.QueryTime, 0), client.API(), a)
+
+	edgeSecurityMap := make(map[string]*edgeSecurity)
+	a.populateEdgeSecurityMap(edgeSecurityMap, &vector)
+	return edgeSecurityMap
+}
+
+func (a AuthorizationPolicyAppender) populateEdgeSecurityMap(edgeSecurityMap map[string]*edgeSecurity, vector *model.Vector) {
+	for _, s := range *vector {
+		m := s.Metric
+		lSourceWlNs, sourceWlNsOk := m["source_workload_namespace"]
+		lSourceWl, sourceWlOk := m["source_workload"]
+		lSourceApp, sourceAppOk := m[model.LabelName("source_"+appLabel)]
+		lSourceVer, sourceVerOk := m[model.LabelName("source_"+verLabel)]
+		lDestSvcNs, destSvcNsOk := m["destination_service_namespace"]
+		lDestSvcName, destSvcNameOk := m["destination_service_name"]
+		lDestWlNs, destWlNsOk := m["destination_workload_namespace"]
+		lDestWl, destWlOk := m["destination_workload"]
+		lDestApp, destAppOk := m[model.LabelName("destination_"+appLabel)]
+		lDestVer, destVerOk := m[model.LabelName("destination_"+verLabel)]
+		lSourcePrincipal, sourcePrincipalOk := m["source_principal"]
+		lDestPrincipal, destPrincipalOk := m["destination_principal"]
+		lCsp, cspOk := m["connection_security_policy"]
+
+		if !sourceWlNsOk || !sourceWlOk || !sourceAppOk || !sourceVerOk || !destSvcNsOk || !destSvcNameOk || !destWlNsOk || !destWlOk || !destAppOk || !destVerOk || !sourcePrincipalOk || !destPrincipalOk || !cspOk {
+			log.Warningf("Skipping %v, missing expected labels", m.String())
+			continue
+		}
+
+		sourceWlNs := string(lSourceWlNs)
+		sourceWl := string(lSourceWl)
+		sourceApp := string(lSourceApp)
+		sourceVer := string(lSourceVer)
+		destSvcNs := string(lDestSvcNs)
+		destSvcName := string(lDestSvcName)
+		destWlNs := string(lDestWlNs)
+		destWl := string(lDestWl)
+		destApp := string(lDestApp)
+		destVer := string(lDestVer)
+
+		es := edgeSecurity{
+			sourcePrincipals: map[string]bool{string(lSourcePrincipal): true},
+			destPrincipals:   map[string]bool{string(lDestPrincipal): true},
+			destWlNs:         destWlNs,
+			destWl:           destWl,
+		}
+		if string(lCsp) == policyNone {
+			es.plaintext = float64(s.Value)
+		}
+
+		// don't inject a service node if destSvcName is not set or the dest node is already a service node.
+		inject := false
+		if a.InjectServiceNodes && graph.IsOK(destSvcName) {
+			_, destNodeType := graph.Id(destSvcNs, destSvcName, destWlNs, destWl, destApp, destVer, a.GraphType)
+			inject = (graph.NodeTypeService != destNodeType)
+		}
+		if inject {
+			a.addEdgeSecurity(edgeSecurityMap, es, sourceWlNs, "", sourceWl, sourceApp, sourceVer, destSvcNs, destSvcName, "", "", "", "")
+			a.addEdgeSecurity(edgeSecurityMap, es, destSvcNs, destSvcName, "", "", "", destSvcNs, destSvcName, destWlNs, destWl, destApp, destVer)
+		} else {
+			a.addEdgeSecurity(edgeSecurityMap, es, sourceWlNs, "", sourceWl, sourceApp, sourceVer, destSvcNs, destSvcName, destWlNs, destWl, destApp, destVer)
+		}
+	}
+}
+
+func (a AuthorizationPolicyAppender) addEdgeSecurity(edgeSecurityMap map[string]*edgeSecurity, es edgeSecurity, sourceNs, sourceSvc, sourceWl, sourceApp, sourceVer, destSvcNs, destSvc, destWlNs, destWl, destApp, destVer string) {
+	sourceId, _ := graph.Id(sourceNs, sourceSvc, sourceNs, sourceWl, sourceApp, sourceVer, a.GraphType)
+	destId, _ := graph.Id(destSvcNs, destSvc, destWlNs, destWl, destApp, destVer, a.GraphType)
+	key := fmt.Sprintf("%s %s", sourceId, destId)
+	existing, ok := edgeSecurityMap[key]
+	if !ok {
+		existing = &edgeSecurity{
+			sourcePrincipals: map[string]bool{},
+			destPrincipals:   map[string]bool{},
+			destWlNs:         es.destWlNs,
+			destWl:           es.destWl,
+		}
+		edgeSecurityMap[key] = existing
+	}
+	for p := range es.sourcePrincipals {
+		existing.sourcePrincipals[p] = true
+	}
+	for p := range es.destPrincipals {
+		existing.destPrincipals[p] = true
+	}
+	existing.plaintext += es.plaintext
+}
+
+func applyAuthorizationPolicies(trafficMap graph.TrafficMap, edgeSecurityMap map[string]*edgeSecurity, nsConfig, meshConfig *securityConfig, namespaceInfo *graph.AppenderNamespaceInfo) {
+	// namespace-wide PeerAuthentication overrides the mesh-wide one
+	mtlsMode := nsConfig.mtlsMode
+	if mtlsMode == "" {
+		mtlsMode = meshConfig.mtlsMode
+	}
+
+	for _, s := range trafficMap {
+		for _, e := range s.Edges {
+			key := fmt.Sprintf("%s %s", e.Source.ID, e.Dest.ID)
+			es, ok := edgeSecurityMap[key]
+			if !ok {
+				continue
+			}
+			e.Metadata[graph.SourcePrincipals] = principals(es.sourcePrincipals)
+			e.Metadata[graph.DestPrincipals] = principals(es.destPrincipals)
+
+			if es.plaintext > 0 && mtlsMode == "STRICT" {
+				e.Metadata[graph.HasPlaintextInStrict] = true
+			}
+
+			wlLabels := map[string]string{}
+			if workload, found := getWorkload(es.destWl, namespaceInfo); found {
+				wlLabels = workload.Labels
+			}
+			matches := []graph.AuthorizationPolicyMatch{}
+			for _, policies := range []models.AuthorizationPolicies{meshConfig.authorizationPolicies, nsConfig.authorizationPolicies} {
+				for _, ap := range policies {
+					if !authorization.SelectorMatches(ap.Spec.Selector, wlLabels) {
+						continue
+					}
+					match := matchAuthorizationPolicy(ap, es)
+					if match.Action == "DENY" && match.Matches && !match.Conditional {
+						e.Metadata[graph.HasDenyViolation] = true
+					}
+					matches = append(matches, match)
+				}
+			}
+			if len(matches) > 0 {
+				e.Metadata[graph.AuthorizationPolicies] = matches
+			}
+		}
+	}
+}
+
+// matchAuthorizationPolicy evaluates the policy rules against every source principal seen on the edge
+func matchAuthorizationPolicy(ap models.AuthorizationPolicy, es *edgeSecurity) graph.AuthorizationPolicyMatch {
+	action, _ := ap.Spec.Action.(string)
+	if action == "" {
+		action = "ALLOW"
+	}
+	match := graph.AuthorizationPolicyMatch{
+		Policy: ap.Metadata.Namespace + "/" + ap.Metadata.Name,
+		Action: action,
+	}
+	unconditional := false
+	for p := range es.sourcePrincipals {
+		matches, conditional := authorization.RulesMatchSource(ap.Spec.Rules, authorization.NewPolicySource(p))
+		if matches {
+			match.Matches = true
+			unconditional = unconditional || !conditional
+		}
+	}
+	match.Conditional = match.Matches && !unconditional
+	return match
+}
+
+func principals(set map[string]bool) []string {
+	result := make([]string, 0, len(set))
+	for p := range set {
+		if graph.IsOK(p) {
+			result = append(result, p)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
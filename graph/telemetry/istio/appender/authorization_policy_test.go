@@ -0,0 +1,189 @@
+package appender
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/models"
+)
+
+func TestAuthorizationPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	vector := model.Vector{
+		&model.Sample{
+			Metric: authorizationPolicyMetric("productpage-v1", "productpage", "spiffe://cluster.local/ns/bookinfo/sa/bookinfo-productpage", "mutual_tls"),
+			Value:  10.0},
+		&model.Sample{
+			Metric: authorizationPolicyMetric("sleep-v1", "sleep", "unknown", "none"),
+			Value:  5.0}}
+
+	trafficMap := authorizationPolicyTestTraffic()
+	appender := AuthorizationPolicyAppender{
+		GraphType: graph.GraphTypeVersionedApp,
+	}
+	edgeSecurityMap := make(map[string]*edgeSecurity)
+	appender.populateEdgeSecurityMap(edgeSecurityMap, &vector)
+
+	allow := models.AuthorizationPolicy{Metadata: meta_v1.ObjectMeta{Name: "allow-productpage", Namespace: "bookinfo"}}
+	allow.Spec.Selector = map[string]interface{}{"matchLabels": map[string]interface{}{"app": "details"}}
+	allow.Spec.Rules = []interface{}{
+		map[string]interface{}{
+			"from": []interface{}{
+				map[string]interface{}{
+					"source": map[string]interface{}{
+						"principals": []interface{}{"cluster.local/ns/bookinfo/sa/bookinfo-productpage"},
+					},
+				},
+			},
+			"to": []interface{}{
+				map[string]interface{}{
+					"operation": map[string]interface{}{"methods": []interface{}{"GET"}},
+				},
+			},
+		},
+	}
+	deny := models.AuthorizationPolicy{Metadata: meta_v1.ObjectMeta{Name: "deny-bookinfo", Namespace: "istio-system"}}
+	deny.Spec.Action = "DENY"
+	deny.Spec.Rules = []interface{}{
+		map[string]interface{}{
+			"from": []interface{}{
+				map[string]interface{}{
+					"source": map[string]interface{}{
+						"namespaces": []interface{}{"bookinfo"},
+					},
+				},
+			},
+		},
+	}
+	other := models.AuthorizationPolicy{Metadata: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}}
+	other.Spec.Selector = map[string]interface{}{"matchLabels": map[string]interface{}{"app": "reviews"}}
+
+	nsConfig := &securityConfig{authorizationPolicies: models.AuthorizationPolicies{allow, other}, mtlsMode: "STRICT"}
+	meshConfig := &securityConfig{authorizationPolicies: models.AuthorizationPolicies{deny}, mtlsMode: "PERMISSIVE"}
+
+	namespaceInfo := graph.NewAppenderNamespaceInfo("bookinfo")
+	namespaceInfo.Vendor[workloadListKey] = &models.WorkloadList{
+		Namespace: models.Namespace{Name: "bookinfo"},
+		Workloads: []models.WorkloadListItem{
+			{Name: "details-v1", Labels: map[string]string{"app": "details", "version": "v1"}},
+		},
+	}
+
+	applyAuthorizationPolicies(trafficMap, edgeSecurityMap, nsConfig, meshConfig, namespaceInfo)
+
+	productpageId, _ := graph.Id("bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeVersionedApp)
+	productpage := trafficMap[productpageId]
+	assert.Len(productpage.Edges, 1)
+	edge := productpage.Edges[0]
+	assert.Equal([]string{"spiffe://cluster.local/ns/bookinfo/sa/bookinfo-productpage"}, edge.Metadata[graph.SourcePrincipals])
+	assert.Equal([]string{"spiffe://cluster.local/ns/bookinfo/sa/bookinfo-details"}, edge.Metadata[graph.DestPrincipals])
+	assert.Equal([]graph.AuthorizationPolicyMatch{
+		{Policy: "istio-system/deny-bookinfo", Action: "DENY", Matches: true, Conditional: false},
+		{Policy: "bookinfo/allow-productpage", Action: "ALLOW", Matches: true, Conditional: true},
+	}, edge.Metadata[graph.AuthorizationPolicies])
+	assert.Equal(true, edge.Metadata[graph.HasDenyViolation])
+	assert.Nil(edge.Metadata[graph.HasPlaintextInStrict])
+
+	sleepId, _ := graph.Id("bookinfo", "", "bookinfo", "sleep-v1", "sleep", "v1", graph.GraphTypeVersionedApp)
+	sleep := trafficMap[sleepId]
+	edge = sleep.Edges[0]
+	assert.Equal([]string{}, edge.Metadata[graph.SourcePrincipals])
+	assert.Equal([]graph.AuthorizationPolicyMatch{
+		{Policy: "istio-system/deny-bookinfo", Action: "DENY", Matches: false, Conditional: false},
+		{Policy: "bookinfo/allow-productpage", Action: "ALLOW", Matches: false, Conditional: false},
+	}, edge.Metadata[graph.AuthorizationPolicies])
+	assert.Nil(edge.Metadata[graph.HasDenyViolation])
+	assert.Equal(true, edge.Metadata[graph.HasPlaintextInStrict])
+}
+
+func TestAuthorizationPolicyRequestSource(t *testing.T) {
+	assert := assert.New(t)
+
+	vector := model.Vector{
+		&model.Sample{
+			Metric: authorizationPolicyMetric("productpage-v1", "productpage", "spiffe://cluster.local/ns/bookinfo/sa/bookinfo-productpage", "mutual_tls"),
+			Value:  10.0}}
+
+	trafficMap := authorizationPolicyTestTraffic()
+	appender := AuthorizationPolicyAppender{
+		GraphType: graph.GraphTypeVersionedApp,
+	}
+	edgeSecurityMap := make(map[string]*edgeSecurity)
+	appender.populateEdgeSecurityMap(edgeSecurityMap, &vector)
+
+	// the source IPs are not known from telemetry, the DENY policy may not apply to the edge
+	deny := models.AuthorizationPolicy{Metadata: meta_v1.ObjectMeta{Name: "deny-ips", Namespace: "bookinfo"}}
+	deny.Spec.Action = "DENY"
+	deny.Spec.Rules = []interface{}{
+		map[string]interface{}{
+			"from": []interface{}{
+				map[string]interface{}{
+					"source": map[string]interface{}{
+						"ipBlocks": []interface{}{"10.1.0.0/16"},
+					},
+				},
+			},
+		},
+	}
+	nsConfig := &securityConfig{authorizationPolicies: models.AuthorizationPolicies{deny}}
+
+	namespaceInfo := graph.NewAppenderNamespaceInfo("bookinfo")
+	namespaceInfo.Vendor[workloadListKey] = &models.WorkloadList{Namespace: models.Namespace{Name: "bookinfo"}}
+
+	applyAuthorizationPolicies(trafficMap, edgeSecurityMap, nsConfig, &securityConfig{}, namespaceInfo)
+
+	productpageId, _ := graph.Id("bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeVersionedApp)
+	edge := trafficMap[productpageId].Edges[0]
+	assert.Equal([]graph.AuthorizationPolicyMatch{
+		{Policy: "bookinfo/deny-ips", Action: "DENY", Matches: true, Conditional: true},
+	}, edge.Metadata[graph.AuthorizationPolicies])
+	assert.Nil(edge.Metadata[graph.HasDenyViolation])
+}
+
+func TestPeerAuthnNamespaceMode(t *testing.T) {
+	assert := assert.New(t)
+
+	pa := models.PeerAuthentication{}
+	pa.Spec.Mtls = map[string]interface{}{"mode": "STRICT"}
+	assert.Equal("STRICT", peerAuthnNamespaceMode(pa))
+
+	pa.Spec.Selector = map[string]interface{}{"matchLabels": map[string]interface{}{"app": "details"}}
+	assert.Equal("", peerAuthnNamespaceMode(pa))
+}
+
+func authorizationPolicyMetric(sourceWl, sourceApp, sourcePrincipal, csp string) model.Metric {
+	return model.Metric{
+		"source_workload_namespace":      "bookinfo",
+		"source_workload":                model.LabelValue(sourceWl),
+		"source_app":                     model.LabelValue(sourceApp),
+		"source_version":                 "v1",
+		"destination_service_namespace":  "bookinfo",
+		"destination_service_name":       "details",
+		"destination_workload_namespace": "bookinfo",
+		"destination_workload":           "details-v1",
+		"destination_app":                "details",
+		"destination_version":            "v1",
+		"source_principal":               model.LabelValue(sourcePrincipal),
+		"destination_principal":          "spiffe://cluster.local/ns/bookinfo/sa/bookinfo-details",
+		"connection_security_policy":     model.LabelValue(csp)}
+}
+
+func authorizationPolicyTestTraffic() graph.TrafficMap {
+	productpage := graph.NewNode("bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeVersionedApp)
+	sleep := graph.NewNode("bookinfo", "", "bookinfo", "sleep-v1", "sleep", "v1", graph.GraphTypeVersionedApp)
+	details := graph.NewNode("bookinfo", "details", "bookinfo", "details-v1", "details", "v1", graph.GraphTypeVersionedApp)
+	trafficMap := graph.NewTrafficMap()
+	trafficMap[productpage.ID] = &productpage
+	trafficMap[sleep.ID] = &sleep
+	trafficMap[details.ID] = &details
+
+	productpage.AddEdge(&details)
+	sleep.AddEdge(&details)
+
+	return trafficMap
+}
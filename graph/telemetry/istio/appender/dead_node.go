@@ -16,7 +16,9 @@ const DeadNodeAppenderName = "deadNode"
 // - service nodes that are not service entries (kiali-1526) and for which there is no incoming
 //   error traffic and no outgoing edges (kiali-1326).
 // Name: deadNode
-type DeadNodeAppender struct{}
+type DeadNodeAppender struct {
+	KeepEgressClusters bool // keep PassthroughCluster nodes, used by the egress appender
+}
 
 // Name implements Appender
 func (a DeadNodeAppender) Name() string {
@@ -29,7 +31,9 @@ func (a DeadNodeAppender) AppendGraph(trafficMap graph.TrafficMap, globalInfo *g
 		return errors.New("trafficMap is nil")
 	}
 	//删除含 PassthroughCluster 的点
-	delete(trafficMap, "svc_unknown_PassthroughCluster")
+	if !a.KeepEgressClusters {
+		delete(trafficMap, "svc_unknown_PassthroughCluster")
+	}
 	if getWorkloadList(namespaceInfo) == nil {
 		workloadList, err := globalInfo.Business.Workload.GetWorkloadList(namespaceInfo.Namespace)
 		if err != nil {
@@ -0,0 +1,196 @@
+package appender
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/prometheus"
+)
+
+const (
+	EgressAppenderName = "egress"
+	egressClusters     = "PassthroughCluster|BlackHoleCluster"
+)
+
+// EgressAppender is responsible for breaking down the traffic sent to the Istio egress clusters
+// (PassthroughCluster and BlackHoleCluster) by external host. The host is the request_host for http
+// traffic, the SNI (requested_server_name) for tcp traffic, falling back to destination_service. Every host
+// is cross-referenced with the ServiceEntries of the accessible namespaces, so hosts that should be
+// registered are flagged.
+// 负责按外部 host 拆分发往 PassthroughCluster 和 BlackHoleCluster 的流量，并标记需要 ServiceEntry 的 host
+// Name: egress
+type EgressAppender struct {
+	AccessibleNamespaces map[string]time.Time
+	Namespaces           graph.NamespaceInfoMap
+	QueryTime            int64 // unix time in seconds
+}
+
+// Name implements Appender
+func (a EgressAppender) Name() string {
+	return EgressAppenderName
+}
+
+// AppendGraph implements Appender
+func (a EgressAppender) AppendGraph(trafficMap graph.TrafficMap, globalInfo *graph.AppenderGlobalInfo, namespaceInfo *graph.AppenderNamespaceInfo) error {
+	if len(trafficMap) == 0 {
+		return errors.New("trafficMap is nil")
+	}
+
+	if !hasEgressCluster(trafficMap) {
+		return nil
+	}
+
+	if globalInfo.PromClient == nil {
+		var err error
+		globalInfo.PromClient, err = prometheus.NewClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	seHosts := loadServiceEntryHosts(a.AccessibleNamespaces, globalInfo)
+	a.appendGraph(trafficMap, namespaceInfo.Namespace, globalInfo.PromClient, seHosts)
+	return nil
+}
+
+func (a EgressAppender) AppendGraphNoAuth(trafficMap graph.TrafficMap, globalInfo *graph.AppenderGlobalInfo, namespaceInfo *graph.AppenderNamespaceInfo, client *prometheus.Client) {
+}
+
+func (a EgressAppender) appendGraph(trafficMap graph.TrafficMap, namespace string, client *prometheus.Client, seHosts serviceEntryHosts) {
+	log.Tracef("Resolving egress hosts for namespace = %v", namespace)
+	duration := a.Namespaces[namespace].Duration
+
+	// egress clusters are only reported by the source proxy
+	groupBy := fmt.Sprintf("source_workload_namespace,source_workload,source_%s,destination_service_name,destination_service", appLabel)
+	httpQuery := fmt.Sprintf(`sum(rate(%s{reporter="source",source_workload_namespace="%v",destination_service_name=~"%s"}[%vs])) by (%s,request_protocol,request_host) > 0`,
+		"istio_requests_total",
+		namespace,
+		egressClusters,
+		int(duration.Seconds()), // range duration for the query
+		groupBy)
+	httpVector := promQuery(httpQuery, time.Unix(a.QueryTime, 0), client.API(), a)
+
+	tcpQuery := fmt.Sprintf(`sum(rate(%s{reporter="source",source_workload_namespace="%v",destination_service_name=~"%s"}[%vs])) by (%s,requested_server_name) > 0`,
+		"istio_tcp_sent_bytes_total",
+		namespace,
+		egressClusters,
+		int(duration.Seconds()), // range duration for the query
+		groupBy)
+	tcpVector := promQuery(tcpQuery, time.Unix(a.QueryTime, 0), client.API(), a)
+
+	a.addEgressHosts(trafficMap, &httpVector, "request_host", seHosts)
+	a.addEgressHosts(trafficMap, &tcpVector, "requested_server_name", seHosts)
+}
+
+func (a EgressAppender) addEgressHosts(trafficMap graph.TrafficMap, vector *model.Vector, hostLabel string, seHosts serviceEntryHosts) {
+	for _, s := range *vector {
+		m := s.Metric
+		lSourceWlNs, sourceWlNsOk := m["source_workload_namespace"]
+		lSourceWl, sourceWlOk := m["source_workload"]
+		lSourceApp, sourceAppOk := m[model.LabelName("source_"+appLabel)]
+		lDestSvcName, destSvcNameOk := m["destination_service_name"]
+		if !sourceWlNsOk || !sourceWlOk || !sourceAppOk || !destSvcNameOk {
+			log.Warningf("Skipping %v, missing expected labels", m.String())
+			continue
+		}
+
+		protocol := "tcp"
+		if hostLabel == "request_host" {
+			protocol = "http"
+			if p, ok := m["request_protocol"]; ok && string(p) == "grpc" {
+				protocol = "grpc"
+			}
+		}
+
+		host := egressHost(string(m[model.LabelName(hostLabel)]), string(m["destination_service"]), string(lDestSvcName))
+		eh := graph.EgressHost{
+			Host:     host,
+			Protocol: protocol,
+			Rate:     float64(s.Value),
+		}
+		if host != graph.Unknown {
+			if seName, found := matchServiceEntry(host, seHosts); found {
+				eh.ServiceEntry = seName
+			} else {
+				eh.NeedsServiceEntry = true
+			}
+		}
+
+		sourceWlNs := string(lSourceWlNs)
+		sourceWl := string(lSourceWl)
+		sourceApp := string(lSourceApp)
+		updated := map[string]bool{}
+		for _, n := range trafficMap {
+			if n.Namespace != sourceWlNs {
+				continue
+			}
+			if n.Workload != sourceWl && (n.Workload != "" || n.App != sourceApp) {
+				continue
+			}
+			for _, e := range n.Edges {
+				if e.Dest.Metadata[graph.IsEgressCluster] != true || e.Dest.Service != string(lDestSvcName) {
+					continue
+				}
+				addEgressHost(e.Metadata, eh)
+				if !updated[e.Dest.ID] {
+					addEgressHost(e.Dest.Metadata, eh)
+					updated[e.Dest.ID] = true
+				}
+			}
+		}
+	}
+}
+
+// egressHost returns the external host reached through an egress cluster, without port. The reported host
+// (request_host or SNI) is preferred, destination_service is used otherwise unless it is just the cluster name.
+func egressHost(reportedHost, destService, destServiceName string) string {
+	host := reportedHost
+	if !graph.IsOK(host) {
+		host = destService
+		if !graph.IsOK(host) || host == destServiceName {
+			return graph.Unknown
+		}
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return host
+}
+
+// matchServiceEntry returns the name of the ServiceEntry registering the host, supporting wildcard hosts
+func matchServiceEntry(host string, seHosts serviceEntryHosts) (string, bool) {
+	if se, found := seHosts[host]; found {
+		return se.name, true
+	}
+	for seHost, se := range seHosts {
+		if strings.HasPrefix(seHost, "*") && strings.HasSuffix(host, seHost[1:]) {
+			return se.name, true
+		}
+	}
+	return "", false
+}
+
+func addEgressHost(md graph.Metadata, host graph.EgressHost) {
+	egressHosts, ok := md[graph.EgressHosts].(graph.EgressHostsMetadata)
+	if !ok {
+		egressHosts = graph.NewEgressHostsMetadata()
+		md[graph.EgressHosts] = egressHosts
+	}
+	egressHosts.Add(host)
+}
+
+func hasEgressCluster(trafficMap graph.TrafficMap) bool {
+	for _, n := range trafficMap {
+		if n.Metadata[graph.IsEgressCluster] == true {
+			return true
+		}
+	}
+	return false
+}
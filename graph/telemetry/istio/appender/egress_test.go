@@ -0,0 +1,92 @@
+package appender
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/graph"
+)
+
+func TestEgress(t *testing.T) {
+	assert := assert.New(t)
+
+	v0 := model.Vector{
+		&model.Sample{
+			Metric: model.Metric{
+				"source_workload_namespace": "bookinfo",
+				"source_workload":           "reviews-v1",
+				"source_app":                "reviews",
+				"destination_service_name":  "PassthroughCluster",
+				"destination_service":       "PassthroughCluster",
+				"request_protocol":          "http",
+				"request_host":              "api.github.com:443"},
+			Value: 10.0},
+		&model.Sample{
+			Metric: model.Metric{
+				"source_workload_namespace": "bookinfo",
+				"source_workload":           "reviews-v1",
+				"source_app":                "reviews",
+				"destination_service_name":  "PassthroughCluster",
+				"destination_service":       "PassthroughCluster",
+				"request_protocol":          "http",
+				"request_host":              "de.wikipedia.org"},
+			Value: 5.0}}
+
+	v1 := model.Vector{
+		&model.Sample{
+			Metric: model.Metric{
+				"source_workload_namespace": "bookinfo",
+				"source_workload":           "reviews-v1",
+				"source_app":                "reviews",
+				"destination_service_name":  "PassthroughCluster",
+				"destination_service":       "PassthroughCluster",
+				"requested_server_name":     "unknown"},
+			Value: 400.0}}
+
+	reviews := graph.NewNode("bookinfo", "reviews", "bookinfo", "reviews-v1", "reviews", "v1", graph.GraphTypeVersionedApp)
+	passthrough := graph.NewNode(graph.Unknown, "PassthroughCluster", graph.Unknown, "", "", "", graph.GraphTypeVersionedApp)
+	trafficMap := graph.NewTrafficMap()
+	trafficMap[reviews.ID] = &reviews
+	trafficMap[passthrough.ID] = &passthrough
+	edge := reviews.AddEdge(&passthrough)
+
+	seHosts := newServiceEntryHosts()
+	seHosts.addHost("*.wikipedia.org", &serviceEntry{location: "MESH_EXTERNAL", name: "wikipedia"})
+
+	appender := EgressAppender{}
+	appender.addEgressHosts(trafficMap, &v0, "request_host", seHosts)
+	appender.addEgressHosts(trafficMap, &v1, "requested_server_name", seHosts)
+
+	edgeHosts, ok := edge.Metadata[graph.EgressHosts].(graph.EgressHostsMetadata)
+	assert.True(ok)
+	assert.Len(edgeHosts, 3)
+
+	github := edgeHosts["http api.github.com"]
+	assert.Equal(10.0, github.Rate)
+	assert.Equal("", github.ServiceEntry)
+	assert.True(github.NeedsServiceEntry)
+
+	wikipedia := edgeHosts["http de.wikipedia.org"]
+	assert.Equal(5.0, wikipedia.Rate)
+	assert.Equal("wikipedia", wikipedia.ServiceEntry)
+	assert.False(wikipedia.NeedsServiceEntry)
+
+	unknown := edgeHosts["tcp unknown"]
+	assert.Equal(400.0, unknown.Rate)
+	assert.False(unknown.NeedsServiceEntry)
+
+	nodeHosts, ok := passthrough.Metadata[graph.EgressHosts].(graph.EgressHostsMetadata)
+	assert.True(ok)
+	assert.Len(nodeHosts, 3)
+}
+
+func TestEgressHost(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("api.github.com", egressHost("api.github.com:443", "PassthroughCluster", "PassthroughCluster"))
+	assert.Equal("10.0.0.1", egressHost("unknown", "10.0.0.1:5432", "PassthroughCluster"))
+	assert.Equal(graph.Unknown, egressHost("", "PassthroughCluster", "PassthroughCluster"))
+	assert.Equal(graph.Unknown, egressHost("unknown", "unknown", "BlackHoleCluster"))
+}
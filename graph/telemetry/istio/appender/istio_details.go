@@ -16,6 +16,7 @@ const IstioAppenderName = "istio"
 // 负责标记具有特殊Istio意义的节点：
 // - CircuitBreaker: n.Metadata[HasCB] = true
 // - VirtualService: n.Metadata[HasVS] = true
+// - TrafficFaults: e.Metadata[TrafficFaults] = faults reported by the response flags, linked to the VS/DR likely causing them
 // Name: istio
 type IstioAppender struct{}
 
@@ -55,6 +56,7 @@ func addBadging(trafficMap graph.TrafficMap, globalInfo *graph.AppenderGlobalInf
 
 	applyCircuitBreakers(trafficMap, namespaceInfo.Namespace, istioCfg)
 	applyVirtualServices(trafficMap, namespaceInfo.Namespace, istioCfg)
+	applyTrafficFaults(trafficMap, namespaceInfo.Namespace, istioCfg)
 }
 
 func applyCircuitBreakers(trafficMap graph.TrafficMap, namespace string, istioCfg models.IstioConfigList) {
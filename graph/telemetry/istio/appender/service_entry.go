@@ -176,35 +176,7 @@ func (a ServiceEntryAppender) applyServiceEntries(trafficMap graph.TrafficMap, g
 // all namespaces (exportTo: *). It's possible that would allow traffic to flow from an accessible workload
 // through a serviceEntry whose definition we can't fetch.
 func (a ServiceEntryAppender) getServiceEntry(serviceName string, globalInfo *graph.AppenderGlobalInfo) (*serviceEntry, bool) {
-	serviceEntryHosts, found := getServiceEntryHosts(globalInfo)
-	if !found {
-		for ns := range a.AccessibleNamespaces {
-			//todo cache
-			istioCfg, err := globalInfo.Business.IstioConfig.GetIstioConfigList(business.IstioConfigCriteria{
-				IncludeServiceEntries: true,
-				Namespace:             ns,
-			})
-			graph.CheckError(err)
-
-			for _, entry := range istioCfg.ServiceEntries {
-				if entry.Spec.Hosts != nil {
-					location := "MESH_EXTERNAL"
-					if entry.Spec.Location == "MESH_INTERNAL" {
-						location = "MESH_INTERNAL"
-					}
-					se := serviceEntry{
-						location: location,
-						address:  entry.Spec.Endpoints,
-						name:     entry.Metadata.Name,
-					}
-					for _, host := range entry.Spec.Hosts.([]interface{}) {
-						serviceEntryHosts.addHost(host.(string), &se)
-					}
-				}
-			}
-		}
-		globalInfo.Vendor[serviceEntryHostsKey] = serviceEntryHosts
-	}
+	serviceEntryHosts := loadServiceEntryHosts(a.AccessibleNamespaces, globalInfo)
 
 	for host, se := range serviceEntryHosts {
 		// handle exact match
@@ -235,6 +207,42 @@ func (a ServiceEntryAppender) getServiceEntry(serviceName string, globalInfo *gr
 	return nil, false
 }
 
+// loadServiceEntryHosts returns the hosts of the service entries defined in the accessible namespaces. The result
+// is cached in the global info so it is fetched only once per graph.
+func loadServiceEntryHosts(accessibleNamespaces map[string]time.Time, globalInfo *graph.AppenderGlobalInfo) serviceEntryHosts {
+	serviceEntryHosts, found := getServiceEntryHosts(globalInfo)
+	if found {
+		return serviceEntryHosts
+	}
+	for ns := range accessibleNamespaces {
+		//todo cache
+		istioCfg, err := globalInfo.Business.IstioConfig.GetIstioConfigList(business.IstioConfigCriteria{
+			IncludeServiceEntries: true,
+			Namespace:             ns,
+		})
+		graph.CheckError(err)
+
+		for _, entry := range istioCfg.ServiceEntries {
+			if entry.Spec.Hosts != nil {
+				location := "MESH_EXTERNAL"
+				if entry.Spec.Location == "MESH_INTERNAL" {
+					location = "MESH_INTERNAL"
+				}
+				se := serviceEntry{
+					location: location,
+					address:  entry.Spec.Endpoints,
+					name:     entry.Metadata.Name,
+				}
+				for _, host := range entry.Spec.Hosts.([]interface{}) {
+					serviceEntryHosts.addHost(host.(string), &se)
+				}
+			}
+		}
+	}
+	globalInfo.Vendor[serviceEntryHostsKey] = serviceEntryHosts
+	return serviceEntryHosts
+}
+
 func (a ServiceEntryAppender) AppendGraphNoAuth(trafficMap graph.TrafficMap, globalInfo *graph.AppenderGlobalInfo, namespaceInfo *graph.AppenderNamespaceInfo, client *prometheus.Client) {
 
 }
@@ -2,18 +2,26 @@ package appender
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/prometheus"
 )
 
 const SidecarsCheckAppenderName = "sidecarsCheck"
 
 // SidecarsCheckAppender flags nodes whose backing workloads are missing at least one Envoy sidecar. Note that
-// a node with no backing workloads is not flagged.
+// a node with no backing workloads is not flagged. It also flags the edges to services outside the effective
+// Sidecar resource of the source workloads, that traffic likely goes to the BlackHoleCluster, and the nodes whose
+// backing workloads have a proxy not acknowledging the configuration sent by istiod.
 // Name: sidecarsCheck
 // SidecarsCheckAppender标记其后备工作负载缺少至少一个Envoy sidecar的节点。请注意，
-// 没有后备工作负载的节点未标记。
+// 没有后备工作负载的节点未标记。同时标记目标服务不在源工作负载生效 Sidecar 范围内的边。
 // 名称：sidecarsCheck
 type SidecarsCheckAppender struct{}
 
@@ -37,6 +45,21 @@ func (a SidecarsCheckAppender) AppendGraph(trafficMap graph.TrafficMap, globalIn
 	}
 
 	a.applySidecarsChecks(trafficMap, namespaceInfo)
+
+	if config.Get().ExternalServices.Istio.ProxyStatusEnabled {
+		if proxyStatus, err := globalInfo.Business.Proxy.GetNamespaceProxyStatus(namespaceInfo.Namespace); err != nil {
+			log.Warningf("Proxy status of namespace [%s] could not be read: %v", namespaceInfo.Namespace, err)
+		} else {
+			a.applyProxyStatusChecks(trafficMap, proxyStatus, namespaceInfo)
+		}
+	}
+
+	scopes, err := globalInfo.Business.SidecarScope.GetSidecarScopes(namespaceInfo.Namespace)
+	if err != nil {
+		log.Warningf("Sidecar scope of namespace [%s] could not be read: %v", namespaceInfo.Namespace, err)
+		return nil
+	}
+	a.applySidecarScopeChecks(trafficMap, scopes, namespaceInfo)
 	return nil
 }
 
@@ -86,6 +109,92 @@ func (a *SidecarsCheckAppender) applySidecarsChecks(trafficMap graph.TrafficMap,
 	}
 }
 
+// applyProxyStatusChecks flags the nodes with at least one backing workload having a stale proxy
+func (a *SidecarsCheckAppender) applyProxyStatusChecks(trafficMap graph.TrafficMap, proxyStatus *models.NamespaceProxyStatus, namespaceInfo *graph.AppenderNamespaceInfo) {
+	if len(proxyStatus.StaleWorkloads) == 0 {
+		return
+	}
+	stale := make(map[string]bool, len(proxyStatus.StaleWorkloads))
+	for _, workload := range proxyStatus.StaleWorkloads {
+		stale[workload] = true
+	}
+
+	for _, n := range trafficMap {
+		if n.Namespace != namespaceInfo.Namespace || config.IsIstioNamespace(n.Namespace) {
+			continue
+		}
+		if isDead, ok := n.Metadata[graph.IsDead]; ok && isDead.(bool) {
+			continue
+		}
+
+		switch n.NodeType {
+		case graph.NodeTypeWorkload:
+			if stale[n.Workload] {
+				n.Metadata[graph.HasStaleProxy] = true
+			}
+		case graph.NodeTypeApp:
+			for _, workload := range getAppWorkloads(n.App, n.Version, namespaceInfo) {
+				if stale[workload.Name] {
+					n.Metadata[graph.HasStaleProxy] = true
+					break
+				}
+			}
+		}
+	}
+}
+
+// applySidecarScopeChecks flags the edges whose destination service is not an egress host of the effective Sidecar
+// of any of the source workloads
+func (a *SidecarsCheckAppender) applySidecarScopeChecks(trafficMap graph.TrafficMap, scopes *business.SidecarScopes, namespaceInfo *graph.AppenderNamespaceInfo) {
+	for _, n := range trafficMap {
+		if n.Namespace != namespaceInfo.Namespace || config.IsIstioNamespace(n.Namespace) || len(n.Edges) == 0 {
+			continue
+		}
+
+		var workloads []models.WorkloadListItem
+		switch n.NodeType {
+		case graph.NodeTypeWorkload:
+			if workload, found := getWorkload(n.Workload, namespaceInfo); found {
+				workloads = append(workloads, *workload)
+			}
+		case graph.NodeTypeApp:
+			workloads = getAppWorkloads(n.App, n.Version, namespaceInfo)
+		}
+
+		sidecarScopes := make([]*models.SidecarScope, 0, len(workloads))
+		for _, workload := range workloads {
+			if scope := scopes.Effective(workload.Name, workload.Labels); scope.Sidecar != nil {
+				sidecarScopes = append(sidecarScopes, scope)
+			}
+		}
+		if len(sidecarScopes) == 0 {
+			continue
+		}
+
+		for _, e := range n.Edges {
+			// traffic to the egress clusters is already known to leave the mesh registry
+			if isEgress, ok := e.Dest.Metadata[graph.IsEgressCluster]; ok && isEgress.(bool) {
+				continue
+			}
+			for _, ds := range destServices(e.Dest) {
+				if !graph.IsOK(ds.Name) {
+					continue
+				}
+				host := ds.Name
+				if !strings.Contains(host, ".") {
+					host = fmt.Sprintf("%s.%s.%s", ds.Name, ds.Namespace, config.Get().ExternalServices.Istio.IstioIdentityDomain)
+				}
+				for _, scope := range sidecarScopes {
+					if !scope.IsReachable(ds.Namespace, host) {
+						e.Metadata[graph.IsOutOfSidecarScope] = scope.Sidecar.Namespace + "/" + scope.Sidecar.Name
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
 func (a SidecarsCheckAppender) AppendGraphNoAuth(trafficMap graph.TrafficMap, globalInfo *graph.AppenderGlobalInfo, namespaceInfo *graph.AppenderNamespaceInfo, client *prometheus.Client) {
 
 }
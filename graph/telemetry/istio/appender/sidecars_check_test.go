@@ -17,7 +17,10 @@ import (
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
 )
 
 func TestWorkloadSidecarsPasses(t *testing.T) {
@@ -129,6 +132,63 @@ func TestServicesAreAlwaysValid(t *testing.T) {
 	}
 }
 
+func TestEdgeOutOfSidecarScopeIsFlagged(t *testing.T) {
+	config.Set(config.NewConfig())
+	trafficMap := buildWorkloadTrafficMap()
+	sourceId, _ := graph.Id("testNamespace", "", "testNamespace", "workload-1", graph.Unknown, graph.Unknown, graph.GraphTypeWorkload)
+	source := trafficMap[sourceId]
+	inScope := graph.NewNode("testNamespace", "ratings", "testNamespace", graph.Unknown, graph.Unknown, graph.Unknown, graph.GraphTypeWorkload)
+	outOfScope := graph.NewNode("other", "reviews", "other", graph.Unknown, graph.Unknown, graph.Unknown, graph.GraphTypeWorkload)
+	source.AddEdge(&inScope)
+	source.AddEdge(&outOfScope)
+
+	sidecar := data.AddHostsToSidecar([]interface{}{"./*", "istio-system/*"},
+		data.AddSelectorToSidecar(map[string]interface{}{"labels": map[string]interface{}{"wk": "wk-1"}},
+			data.CreateSidecar("workload-1", "testNamespace")))
+	businessLayer := setupSidecarsCheckWorkloadsWithSidecars(buildFakeWorkloadDeployments(), buildFakeWorkloadPods(), []kubernetes.IstioObject{sidecar})
+
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.Business = businessLayer
+	namespaceInfo := graph.NewAppenderNamespaceInfo("testNamespace")
+	workloadList := data.CreateWorkloadList("testNamespace", data.CreateWorkloadListItem("workload-1", map[string]string{"app": "myTest", "wk": "wk-1"}))
+	namespaceInfo.Vendor[workloadListKey] = &workloadList
+
+	a := SidecarsCheckAppender{}
+	a.AppendGraph(trafficMap, globalInfo, namespaceInfo)
+
+	for _, e := range source.Edges {
+		if e.Dest.Service == "reviews" {
+			assert.Equal(t, "testNamespace/workload-1", e.Metadata[graph.IsOutOfSidecarScope])
+		} else {
+			assert.NotContains(t, e.Metadata, graph.IsOutOfSidecarScope)
+		}
+	}
+}
+
+func TestStaleProxyIsFlagged(t *testing.T) {
+	config.Set(config.NewConfig())
+	trafficMap := buildAppTrafficMap()
+	workloadNode := graph.NewNode("testNamespace", "", "testNamespace", "workload-2", graph.Unknown, graph.Unknown, graph.GraphTypeWorkload)
+	trafficMap[workloadNode.ID] = &workloadNode
+
+	namespaceInfo := graph.NewAppenderNamespaceInfo("testNamespace")
+	workloadList := data.CreateWorkloadList("testNamespace",
+		data.CreateWorkloadListItem("workload-1", map[string]string{"app": "myTest"}),
+		data.CreateWorkloadListItem("workload-2", map[string]string{"app": "other"}))
+	namespaceInfo.Vendor[workloadListKey] = &workloadList
+
+	a := SidecarsCheckAppender{}
+	a.applyProxyStatusChecks(trafficMap, &models.NamespaceProxyStatus{StaleWorkloads: []string{"workload-1"}}, namespaceInfo)
+
+	for _, node := range trafficMap {
+		if node.NodeType == graph.NodeTypeApp {
+			assert.Equal(t, true, node.Metadata[graph.HasStaleProxy])
+		} else {
+			assert.NotContains(t, node.Metadata, graph.HasStaleProxy)
+		}
+	}
+}
+
 func buildWorkloadTrafficMap() graph.TrafficMap {
 	trafficMap := graph.NewTrafficMap()
 
@@ -200,6 +260,10 @@ func buildFakeWorkloadPodsNoSidecar() []core_v1.Pod {
 }
 
 func setupSidecarsCheckWorkloads(deployments []apps_v1.Deployment, pods []core_v1.Pod) *business.Layer {
+	return setupSidecarsCheckWorkloadsWithSidecars(deployments, pods, []kubernetes.IstioObject{})
+}
+
+func setupSidecarsCheckWorkloadsWithSidecars(deployments []apps_v1.Deployment, pods []core_v1.Pod, sidecars []kubernetes.IstioObject) *business.Layer {
 	k8s := kubetest.NewK8SClientMock()
 
 	k8s.On("GetProject", mock.AnythingOfType("string")).Return(&osproject_v1.Project{}, nil)
@@ -211,6 +275,8 @@ func setupSidecarsCheckWorkloads(deployments []apps_v1.Deployment, pods []core_v
 	k8s.On("GetReplicationControllers", mock.AnythingOfType("string")).Return([]core_v1.ReplicationController{}, nil)
 	k8s.On("GetReplicaSets", mock.AnythingOfType("string")).Return([]apps_v1.ReplicaSet{}, nil)
 	k8s.On("GetStatefulSets", mock.AnythingOfType("string")).Return([]apps_v1.StatefulSet{}, nil)
+	k8s.On("GetSidecars", "testNamespace").Return(sidecars, nil)
+	k8s.On("GetSidecars", mock.AnythingOfType("string")).Return([]kubernetes.IstioObject{}, nil)
 	config.Set(config.NewConfig())
 
 	businessLayer := business.NewWithBackends(k8s, nil, nil)
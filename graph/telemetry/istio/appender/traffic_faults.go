@@ -0,0 +1,159 @@
+package appender
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/models"
+)
+
+// trafficFaultKinds maps the envoy response flags to the kind of fault they report
+// 将 envoy 的 response flags 映射为故障类型
+var trafficFaultKinds = map[string]string{
+	"DC":  graph.TrafficFaultDownstreamDisconnect,
+	"DI":  graph.TrafficFaultInjection,
+	"FI":  graph.TrafficFaultInjection,
+	"NR":  graph.TrafficFaultNoRoute,
+	"UO":  graph.TrafficFaultCircuitBreaker,
+	"URX": graph.TrafficFaultRetryExhausted,
+}
+
+// applyTrafficFaults marks the edges reporting fault injection, circuit breaker overflow, retry exhaustion,
+// missing routes or downstream disconnects, and links them to the VirtualServices and DestinationRules of
+// the namespace likely causing them. It runs once per namespace, the faults of an edge are computed on the
+// first pass and the following passes only add config links.
+// 根据 response flags 标记边上的故障注入, 熔断, 重试耗尽, 无路由等, 并关联可能导致该故障的 VirtualService 和 DestinationRule
+func applyTrafficFaults(trafficMap graph.TrafficMap, namespace string, istioCfg models.IstioConfigList) {
+	for _, n := range trafficMap {
+		for _, e := range n.Edges {
+			faults, ok := e.Metadata[graph.TrafficFaults].(graph.TrafficFaultsMetadata)
+			if !ok {
+				faults = newTrafficFaults(e)
+				if len(faults) == 0 {
+					continue
+				}
+				e.Metadata[graph.TrafficFaults] = faults
+			}
+			for _, ds := range destServices(e.Dest) {
+				for _, fault := range faults {
+					linkTrafficFault(fault, ds, namespace, istioCfg)
+				}
+			}
+		}
+	}
+}
+
+// newTrafficFaults interprets the response flags of the edge responses
+func newTrafficFaults(e *graph.Edge) graph.TrafficFaultsMetadata {
+	faultMap := make(map[string]*graph.TrafficFault)
+	total := 0.0
+	for _, protocol := range graph.Protocols {
+		responses, ok := e.Metadata[protocol.EdgeResponses].(graph.Responses)
+		if !ok {
+			continue
+		}
+		if val, ok := e.Metadata[graph.MetadataKey(protocol.Name)].(float64); ok {
+			total += val
+		}
+		for _, detail := range responses {
+			for flags, val := range detail.Flags {
+				// a response may report several flags, i.e. UO,URX, count it once per kind
+				kinds := make(map[string]bool)
+				for _, flag := range strings.Split(flags, ",") {
+					kind, ok := trafficFaultKinds[flag]
+					if !ok {
+						continue
+					}
+					fault, ok := faultMap[kind]
+					if !ok {
+						fault = &graph.TrafficFault{Kind: kind, Flags: []string{}}
+						faultMap[kind] = fault
+					}
+					if !containsString(fault.Flags, flag) {
+						fault.Flags = append(fault.Flags, flag)
+					}
+					if !kinds[kind] {
+						kinds[kind] = true
+						fault.Rate += val
+					}
+				}
+			}
+		}
+	}
+
+	faults := graph.TrafficFaultsMetadata{}
+	for _, fault := range faultMap {
+		sort.Strings(fault.Flags)
+		if total > 0 {
+			fault.Percent = math.Round(fault.Rate*1000/total) / 10
+		}
+		faults = append(faults, fault)
+	}
+	sort.Slice(faults, func(i, j int) bool {
+		return faults[i].Kind < faults[j].Kind
+	})
+	return faults
+}
+
+// destServices returns the services the edge traffic was sent to
+func destServices(n *graph.Node) []graph.ServiceName {
+	if n.NodeType == graph.NodeTypeService {
+		return []graph.ServiceName{{Namespace: n.Namespace, Name: n.Service}}
+	}
+	result := []graph.ServiceName{}
+	if dsm, ok := n.Metadata[graph.DestServices].(graph.DestServicesMetadata); ok {
+		for _, ds := range dsm {
+			result = append(result, ds)
+		}
+	}
+	return result
+}
+
+// linkTrafficFault adds the config of the namespace likely causing the fault on the given service:
+// - faultInjection: VirtualServices injecting faults
+// - retryExhausted: VirtualServices setting retries
+// - noRoute: VirtualServices routing the service, its matches do not cover the request
+// - circuitBreaker: DestinationRules setting a connection pool or outlier detection
+// A downstream disconnect is caused by the client, no config is linked.
+func linkTrafficFault(fault *graph.TrafficFault, ds graph.ServiceName, namespace string, istioCfg models.IstioConfigList) {
+	switch fault.Kind {
+	case graph.TrafficFaultInjection, graph.TrafficFaultRetryExhausted, graph.TrafficFaultNoRoute:
+		for _, vs := range istioCfg.VirtualServices.Items {
+			if !vs.IsValidHost(ds.Namespace, ds.Name) {
+				continue
+			}
+			if (fault.Kind == graph.TrafficFaultInjection && !vs.HasFaultInjection()) ||
+				(fault.Kind == graph.TrafficFaultRetryExhausted && !vs.HasRetries()) {
+				continue
+			}
+			fault.VirtualServices = appendConfigRef(fault.VirtualServices, namespace, vs.Metadata.Name)
+		}
+	case graph.TrafficFaultCircuitBreaker:
+		for _, dr := range istioCfg.DestinationRules.Items {
+			if dr.HasCircuitBreaker(ds.Namespace, ds.Name, "") {
+				fault.DestinationRules = appendConfigRef(fault.DestinationRules, namespace, dr.Metadata.Name)
+			}
+		}
+	}
+}
+
+func appendConfigRef(refs []string, namespace, name string) []string {
+	ref := namespace + "/" + name
+	if containsString(refs, ref) {
+		return refs
+	}
+	refs = append(refs, ref)
+	sort.Strings(refs)
+	return refs
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
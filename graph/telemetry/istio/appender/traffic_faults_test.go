@@ -0,0 +1,76 @@
+package appender
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/models"
+)
+
+func TestTrafficFaults(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	productpage := graph.NewNode("bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeVersionedApp)
+	reviews := graph.NewNode("bookinfo", "reviews", "", "", "", "", graph.GraphTypeVersionedApp)
+	ratings := graph.NewNode("bookinfo", "", "bookinfo", "ratings-v1", "ratings", "v1", graph.GraphTypeVersionedApp)
+	ratings.Metadata[graph.DestServices] = graph.NewDestServicesMetadata().Add("bookinfo ratings", graph.ServiceName{Namespace: "bookinfo", Name: "ratings"})
+	trafficMap := graph.NewTrafficMap()
+	trafficMap[productpage.ID] = &productpage
+	trafficMap[reviews.ID] = &reviews
+	trafficMap[ratings.ID] = &ratings
+
+	e := productpage.AddEdge(&reviews)
+	e.Metadata[graph.ProtocolKey] = "http"
+	e.Metadata["http"] = 10.0
+	e.Metadata["httpResponses"] = graph.Responses{
+		"200": &graph.ResponseDetail{Flags: graph.ResponseFlags{"-": 6.0}},
+		"503": &graph.ResponseDetail{Flags: graph.ResponseFlags{"UO,URX": 1.0, "FI": 2.0}},
+		"404": &graph.ResponseDetail{Flags: graph.ResponseFlags{"NR": 1.0}},
+	}
+	healthy := productpage.AddEdge(&ratings)
+	healthy.Metadata[graph.ProtocolKey] = "http"
+	healthy.Metadata["http"] = 5.0
+	healthy.Metadata["httpResponses"] = graph.Responses{
+		"200": &graph.ResponseDetail{Flags: graph.ResponseFlags{"-": 5.0}},
+	}
+
+	fault := models.VirtualService{Metadata: meta_v1.ObjectMeta{Name: "reviews-fault"}}
+	fault.Spec.Hosts = []interface{}{"reviews"}
+	fault.Spec.Http = []interface{}{
+		map[string]interface{}{
+			"fault": map[string]interface{}{"abort": map[string]interface{}{"httpStatus": 503}},
+			"route": []interface{}{map[string]interface{}{"destination": map[string]interface{}{"host": "reviews"}}},
+		},
+	}
+	other := models.VirtualService{Metadata: meta_v1.ObjectMeta{Name: "ratings"}}
+	other.Spec.Hosts = []interface{}{"ratings"}
+	other.Spec.Http = []interface{}{
+		map[string]interface{}{
+			"route": []interface{}{map[string]interface{}{"destination": map[string]interface{}{"host": "ratings"}}},
+		},
+	}
+	cb := models.DestinationRule{Metadata: meta_v1.ObjectMeta{Name: "reviews-cb"}}
+	cb.Spec.Host = "reviews"
+	cb.Spec.TrafficPolicy = map[string]interface{}{"connectionPool": map[string]interface{}{}}
+
+	istioCfg := models.IstioConfigList{
+		VirtualServices:  models.VirtualServices{Items: []models.VirtualService{fault, other}},
+		DestinationRules: models.DestinationRules{Items: []models.DestinationRule{cb}},
+	}
+	applyTrafficFaults(trafficMap, "bookinfo", istioCfg)
+	// a second pass, as for another namespace, does not count the faults twice
+	applyTrafficFaults(trafficMap, "bookinfo", istioCfg)
+
+	assert.Nil(healthy.Metadata[graph.TrafficFaults])
+	assert.Equal(graph.TrafficFaultsMetadata{
+		{Kind: graph.TrafficFaultCircuitBreaker, Flags: []string{"UO"}, Rate: 1.0, Percent: 10.0, DestinationRules: []string{"bookinfo/reviews-cb"}},
+		{Kind: graph.TrafficFaultInjection, Flags: []string{"FI"}, Rate: 2.0, Percent: 20.0, VirtualServices: []string{"bookinfo/reviews-fault"}},
+		{Kind: graph.TrafficFaultNoRoute, Flags: []string{"NR"}, Rate: 1.0, Percent: 10.0, VirtualServices: []string{"bookinfo/reviews-fault"}},
+		{Kind: graph.TrafficFaultRetryExhausted, Flags: []string{"URX"}, Rate: 1.0, Percent: 10.0},
+	}, e.Metadata[graph.TrafficFaults])
+}
@@ -0,0 +1,129 @@
+package istio
+
+// Gateway.go generates TrafficMaps rooted at an Istio Gateway. The telemetry of the workloads running the
+// gateway is gathered with the node graph queries, and then decorated with the hosts and routes declared by
+// the VirtualServices bound to the gateway, so routes without traffic and hosts without a backing service
+// can be identified.
+
+import (
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/telemetry"
+	"github.com/kiali/kiali/graph/telemetry/istio/appender"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// BuildGatewayTrafficMap returns the traffic map of the workloads selected by a Gateway, decorated with the
+// routes declared by the VirtualServices bound to it.
+// 以 Gateway 为根节点的流量视图
+func BuildGatewayTrafficMap(o graph.TelemetryOptions, client *prometheus.Client, globalInfo *graph.AppenderGlobalInfo, routes *models.GatewayRoutes) graph.TrafficMap {
+	log.Tracef("Build graph for gateway [%s/%s]", routes.Gateway.Metadata.Namespace, routes.Gateway.Metadata.Name)
+
+	setLabels()
+	appenders := appender.ParseAppenders(o)
+	trafficMap := graph.NewTrafficMap()
+
+	gwNodes := make([]*graph.Node, 0, len(routes.Workloads))
+	for _, w := range routes.Workloads {
+		// gateway workloads usually live in the control plane namespace, which may not be requested
+		if _, found := o.Namespaces[w.Namespace]; !found {
+			o.Namespaces[w.Namespace] = graph.NamespaceInfo{
+				Name:     w.Namespace,
+				Duration: o.Duration,
+				IsIstio:  config.IsIstioNamespace(w.Namespace),
+			}
+		}
+		n := graph.NewNode(w.Namespace, "", w.Namespace, w.Name, w.App, w.Version, o.GraphType)
+		wlTrafficMap := buildNodeTrafficMap(w.Namespace, n, o, client)
+
+		namespaceInfo := graph.NewAppenderNamespaceInfo(w.Namespace)
+		for _, a := range appenders {
+			appenderTimer := internalmetrics.GetGraphAppenderTimePrometheusTimer(a.Name())
+			a.AppendGraph(wlTrafficMap, globalInfo, namespaceInfo)
+			appenderTimer.ObserveDuration()
+		}
+		telemetry.MergeTrafficMaps(trafficMap, w.Namespace, wlTrafficMap)
+
+		gwNode, found := trafficMap[n.ID]
+		if !found {
+			gwNode = &n
+			trafficMap[n.ID] = gwNode
+		}
+		gwNodes = append(gwNodes, gwNode)
+	}
+
+	appendGatewayRoutes(trafficMap, gwNodes, routes, o)
+
+	telemetry.MarkOutsideOrInaccessible(trafficMap, o)
+	telemetry.MarkTrafficGenerators(trafficMap)
+
+	return trafficMap
+}
+
+// appendGatewayRoutes decorates the gateway nodes with the declared hosts, and adds an edge for every route
+// destination. Destinations not reached by any telemetry are flagged as unused routes, and destination hosts
+// not backed by a Service or ServiceEntry are flagged on the destination node.
+func appendGatewayRoutes(trafficMap graph.TrafficMap, gwNodes []*graph.Node, routes *models.GatewayRoutes, o graph.TelemetryOptions) {
+	gwName := routes.Gateway.Metadata.Namespace + "/" + routes.Gateway.Metadata.Name
+	for _, gwNode := range gwNodes {
+		gwNode.Metadata[graph.IsRoot] = true
+		gwNode.Metadata[graph.IsGateway] = gwName
+		gwNode.Metadata[graph.GatewayHosts] = routes.Hosts
+
+		for _, route := range routes.Routes {
+			for _, d := range route.Destinations {
+				edge := findServiceEdge(gwNode, d.Namespace, d.Service)
+				if edge == nil {
+					dest, _ := addNode(trafficMap, d.Namespace, d.Service, "", "", "", "", o)
+					addToDestServices(dest.Metadata, d.Namespace, d.Service)
+					edge = gwNode.AddEdge(dest)
+					edge.Metadata[graph.ProtocolKey] = routeProtocol(route.Protocol)
+					edge.Metadata[graph.IsUnusedRoute] = true
+				}
+				if !d.HasService {
+					if dest, found := trafficMap[edge.Dest.ID]; found {
+						dest.Metadata[graph.HasMissingService] = true
+					}
+				}
+				addGatewayRoute(edge.Metadata, route.Name()+"/"+route.Path)
+			}
+		}
+	}
+}
+
+// findServiceEdge returns the gateway edge reaching the given service, either directly or through its workloads
+func findServiceEdge(gwNode *graph.Node, namespace, service string) *graph.Edge {
+	key := (&graph.ServiceName{Namespace: namespace, Name: service}).Key()
+	for _, e := range gwNode.Edges {
+		if e.Dest.NodeType == graph.NodeTypeService && e.Dest.Namespace == namespace && e.Dest.Service == service {
+			return e
+		}
+		if destServices, ok := e.Dest.Metadata[graph.DestServices].(graph.DestServicesMetadata); ok {
+			if _, found := destServices[key]; found {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+func addGatewayRoute(md graph.Metadata, route string) {
+	routes, _ := md[graph.GatewayRoutes].([]string)
+	for _, r := range routes {
+		if r == route {
+			return
+		}
+	}
+	md[graph.GatewayRoutes] = append(routes, route)
+}
+
+// routeProtocol maps a VirtualService route section to the protocol reported by telemetry
+func routeProtocol(section string) string {
+	if section == "http" {
+		return "http"
+	}
+	return "tcp"
+}
@@ -0,0 +1,83 @@
+package istio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/models"
+)
+
+func TestAppendGatewayRoutes(t *testing.T) {
+	assert := assert.New(t)
+
+	o := graph.TelemetryOptions{InjectServiceNodes: true}
+	o.GraphType = graph.GraphTypeWorkload
+
+	trafficMap := graph.NewTrafficMap()
+	gwNode, _ := addNode(trafficMap, "istio-system", "", "istio-system", "istio-ingressgateway", "istio-ingressgateway", "", o)
+	reviews, _ := addNode(trafficMap, "bookinfo", "reviews", "", "", "", "", o)
+	edge := gwNode.AddEdge(reviews)
+	edge.Metadata[graph.ProtocolKey] = "http"
+	edge.Metadata["http"] = 1.0
+
+	routes := &models.GatewayRoutes{
+		Hosts: []string{"bookinfo.example.com"},
+		Routes: []models.GatewayRoute{
+			{
+				VirtualService: "bookinfo",
+				Namespace:      "bookinfo",
+				Protocol:       "http",
+				Path:           "spec/http[0]",
+				Destinations: []models.GatewayRouteDestination{
+					{Host: "reviews", Service: "reviews", Namespace: "bookinfo", HasService: true},
+				},
+			},
+			{
+				VirtualService: "bookinfo",
+				Namespace:      "bookinfo",
+				Protocol:       "http",
+				Path:           "spec/http[1]",
+				Destinations: []models.GatewayRouteDestination{
+					{Host: "ratings", Service: "ratings", Namespace: "bookinfo", HasService: true},
+				},
+			},
+			{
+				VirtualService: "legacy",
+				Namespace:      "bookinfo",
+				Protocol:       "tcp",
+				Path:           "spec/tcp[0]",
+				Destinations: []models.GatewayRouteDestination{
+					{Host: "mongodb", Service: "mongodb", Namespace: "bookinfo", HasService: false},
+				},
+			},
+		},
+	}
+	routes.Gateway.Metadata = meta_v1.ObjectMeta{Name: "bookinfo-gateway", Namespace: "bookinfo"}
+
+	appendGatewayRoutes(trafficMap, []*graph.Node{gwNode}, routes, o)
+
+	assert.Equal(true, gwNode.Metadata[graph.IsRoot])
+	assert.Equal("bookinfo/bookinfo-gateway", gwNode.Metadata[graph.IsGateway])
+	assert.Equal([]string{"bookinfo.example.com"}, gwNode.Metadata[graph.GatewayHosts])
+	assert.Len(gwNode.Edges, 3)
+
+	// route with traffic reuses the telemetry edge
+	assert.Equal([]string{"bookinfo/bookinfo/spec/http[0]"}, edge.Metadata[graph.GatewayRoutes])
+	assert.Nil(edge.Metadata[graph.IsUnusedRoute])
+
+	// route without traffic is flagged
+	ratings := gwNode.Edges[1]
+	assert.Equal("svc_bookinfo_ratings", ratings.Dest.ID)
+	assert.Equal(true, ratings.Metadata[graph.IsUnusedRoute])
+	assert.Equal("http", ratings.Metadata[graph.ProtocolKey])
+	assert.Nil(trafficMap["svc_bookinfo_ratings"].Metadata[graph.HasMissingService])
+
+	// route to a host with no service is flagged
+	mongodb := gwNode.Edges[2]
+	assert.Equal("tcp", mongodb.Metadata[graph.ProtocolKey])
+	assert.Equal(true, mongodb.Metadata[graph.IsUnusedRoute])
+	assert.Equal(true, trafficMap["svc_bookinfo_mongodb"].Metadata[graph.HasMissingService])
+}
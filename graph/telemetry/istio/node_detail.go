@@ -0,0 +1,73 @@
+package istio
+
+// Node_detail.go generates the TrafficMap used by the tabular node detail. It is the node graph TrafficMap
+// with the edge response times resolved for several quantiles.
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/telemetry/istio/appender"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// DefaultDetailQuantiles are the response time quantiles reported by the node detail
+var DefaultDetailQuantiles = []float64{0.5, 0.95, 0.99}
+
+// BuildNodeDetailTrafficMap returns the node graph TrafficMap, with the edge response times of every quantile
+// stored in the ResponseTimes metadata, keyed by quantile label (i.e. p95).
+// 节点详情表格使用的流量数据, 每条边包含多个分位数的响应时间
+func BuildNodeDetailTrafficMap(o graph.TelemetryOptions, client *prometheus.Client, globalInfo *graph.AppenderGlobalInfo, quantiles []float64) graph.TrafficMap {
+	trafficMap := BuildNodeTrafficMap(o, client, globalInfo)
+	if len(trafficMap) == 0 {
+		return trafficMap
+	}
+
+	// discard a response time resolved by the requested appenders, its quantile is unknown
+	for _, n := range trafficMap {
+		for _, e := range n.Edges {
+			delete(e.Metadata, graph.ResponseTime)
+		}
+	}
+
+	namespaceInfo := graph.NewAppenderNamespaceInfo(o.NodeOptions.Namespace)
+	for _, q := range quantiles {
+		a := appender.ResponseTimeAppender{
+			GraphType:          o.GraphType,
+			InjectServiceNodes: o.InjectServiceNodes,
+			Namespaces:         o.Namespaces,
+			Quantile:           q,
+			QueryTime:          o.QueryTime,
+		}
+		appenderTimer := internalmetrics.GetGraphAppenderTimePrometheusTimer(a.Name())
+		a.AppendGraphNoAuth(trafficMap, globalInfo, namespaceInfo, client)
+		appenderTimer.ObserveDuration()
+
+		label := QuantileLabel(q)
+		for _, n := range trafficMap {
+			for _, e := range n.Edges {
+				val, ok := e.Metadata[graph.ResponseTime]
+				if !ok {
+					continue
+				}
+				responseTimes, ok := e.Metadata[graph.ResponseTimes].(map[string]float64)
+				if !ok {
+					responseTimes = map[string]float64{}
+					e.Metadata[graph.ResponseTimes] = responseTimes
+				}
+				responseTimes[label] = val.(float64)
+				delete(e.Metadata, graph.ResponseTime)
+			}
+		}
+	}
+
+	return trafficMap
+}
+
+// QuantileLabel returns the label of a quantile, i.e. 0.95 -> p95, 0.999 -> p99.9
+func QuantileLabel(quantile float64) string {
+	return fmt.Sprintf("p%s", strconv.FormatFloat(math.Round(quantile*1000)/10, 'f', -1, 64))
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	admission "k8s.io/api/admission/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// AdmissionValidate is a validating admission webhook for the Istio objects. It runs the Kiali checkers on the
+// object being created or updated, and denies it when the validation finds errors.
+// The webhook is called by the API server, so the business layer uses the Kiali service account: it is only served
+// by the admission server, to the clients authenticated by their certificate.
+func AdmissionValidate(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Admission review could not be read: "+err.Error())
+		return
+	}
+	review := admission.AdmissionReview{}
+	if err := json.Unmarshal(body, &review); err != nil || review.Request == nil {
+		RespondWithError(w, http.StatusBadRequest, "Bad admission review")
+		return
+	}
+
+	review.Response = admissionResponse(review.Request)
+	review.Response.UID = review.Request.UID
+	review.Request = nil
+	RespondWithJSON(w, http.StatusOK, review)
+}
+
+func admissionResponse(request *admission.AdmissionRequest) *admission.AdmissionResponse {
+	allowed := &admission.AdmissionResponse{Allowed: true}
+
+	resourceType := request.Resource.Resource
+	if request.Operation != admission.Create && request.Operation != admission.Update || business.GetIstioAPI(resourceType) == "" {
+		return allowed
+	}
+
+	proposed := &kubernetes.GenericIstioObject{}
+	if err := json.Unmarshal(request.Object.Raw, proposed); err != nil {
+		return admissionDenied(fmt.Sprintf("Object could not be read: %v", err))
+	}
+	if proposed.Namespace == "" {
+		proposed.Namespace = request.Namespace
+	}
+	if proposed.Name == "" {
+		proposed.Name = request.Name
+	}
+
+	token, err := kubernetes.GetKialiToken()
+	if err != nil {
+		log.Errorf("Admission review of %s [%s/%s] skipped: %v", resourceType, proposed.Namespace, proposed.Name, err)
+		return allowed
+	}
+	layer, err := business.Get(token)
+	if err != nil {
+		log.Errorf("Admission review of %s [%s/%s] skipped: %v", resourceType, proposed.Namespace, proposed.Name, err)
+		return allowed
+	}
+
+	dryRun, err := layer.Validations.GetDryRunValidations(proposed.Namespace, resourceType, proposed.Name,
+		func(current kubernetes.IstioObject) (kubernetes.IstioObject, error) {
+			return proposed, nil
+		})
+	if err != nil {
+		// Kiali being unable to validate must not block the changes on the cluster
+		log.Errorf("Admission review of %s [%s/%s] skipped: %v", resourceType, proposed.Namespace, proposed.Name, err)
+		return allowed
+	}
+	if dryRun.Validation.Valid {
+		return allowed
+	}
+
+	messages := []string{}
+	for _, check := range dryRun.Validation.Checks {
+		if check.Severity == models.ErrorSeverity {
+			messages = append(messages, check.Message)
+		}
+	}
+	return admissionDenied(strings.Join(messages, "; "))
+}
+
+func admissionDenied(message string) *admission.AdmissionResponse {
+	return &admission.AdmissionResponse{
+		Allowed: false,
+		Result: &meta_v1.Status{
+			Status:  meta_v1.StatusFailure,
+			Reason:  meta_v1.StatusReasonInvalid,
+			Message: message,
+			Code:    http.StatusUnprocessableEntity,
+		},
+	}
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/business/audit"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// AuditEvents is the API handler returning the audit events of the write operations on the namespaces accessible
+// to the user, the most recent first
+func AuditEvents(w http.ResponseWriter, r *http.Request) {
+	auditEvents(w, r, func() (*business.Layer, error) { return getBusiness(r) })
+}
+
+//AuditEventsController
+// audit?since=1600000000&user=admin&limit=100
+// @ID AuditEvents
+// @Summary audit-events
+// @Description 查询 Istio, 3scale 和 iter8 对象的创建, 修改和删除的审计事件, 最新的在前
+// @Tags audit
+// @Param since query integer false "unix 时间, 默认一天前"
+// @Param user query string false "用户"
+// @Param namespaces query string false "逗号分隔的命名空间, 默认所有命名空间"
+// @Param limit query integer false "最多返回的事件数量, 默认 100"
+// @Success 200 {array} models.AuditEvent
+// @Failure 400 {object} responseError
+// @Failure 503 {object} responseError
+// @Router /audit [get]
+func (g *GraphController) AuditEventsController(w http.ResponseWriter, r *http.Request) {
+	auditEvents(w, r, g.getBusiness)
+}
+
+func auditEvents(w http.ResponseWriter, r *http.Request, getLayer func() (*business.Layer, error)) {
+	queryParams := r.URL.Query()
+
+	since, err := extractSince(queryParams)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	q := audit.Query{Since: since, User: queryParams.Get("user"), Limit: 100}
+	if limit := queryParams.Get("limit"); limit != "" {
+		if q.Limit, err = strconv.Atoi(limit); err != nil || q.Limit < 0 {
+			RespondWithError(w, http.StatusBadRequest, "bad request, cannot parse query parameter 'limit'")
+			return
+		}
+	}
+
+	business, err := getLayer()
+	if err != nil {
+		log.Error(err)
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// only the events of the namespaces the user has access to are returned
+	namespaces, err := business.Namespace.GetNamespaces()
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	requested := map[string]bool{}
+	if nss := queryParams.Get("namespaces"); nss != "" {
+		for _, ns := range strings.Split(nss, ",") {
+			requested[ns] = true
+		}
+	}
+	q.Namespaces = map[string]bool{}
+	for _, ns := range namespaces {
+		if len(requested) == 0 || requested[ns.Name] {
+			q.Namespaces[ns.Name] = true
+		}
+	}
+
+	events, err := audit.Events(q)
+	if err != nil {
+		if err == audit.ErrAuditFileDisabled {
+			RespondWithError(w, http.StatusServiceUnavailable, err.Error())
+			return
+		}
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, events)
+}
+
+// auditChange records the audit event of a write operation, completed with the user and the client of the request
+// and the result of the operation
+func auditChange(r *http.Request, event models.AuditEvent, err error) {
+	event.Time = time.Now()
+	event.User = r.Header.Get("Kiali-User")
+	event.Strategy = config.Get().Auth.Strategy
+	event.SourceIP = sourceIP(r)
+	event.ForwardedFor = r.Header.Get("X-Forwarded-For")
+	event.Result = models.AuditSuccess
+	if err != nil {
+		event.Result = models.AuditFailure
+		event.Error = err.Error()
+	}
+	audit.Record(event)
+}
+
+// auditSecrets are the fields of the changes not written in the audit events
+var auditSecrets = map[string]bool{"accessToken": true, "password": true, "token": true}
+
+// auditDiff returns the change of an object as JSON, with the secrets obfuscated. The content not being JSON is
+// kept as a string.
+func auditDiff(content []byte) json.RawMessage {
+	if len(content) == 0 {
+		return nil
+	}
+	var diff interface{}
+	if err := json.Unmarshal(content, &diff); err != nil {
+		quoted, _ := json.Marshal(string(content))
+		return quoted
+	}
+	obfuscated, _ := json.Marshal(obfuscateSecrets(diff))
+	return obfuscated
+}
+
+func obfuscateSecrets(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if auditSecrets[key] {
+				v[key] = "xxx"
+			} else {
+				v[key] = obfuscateSecrets(field)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = obfuscateSecrets(v[i])
+		}
+	}
+	return value
+}
+
+// auditName returns the name of a created object, from its metadata or from the name field of the Kiali models,
+// the serviceName for the 3scale rules
+func auditName(content []byte) string {
+	object := struct {
+		Name        string `json:"name"`
+		ServiceName string `json:"serviceName"`
+		Metadata    struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(content, &object); err != nil {
+		return ""
+	}
+	if object.Metadata.Name != "" {
+		return object.Metadata.Name
+	}
+	if object.Name != "" {
+		return object.Name
+	}
+	return object.ServiceName
+}
+
+// sourceIP returns the address of the client connected to Kiali. X-Forwarded-For is not used as any client can set it.
+func sourceIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditDiff(t *testing.T) {
+	assert := assert.New(t)
+
+	diff := auditDiff([]byte(`{"name": "threescale", "serviceId": "123",
+		"accessToken": "secret", "nested": [{"password": "secret"}]}`))
+	assert.JSONEq(`{"name":"threescale","serviceId":"123","accessToken":"xxx","nested":[{"password":"xxx"}]}`, string(diff))
+	assert.Equal(`"not json"`, string(auditDiff([]byte("not json"))))
+	assert.Nil(auditDiff(nil))
+
+	assert.Equal("reviews", auditName([]byte(`{"metadata":{"name":"reviews"},"spec":{}}`)))
+	assert.Equal("threescale", auditName([]byte(`{"name":"threescale"}`)))
+	assert.Equal("reviews", auditName([]byte(`{"serviceName":"reviews","serviceNamespace":"bookinfo"}`)))
+}
+
+func TestAuditSourceIP(t *testing.T) {
+	assert := assert.New(t)
+
+	r := httptest.NewRequest("DELETE", "/api/namespaces/bookinfo/istio/virtualservices/reviews", nil)
+	r.RemoteAddr = "10.0.0.1:53211"
+	assert.Equal("10.0.0.1", sourceIP(r))
+	r.Header.Set("X-Forwarded-For", "192.168.1.20, 10.0.0.2")
+	assert.Equal("10.0.0.1", sourceIP(r))
+}
@@ -299,7 +299,7 @@ func checkOpenshiftSession(w http.ResponseWriter, r *http.Request) (int, string)
 		_, err = business.OpenshiftOAuth.GetUserInfo(claims.SessionId)
 		if err == nil {
 			// Internal header used to propagate the subject of the request for audit purposes
-			r.Header.Add("Kiali-User", claims.Subject)
+			r.Header.Set("Kiali-User", claims.Subject)
 			return http.StatusOK, claims.SessionId
 		}
 
@@ -390,7 +390,7 @@ func checkKialiSession(w http.ResponseWriter, r *http.Request) int {
 		}
 
 		// Internal header used to propagate the subject of the request for audit purposes
-		r.Header.Add("Kiali-User", user)
+		r.Header.Set("Kiali-User", user)
 	} else {
 		user := checkKialiCredentials(r)
 		if len(user) == 0 {
@@ -404,7 +404,7 @@ func checkKialiSession(w http.ResponseWriter, r *http.Request) int {
 		}
 
 		// Internal header used to propagate the subject of the request for audit purposes
-		r.Header.Add("Kiali-User", user)
+		r.Header.Set("Kiali-User", user)
 	}
 
 	return http.StatusOK
@@ -420,7 +420,7 @@ func checkLDAPSession(w http.ResponseWriter, r *http.Request) (int, string) {
 			return http.StatusUnauthorized, ""
 		}
 		// Internal header used to propagate the subject of the request for audit purposes
-		r.Header.Add("Kiali-User", user.Status.User.Username)
+		r.Header.Set("Kiali-User", user.Status.User.Username)
 		return http.StatusOK, token
 	}
 	return http.StatusUnauthorized, ""
@@ -446,7 +446,7 @@ func checkTokenSession(w http.ResponseWriter, r *http.Request) (int, string) {
 		_, err = business.Namespace.GetNamespaces()
 		if err == nil {
 			// Internal header used to propagate the subject of the request for audit purposes
-			r.Header.Add("Kiali-User", claims.Subject)
+			r.Header.Set("Kiali-User", claims.Subject)
 			return http.StatusOK, claims.SessionId
 		}
 
@@ -479,6 +479,8 @@ func (aHandler AuthenticationHandler) Handle(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		statusCode := http.StatusOK
 		conf := config.Get()
+		// Kiali-User is only set by the session checks, never taken from the client
+		r.Header.Del("Kiali-User")
 
 		var token string
 
@@ -518,6 +520,7 @@ func (aHandler AuthenticationHandler) Handle(next http.Handler) http.Handler {
 
 func (aHandler AuthenticationHandler) HandleUnauthenticated(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Del("Kiali-User")
 		context := context.WithValue(r.Context(), "token", "")
 		next.ServeHTTP(w, r.WithContext(context))
 	})
@@ -671,3 +671,32 @@ func TestMissingSecretFlagAbsent(t *testing.T) {
 
 	assert.NotContains(t, reply, "secretMissing")
 }
+
+// TestKialiUserHeaderNotSpoofed checks that the Kiali-User header sent by the client is replaced by the
+// authenticated user, or removed when there is none
+func TestKialiUserHeaderNotSpoofed(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Auth.Strategy = config.AuthStrategyLogin
+	cfg.LoginToken.SigningKey = util.RandomString(10)
+	cfg.Server.Credentials.Username = "foo"
+	cfg.Server.Credentials.Passphrase = "bar"
+	config.Set(cfg)
+
+	var users []string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		users = r.Header["Kiali-User"]
+	})
+
+	request := httptest.NewRequest("POST", "http://kiali/api/namespaces/bookinfo/istio/virtualservices", nil)
+	request.SetBasicAuth("foo", "bar")
+	request.Header.Set("Kiali-User", "admin")
+	AuthenticationHandler{}.Handle(next).ServeHTTP(httptest.NewRecorder(), request)
+	assert.Equal(t, []string{"foo"}, users)
+
+	cfg.Auth.Strategy = config.AuthStrategyAnonymous
+	config.Set(cfg)
+	request = httptest.NewRequest("POST", "http://kiali/api/namespaces/bookinfo/istio/virtualservices", nil)
+	request.Header.Set("Kiali-User", "admin")
+	AuthenticationHandler{}.Handle(next).ServeHTTP(httptest.NewRecorder(), request)
+	assert.Empty(t, users)
+}
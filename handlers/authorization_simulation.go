@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kiali/kiali/models"
+)
+
+// WorkloadAuthorizationSimulation is the API handler evaluating a request to a workload against the
+// AuthorizationPolicies applying to it, to test the policies before rolling them out
+func WorkloadAuthorizationSimulation(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Authorization simulation could not be read: "+err.Error())
+		return
+	}
+	simulation := models.AuthorizationSimulation{}
+	if err := json.Unmarshal(body, &simulation); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Bad authorization simulation: "+err.Error())
+		return
+	}
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	result, err := business.IstioConfig.SimulateAuthorization(params["namespace"], params["workload"], simulation)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, result)
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/graph"
 	"github.com/kiali/kiali/graph/api"
+	"github.com/kiali/kiali/graph/config/table"
+	"github.com/kiali/kiali/graph/telemetry/istio/appender"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/util"
 	"github.com/opentracing/opentracing-go"
@@ -13,6 +16,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"net/http"
+	"strings"
 )
 
 type GraphController struct {
@@ -31,17 +35,27 @@ func NewGraphController(config *rest.Config, client kubernetes.Interface, promet
 	}
 }
 
+// getBusiness returns the business layer of the controllers, built from the client configuration as the router
+// serving them has no authentication
+func (g *GraphController) getBusiness() (*business.Layer, error) {
+	return GetBusinessNoAuth(g.Config, g.PrometheusURL, nil)
+}
+
 type Graph struct {
 	Namespace string `json:"namespace" default:"default"`
 	// 集群名称
 	DeadEdges     bool   `json:"deadEdges" default:"false"`
 	PrometheusUrl string `json:"prometheusUrl"`
 	Service       string `json:"service"`
+	Gateway       string `json:"gateway"`
+	Workload      string `json:"workload"`
 	App           string `json:"app"`
 	Version       string `json:"version"`
 	GraphType     string `json:"graphType"`
 	PassThrough   bool   `json:"passThrough" default:"true"`
 	Duration      string `json:"duration" default:"60s"`
+	// 是否按外部 host 拆分 PassthroughCluster 和 BlackHoleCluster 的流量
+	Egress bool `json:"egress" default:"false"`
 }
 
 type NamespacesRequest struct {
@@ -59,9 +73,11 @@ type NamespacesRequest struct {
 // @Param cluster body NamespacesRequest true "集群信息"
 // @Param deadEdges path boolean false "是否去掉没有流量的线"
 // @Param passThrough path boolean false "是否需要加多集群的线"
+// @Param egress path boolean false "是否按外部 host 拆分出口流量"
 // @Success 200 {object} GraphNamespacesResponse
 // @Failure 500 {object} responseError
 // @Router /graph/namespace/{namespace}/duration/{duration}/deadEdges/{deadEdges}/passThrough/{passThrough}/graphType/{graphType} [post]
+// @Router /graph/namespace/{namespace}/duration/{duration}/deadEdges/{deadEdges}/passThrough/{passThrough}/graphType/{graphType}/egress/{egress} [post]
 func (g *GraphController) GetNamespacesController(w http.ResponseWriter, r *http.Request) {
 	request := NamespacesRequest{}
 	s, _ := ioutil.ReadAll(r.Body)
@@ -92,7 +108,8 @@ func (g *GraphController) GetNamespaces(graphs *Graph, clusters map[string]strin
 	defer graphSpan.Finish()
 	optionSpan := opentracing.StartSpan("namespace-options", opentracing.ChildOf(graphSpan.Context()))
 	option := graph.NewSimpleOption(graphs.Namespace, g.Context, g.PrometheusURL,
-		clusters, g.Config).SetDeadEdges(graphs.DeadEdges).SetPassThrough(graphs.PassThrough).SetDuration(graphs.Duration).SetGraphType(graphs.GraphType)
+		clusters, g.Config).SetDeadEdges(graphs.DeadEdges).SetPassThrough(graphs.PassThrough).SetDuration(graphs.Duration).SetGraphType(graphs.GraphType).
+		SetEgress(graphs.Egress)
 	clusterCha := make(map[string]interface{}, 0)
 	log.Infof("cluster start ")
 	graphApi, err := api.NewGraphApi(option, optionSpan)
@@ -126,7 +143,8 @@ func (g *GraphController) GetNode(graphs *Graph, clusters map[string]string) (gr
 		clusters, g.Config).SetDeadEdges(graphs.DeadEdges).
 		SetService(graphs.Service).
 		SetNamespace(graphs.Namespace).
-		SetPassThrough(graphs.PassThrough).SetDuration(graphs.Duration)
+		SetPassThrough(graphs.PassThrough).SetDuration(graphs.Duration).
+		SetEgress(graphs.Egress)
 	clusterCha := make(map[string]interface{}, 0)
 	log.Infof("cluster start ")
 	graphApi, err := api.NewGraphApi(option, optionSpan)
@@ -160,9 +178,11 @@ func (g *GraphController) GetNode(graphs *Graph, clusters map[string]string) (gr
 // @Param service path string true "service 名称"
 // @Param deadEdges path boolean false "是否去掉没有流量的线"
 // @Param passThrough path boolean false "是否需要加多集群的线"
+// @Param egress path boolean false "是否按外部 host 拆分出口流量"
 // @Success 200 {object} GraphNamespacesResponse
 // @Failure 500 {object} responseError
 // @Router /graph/namespace/{namespace}/service/{service}/duration/{duration}/deadEdges/{deadEdges}/passThrough/{passThrough} [post]
+// @Router /graph/namespace/{namespace}/service/{service}/duration/{duration}/deadEdges/{deadEdges}/passThrough/{passThrough}/egress/{egress} [post]
 func (g *GraphController) GetNodeController(w http.ResponseWriter, r *http.Request) {
 	request := NamespacesRequest{}
 	s, _ := ioutil.ReadAll(r.Body)
@@ -186,3 +206,114 @@ func (g *GraphController) GetNodeController(w http.ResponseWriter, r *http.Reque
 	}
 	RespondWithJSON(w, 200, graphName)
 }
+
+func (g *GraphController) GetGateway(graphs *Graph) (graphName GraphName, err error) {
+	ctx := context.TODO()
+	graphSpan, ctx := opentracing.StartSpanFromContext(ctx, fmt.Sprintf("GetGatewayGraph"))
+	defer graphSpan.Finish()
+	optionSpan := opentracing.StartSpan("gateway-options", opentracing.ChildOf(graphSpan.Context()))
+	option := graph.NewSimpleOption(graphs.Namespace, g.Context, g.PrometheusURL,
+		nil, g.Config).SetDeadEdges(graphs.DeadEdges).
+		SetGateway(graphs.Gateway).
+		SetNamespace(graphs.Namespace).
+		SetPassThrough(false).SetDuration(graphs.Duration)
+	clusterCha := make(map[string]interface{}, 0)
+	graphApi, err := api.NewGraphApi(option, optionSpan)
+	if err != nil {
+		return
+	}
+	err = graphApi.GatewayRegistryHandle(optionSpan, clusterCha)
+	if err != nil {
+		return
+	}
+	graphName = GraphName{
+		Cluster: clusterCha[g.Context],
+	}
+	return
+}
+
+//GetGatewayController
+// graph/namespace/istio-system/gateway/bookinfo-gateway/duration/60s/deadEdges/false
+// @ID GetGateway
+// @Summary graph-Gateway
+// @Description 通过 gateway 来查询流量视图, 包括绑定的 VirtualService 的 host 和路由
+// @Accept  json
+// @Tags graph
+// @Param namespace path string true "gateway 所在的命名空间"
+// @Param gateway path string true "gateway 名称"
+// @Param duration path string true "时长"
+// @Param deadEdges path boolean false "是否去掉没有流量的线"
+// @Success 200 {object} GraphNamespacesResponse
+// @Failure 500 {object} responseError
+// @Router /graph/namespace/{namespace}/gateway/{gateway}/duration/{duration}/deadEdges/{deadEdges} [get]
+func (g *GraphController) GetGatewayController(w http.ResponseWriter, r *http.Request) {
+	url := r.RequestURI
+	url = url[7:]
+	graphs := &Graph{}
+	err := util.Parse(url, graphs)
+	if err != nil {
+		RespondWithError(w, 500, err.Error())
+		return
+	}
+	graphName, err := g.GetGateway(graphs)
+	if err != nil {
+		RespondWithError(w, 500, err.Error())
+		return
+	}
+	RespondWithJSON(w, 200, graphName)
+}
+
+func (g *GraphController) GetNodeDetail(graphs *Graph) (detail table.NodeDetail, err error) {
+	ctx := context.TODO()
+	graphSpan, ctx := opentracing.StartSpanFromContext(ctx, fmt.Sprintf("GetNodeDetail"))
+	defer graphSpan.Finish()
+	optionSpan := opentracing.StartSpan("node-detail-options", opentracing.ChildOf(graphSpan.Context()))
+	// service 节点需要插入 service 节点, app 和 workload 节点直接看 workload 之间的流量
+	option := graph.NewSimpleOption(graphs.Namespace, g.Context, g.PrometheusURL,
+		nil, g.Config).SetAppenders(appender.SecurityPolicyAppenderName).
+		SetInjectServiceNodes(graphs.Service != "").
+		SetService(graphs.Service).
+		SetWorkload(graphs.Workload).
+		SetApp(graphs.App, graphs.Version).
+		SetPassThrough(false).SetDuration(graphs.Duration)
+	graphApi, err := api.NewGraphApi(option, optionSpan)
+	if err != nil {
+		return
+	}
+	return graphApi.NodeDetailHandle(optionSpan)
+}
+
+//GetNodeDetailController
+// graph/detail/namespace/bookinfo/service/reviews/duration/60s
+// @ID GetNodeDetail
+// @Summary graph-node-detail
+// @Description 查询 app/workload/service 的上下游流量表格, 包括请求速率, 错误率, 响应时间, 协议, mTLS 比例, 主要的响应码和响应标志
+// @Accept  json
+// @Tags graph
+// @Param namespace path string true "命名空间"
+// @Param service path string false "服务名称"
+// @Param workload path string false "workload 名称"
+// @Param app path string false "app 名称"
+// @Param version path string false "app 版本"
+// @Param duration path string true "时长"
+// @Success 200 {object} table.NodeDetail
+// @Failure 500 {object} responseError
+// @Router /graph/detail/namespace/{namespace}/service/{service}/duration/{duration} [get]
+// @Router /graph/detail/namespace/{namespace}/workload/{workload}/duration/{duration} [get]
+// @Router /graph/detail/namespace/{namespace}/app/{app}/duration/{duration} [get]
+// @Router /graph/detail/namespace/{namespace}/app/{app}/version/{version}/duration/{duration} [get]
+func (g *GraphController) GetNodeDetailController(w http.ResponseWriter, r *http.Request) {
+	url := strings.TrimPrefix(r.RequestURI, "/graph/detail/")
+	graphs := &Graph{}
+	err := util.Parse(url, graphs)
+	if err != nil {
+		RespondWithError(w, 500, err.Error())
+		return
+	}
+	detail, err := g.GetNodeDetail(graphs)
+	if err != nil {
+		RespondWithError(w, 500, err.Error())
+		return
+	}
+	RespondWithJSON(w, 200, detail)
+}
@@ -9,8 +9,6 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/kiali/kiali/business"
-	"github.com/kiali/kiali/config"
-	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/models"
 )
 
@@ -92,6 +90,7 @@ func parseCriteria(namespace string, objects string) business.IstioConfigCriteri
 	criteria.IncludeGateways = defaultInclude
 	criteria.IncludeVirtualServices = defaultInclude
 	criteria.IncludeDestinationRules = defaultInclude
+	criteria.IncludeEnvoyFilters = defaultInclude
 	criteria.IncludeServiceEntries = defaultInclude
 	criteria.IncludeRules = defaultInclude
 	criteria.IncludeAdapters = defaultInclude
@@ -126,6 +125,9 @@ func parseCriteria(namespace string, objects string) business.IstioConfigCriteri
 	if checkType(types, business.DestinationRules) {
 		criteria.IncludeDestinationRules = true
 	}
+	if checkType(types, business.EnvoyFilters) {
+		criteria.IncludeEnvoyFilters = true
+	}
 	if checkType(types, business.ServiceEntries) {
 		criteria.IncludeServiceEntries = true
 	}
@@ -265,11 +267,11 @@ func IstioConfigDelete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	err = business.IstioConfig.DeleteIstioConfigDetail(api, namespace, objectType, objectSubtype, object)
+	auditChange(r, models.AuditEvent{Operation: models.AuditDelete, Namespace: namespace, ObjectType: objectType, ObjectSubtype: objectSubtype, Name: object}, err)
 	if err != nil {
 		handleErrorResponse(w, err)
 		return
 	} else {
-		audit(r, "DELETE on Namespace: "+namespace+" Type: "+objectType+" Subtype: "+objectSubtype+" Name: "+object)
 		RespondWithCode(w, http.StatusOK)
 	}
 }
@@ -299,14 +301,25 @@ func IstioConfigUpdate(w http.ResponseWriter, r *http.Request) {
 		RespondWithError(w, http.StatusBadRequest, "Update request with bad update patch: "+err.Error())
 	}
 	jsonPatch := string(body)
+
+	if r.URL.Query().Get("dryRun") == "true" {
+		dryRun, err := business.IstioConfig.DryRunUpdateIstioConfigDetail(namespace, objectType, object, jsonPatch)
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+		RespondWithJSON(w, http.StatusOK, dryRun)
+		return
+	}
+
 	updatedConfigDetails, err := business.IstioConfig.UpdateIstioConfigDetail(api, namespace, objectType, objectSubtype, object, jsonPatch)
+	auditChange(r, models.AuditEvent{Operation: models.AuditUpdate, Namespace: namespace, ObjectType: objectType, ObjectSubtype: objectSubtype, Name: object, Diff: auditDiff(body)}, err)
 
 	if err != nil {
 		handleErrorResponse(w, err)
 		return
 	}
 
-	audit(r, "UPDATE on Namespace: "+namespace+" Type: "+objectType+" Subtype: "+objectSubtype+" Name: "+object+" Patch: "+jsonPatch)
 	RespondWithJSON(w, http.StatusOK, updatedConfigDetails)
 }
 
@@ -335,13 +348,23 @@ func IstioConfigCreate(w http.ResponseWriter, r *http.Request) {
 		RespondWithError(w, http.StatusBadRequest, "Create request could not be read: "+err.Error())
 	}
 
+	if r.URL.Query().Get("dryRun") == "true" {
+		dryRun, err := business.IstioConfig.DryRunCreateIstioConfigDetail(namespace, objectType, objectSubtype, body)
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+		RespondWithJSON(w, http.StatusOK, dryRun)
+		return
+	}
+
 	createdConfigDetails, err := business.IstioConfig.CreateIstioConfigDetail(api, namespace, objectType, objectSubtype, body)
+	auditChange(r, models.AuditEvent{Operation: models.AuditCreate, Namespace: namespace, ObjectType: objectType, ObjectSubtype: objectSubtype, Name: auditName(body), Diff: auditDiff(body)}, err)
 	if err != nil {
 		handleErrorResponse(w, err)
 		return
 	}
 
-	audit(r, "CREATE on Namespace: "+namespace+" Type: "+objectType+" Subtype: "+objectSubtype+" Object: "+string(body))
 	RespondWithJSON(w, http.StatusOK, createdConfigDetails)
 }
 
@@ -349,13 +372,6 @@ func checkObjectType(objectType string) bool {
 	return business.GetIstioAPI(objectType) != ""
 }
 
-func audit(r *http.Request, message string) {
-	if config.Get().Server.AuditLog {
-		user := r.Header.Get("Kiali-User")
-		log.Infof("AUDIT User [%s] Msg [%s]", user, message)
-	}
-}
-
 func IstioConfigPermissions(w http.ResponseWriter, r *http.Request) {
 	// query params
 	params := r.URL.Query()
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// IstioConfigExport is the API handler returning the Istio objects of namespaces as a multi-document YAML bundle
+func IstioConfigExport(w http.ResponseWriter, r *http.Request) {
+	nss := r.URL.Query().Get("namespaces")
+	if nss == "" {
+		RespondWithError(w, http.StatusBadRequest, "bad request, query parameter 'namespaces' is required")
+		return
+	}
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	bundle, err := business.IstioConfig.ExportIstioConfig(strings.Split(nss, ","))
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-yaml")
+	w.Header().Set("Content-Disposition", "attachment; filename=istio-config.yaml")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(bundle); err != nil {
+		log.Errorf("Error writing the Istio config bundle: %v", err)
+	}
+}
+
+// IstioConfigImport is the API handler validating and applying a multi-document YAML bundle of Istio objects,
+// optionally into the namespace given by the namespace query parameter. With dryRun=true it is only validated.
+func IstioConfigImport(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	dryRun := queryParams.Get("dryRun") == "true"
+
+	bundle, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Import request could not be read: "+err.Error())
+		return
+	}
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	imported, err := business.IstioConfig.ImportIstioConfig(bundle, queryParams.Get("namespace"), dryRun)
+	if err != nil {
+		if errors.IsBadRequest(err) {
+			RespondWithError(w, http.StatusBadRequest, err.Error())
+		} else {
+			handleErrorResponse(w, err)
+		}
+		return
+	}
+	if !dryRun && !imported.Applied {
+		// the bundle has validation errors
+		RespondWithJSON(w, http.StatusUnprocessableEntity, imported)
+		return
+	}
+	for _, o := range imported.Objects {
+		if !dryRun && (o.Applied || o.Error != "") {
+			operation := models.AuditCreate
+			if o.Action == models.BundleUpdate {
+				operation = models.AuditUpdate
+			}
+			var applyErr error
+			if o.Error != "" {
+				applyErr = fmt.Errorf("%s", o.Error)
+			}
+			auditChange(r, models.AuditEvent{Operation: operation, Namespace: o.Namespace, ObjectType: o.ObjectType, Name: o.Name}, applyErr)
+		}
+	}
+	RespondWithJSON(w, http.StatusOK, imported)
+}
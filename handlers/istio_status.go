@@ -2,6 +2,10 @@ package handlers
 
 import (
 	"net/http"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/kiali/kiali/util"
 )
 
 // IstioStatus returns a list of istio components and its status
@@ -21,3 +25,33 @@ func IstioStatus(w http.ResponseWriter, r *http.Request) {
 
 	RespondWithJSON(w, http.StatusOK, istioStatus)
 }
+
+// IstioControlPlaneStatus returns the health of each revision of the control plane, including the istiod metrics
+func IstioControlPlaneStatus(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	rateInterval := defaultHealthRateInterval
+	if _, found := queryParams["rateInterval"]; found {
+		rateInterval = queryParams.Get("rateInterval")
+	}
+	// the interval is written in the Prometheus queries
+	if _, err := model.ParseDuration(rateInterval); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "bad request, cannot parse query parameter 'rateInterval'")
+		return
+	}
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	status, err := business.IstioStatus.GetControlPlaneStatus(rateInterval, util.Clock.Now())
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, status)
+}
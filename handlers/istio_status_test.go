@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIstioControlPlaneStatusBadRateInterval(t *testing.T) {
+	r := httptest.NewRequest("GET", "/api/istio/status/controlplane?rateInterval=5m%5D", nil)
+	w := httptest.NewRecorder()
+
+	IstioControlPlaneStatus(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
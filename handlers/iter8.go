@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/gorilla/mux"
+
+	"github.com/kiali/kiali/models"
 )
 
 func Iter8Status(w http.ResponseWriter, r *http.Request) {
@@ -66,6 +68,7 @@ func Iter8ExperimentCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	experiment, err := business.Iter8.CreateIter8Experiment(namespace, body)
+	auditChange(r, models.AuditEvent{Operation: models.AuditCreate, Namespace: namespace, ObjectType: "experiments", Name: auditName(body), Diff: auditDiff(body)}, err)
 	if err != nil {
 		handleErrorResponse(w, err)
 		return
@@ -101,6 +104,7 @@ func Iter8ExperimentDelete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	err = business.Iter8.DeleteIter8Experiment(namespace, name)
+	auditChange(r, models.AuditEvent{Operation: models.AuditDelete, Namespace: namespace, ObjectType: "experiments", Name: name}, err)
 	if err != nil {
 		handleErrorResponse(w, err)
 		return
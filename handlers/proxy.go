@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kiali/kiali/business"
+)
+
+// defaultCertExpiringWithin is the remaining validity under which a certificate is flagged as expiring soon.
+// Istio rotates the workload certificates, 24h long by default, when half of their validity is left.
+const defaultCertExpiringWithin = time.Hour
+
+// PodProxyCertificates is the API to get the certificates loaded by the proxy of a pod
+func PodProxyCertificates(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	// Get business layer
+	layer, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Proxy initialization error: "+err.Error())
+		return
+	}
+
+	certs, err := layer.Proxy.GetProxyCertificates(params["namespace"], params["pod"])
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, certs)
+}
+
+// NamespaceProxyCertificates is the API to get the certificate status of the proxies of a namespace
+func NamespaceProxyCertificates(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	queryParams := r.URL.Query()
+
+	// Get business layer
+	layer, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Proxy initialization error: "+err.Error())
+		return
+	}
+
+	expiringWithin := defaultCertExpiringWithin
+	if value := queryParams.Get("expiringWithin"); value != "" {
+		if expiringWithin, err = time.ParseDuration(value); err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Invalid expiringWithin: "+err.Error())
+			return
+		}
+	}
+
+	certs, err := layer.Proxy.GetNamespaceCertificates(params["namespace"], expiringWithin)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, certs)
+}
+
+// NamespaceProxyStatus is the API to get the sync status of the proxies of a namespace with istiod
+func NamespaceProxyStatus(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	// Get business layer
+	layer, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Proxy initialization error: "+err.Error())
+		return
+	}
+
+	status, err := layer.Proxy.GetNamespaceProxyStatus(params["namespace"])
+	if err != nil {
+		if err == business.ErrProxyStatusDisabled {
+			RespondWithError(w, http.StatusServiceUnavailable, err.Error())
+			return
+		}
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, status)
+}
+
+// PodProxyConfigDump is the API to get the raw config_dump of the proxy of a pod
+func PodProxyConfigDump(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	// Get business layer
+	layer, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Proxy initialization error: "+err.Error())
+		return
+	}
+
+	dump, err := layer.Proxy.GetProxyConfigDump(params["namespace"], params["pod"])
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, dump)
+}
+
+// PodProxyConfig is the API to get a summary of the listeners, routes, clusters or endpoints of the proxy of a pod
+func PodProxyConfig(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	queryParams := r.URL.Query()
+
+	// Get business layer
+	layer, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Proxy initialization error: "+err.Error())
+		return
+	}
+
+	filter := business.ProxyConfigFilter{
+		Direction:      queryParams.Get("direction"),
+		FQDN:           queryParams.Get("fqdn"),
+		VirtualService: queryParams.Get("virtualService"),
+		Cluster:        queryParams.Get("cluster"),
+	}
+	if port := queryParams.Get("port"); port != "" {
+		value, err := strconv.ParseUint(port, 10, 32)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Invalid port: "+err.Error())
+			return
+		}
+		filter.Port = uint32(value)
+	}
+
+	namespace, pod := params["namespace"], params["pod"]
+	var result interface{}
+	switch params["resource"] {
+	case "listeners":
+		result, err = layer.Proxy.GetProxyListeners(namespace, pod, filter)
+	case "routes":
+		result, err = layer.Proxy.GetProxyRoutes(namespace, pod, filter)
+	case "clusters":
+		result, err = layer.Proxy.GetProxyClusters(namespace, pod, filter)
+	case "endpoints":
+		result, err = layer.Proxy.GetProxyEndpoints(namespace, pod, filter)
+	default:
+		RespondWithError(w, http.StatusBadRequest, "Invalid resource: "+params["resource"]+". Expected listeners, routes, clusters or endpoints")
+		return
+	}
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, result)
+}
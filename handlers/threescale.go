@@ -59,6 +59,7 @@ func ThreeScaleHandlersCreate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	threeScaleHandlers, err := business.ThreeScale.CreateThreeScaleHandler(body)
+	auditChange(r, models.AuditEvent{Operation: models.AuditCreate, Namespace: config.Get().IstioNamespace, ObjectType: "threescalehandlers", Name: auditName(body), Diff: auditDiff(body)}, err)
 	if err != nil {
 		if err.Error() == models.BadThreeScaleHandlerJson {
 			RespondWithError(w, http.StatusBadRequest, err.Error())
@@ -67,9 +68,6 @@ func ThreeScaleHandlersCreate(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
-	conf := config.Get()
-	audit(r, "CREATE on Namespace: "+conf.IstioNamespace+" ThreeScale Adapter. Json: "+string(body))
-
 	RespondWithJSON(w, http.StatusOK, threeScaleHandlers)
 }
 
@@ -89,13 +87,11 @@ func ThreeScaleHandlersUpdate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	threeScaleHandlers, err := business.ThreeScale.UpdateThreeScaleHandler(threescaleHandlerName, body)
+	auditChange(r, models.AuditEvent{Operation: models.AuditUpdate, Namespace: config.Get().IstioNamespace, ObjectType: "threescalehandlers", Name: threescaleHandlerName, Diff: auditDiff(body)}, err)
 	if err != nil {
 		handleErrorResponse(w, err)
 		return
 	}
-	conf := config.Get()
-	audit(r, "UPDATE on Namespace: "+conf.IstioNamespace+" ThreeScale Adapter Name: "+threescaleHandlerName)
-
 	RespondWithJSON(w, http.StatusOK, threeScaleHandlers)
 }
 
@@ -110,13 +106,11 @@ func ThreeScaleHandlersDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	threeScaleHandlers, err := business.ThreeScale.DeleteThreeScaleHandler(threescaleHandlerName)
+	auditChange(r, models.AuditEvent{Operation: models.AuditDelete, Namespace: config.Get().IstioNamespace, ObjectType: "threescalehandlers", Name: threescaleHandlerName}, err)
 	if err != nil {
 		handleErrorResponse(w, err)
 		return
 	}
-	conf := config.Get()
-	audit(r, "DELETE on Namespace: "+conf.IstioNamespace+" ThreeScale Adapter Name: "+threescaleHandlerName)
-
 	RespondWithJSON(w, http.StatusOK, threeScaleHandlers)
 }
 
@@ -162,6 +156,7 @@ func ThreeScaleServiceRuleCreate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	threeScaleRule, err := business.ThreeScale.CreateThreeScaleRule(namespace, body)
+	auditChange(r, models.AuditEvent{Operation: models.AuditCreate, Namespace: namespace, ObjectType: "threescalerules", Name: auditName(body), Diff: auditDiff(body)}, err)
 	if err != nil {
 		if err.Error() == models.BadThreeScaleRuleJson {
 			RespondWithError(w, http.StatusBadRequest, err.Error())
@@ -170,9 +165,6 @@ func ThreeScaleServiceRuleCreate(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
-	conf := config.Get()
-	audit(r, "CREATE on Namespace: "+conf.IstioNamespace+" ThreeScale Rule Name: threescale-"+namespace+"-"+threeScaleRule.ServiceName)
-
 	RespondWithJSON(w, http.StatusOK, threeScaleRule)
 }
 
@@ -193,6 +185,7 @@ func ThreeScaleServiceRuleUpdate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	threeScaleRule, err := business.ThreeScale.UpdateThreeScaleRule(namespace, service, body)
+	auditChange(r, models.AuditEvent{Operation: models.AuditUpdate, Namespace: namespace, ObjectType: "threescalerules", Name: service, Diff: auditDiff(body)}, err)
 	if err != nil {
 		if err.Error() == models.BadThreeScaleRuleJson {
 			RespondWithError(w, http.StatusBadRequest, err.Error())
@@ -201,9 +194,6 @@ func ThreeScaleServiceRuleUpdate(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
-	conf := config.Get()
-	audit(r, "UPDATE on Namespace: "+conf.IstioNamespace+" ThreeScale Rule Name: threescale-"+namespace+"-"+service)
-
 	RespondWithJSON(w, http.StatusOK, threeScaleRule)
 }
 
@@ -219,12 +209,10 @@ func ThreeScaleServiceRuleDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err = business.ThreeScale.DeleteThreeScaleRule(namespace, service)
+	auditChange(r, models.AuditEvent{Operation: models.AuditDelete, Namespace: namespace, ObjectType: "threescalerules", Name: service}, err)
 	if err != nil {
 		handleErrorResponse(w, err)
 		return
 	}
-	conf := config.Get()
-	audit(r, "DELETE on Namespace: "+conf.IstioNamespace+" ThreeScale Rule Name: threescale-"+namespace+"-"+service)
-
 	RespondWithCode(w, http.StatusOK)
 }
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/common/model"
 
 	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/util"
 )
 
 // NamespaceTls is the API to get namespace-wide mTLS status
@@ -64,3 +67,69 @@ func MeshTls(w http.ResponseWriter, r *http.Request) {
 
 	RespondWithJSON(w, http.StatusOK, globalmTLSStatus)
 }
+
+// WorkloadTls is the API to get the effective mTLS mode accepted by a workload
+func WorkloadTls(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	status, err := business.TLS.WorkloadMTLSStatus(params["namespace"], params["workload"])
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, status)
+}
+
+// MeshTlsMatrix is the API to get the mTLS status of the traffic between the workloads of the namespaces,
+// compared with the telemetry
+func MeshTlsMatrix(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	rateInterval := defaultHealthRateInterval
+	if _, found := queryParams["rateInterval"]; found {
+		rateInterval = queryParams.Get("rateInterval")
+	}
+	// the interval is written in the Prometheus queries
+	if _, err := model.ParseDuration(rateInterval); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "bad request, cannot parse query parameter 'rateInterval'")
+		return
+	}
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	nsNames := []string{}
+	if namespaces := queryParams.Get("namespaces"); namespaces != "" {
+		nsNames = strings.Split(namespaces, ",")
+	} else {
+		namespaces, err := business.Namespace.GetNamespaces()
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+		for _, ns := range namespaces {
+			nsNames = append(nsNames, ns.Name)
+		}
+	}
+
+	matrix, err := business.TLS.MTLSMatrix(nsNames, rateInterval, util.Clock.Now())
+	if err != nil {
+		log.Error(err)
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, matrix)
+}
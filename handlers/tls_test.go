@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeshTlsMatrixBadRateInterval(t *testing.T) {
+	for _, rateInterval := range []string{"5m])) or vector(1) #", "5 minutes", ""} {
+		r := httptest.NewRequest("GET", "/api/mesh/tls/matrix", nil)
+		q := r.URL.Query()
+		q.Set("rateInterval", rateInterval)
+		r.URL.RawQuery = q.Encode()
+		w := httptest.NewRecorder()
+
+		MeshTlsMatrix(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code, rateInterval)
+	}
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/models"
+)
+
+// ServiceTrafficRouting is the API handler generating and creating the DestinationRule and the VirtualService
+// routing the traffic of a service. With dryRun=true the objects are only generated and validated.
+func ServiceTrafficRouting(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	service := params["service"]
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Traffic routing request could not be read: "+err.Error())
+		return
+	}
+	request := models.TrafficRoutingRequest{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Bad traffic routing request: "+err.Error())
+		return
+	}
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	routing, err := business.IstioConfig.ApplyTrafficRouting(namespace, service, request, dryRun)
+	for _, change := range routing.Changes {
+		auditChange(r, models.AuditEvent{Operation: change.Operation, Namespace: namespace, ObjectType: change.ObjectType, Name: change.Name, Diff: auditDiff(change.Object)}, change.Error)
+	}
+	if err != nil {
+		if errors.IsBadRequest(err) {
+			RespondWithError(w, http.StatusBadRequest, err.Error())
+		} else if errors.IsAlreadyExists(err) {
+			RespondWithError(w, http.StatusConflict, err.Error())
+		} else {
+			handleErrorResponse(w, err)
+		}
+		return
+	}
+	if !dryRun && !routing.Applied {
+		// the generated objects have validation errors
+		RespondWithJSON(w, http.StatusUnprocessableEntity, routing)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, routing)
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/gorilla/mux"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// ValidationHistoryNew is the API handler returning the validation findings first seen since a time
+func ValidationHistoryNew(w http.ResponseWriter, r *http.Request) {
+	validationHistoryFindings(w, r, func() (*business.Layer, error) { return getBusiness(r) }, readNewFindings)
+}
+
+// ValidationHistoryResolved is the API handler returning the validation findings resolved since a time
+func ValidationHistoryResolved(w http.ResponseWriter, r *http.Request) {
+	validationHistoryFindings(w, r, func() (*business.Layer, error) { return getBusiness(r) }, readResolvedFindings)
+}
+
+// NamespaceValidationTrend is the API handler returning the validation summaries of a namespace over time
+func NamespaceValidationTrend(w http.ResponseWriter, r *http.Request) {
+	namespaceValidationTrend(w, r, mux.Vars(r)["namespace"], func() (*business.Layer, error) { return getBusiness(r) })
+}
+
+//ValidationHistoryNewController
+// validations/history/new?since=1600000000&severity=error
+// @ID ValidationHistoryNew
+// @Summary validation-history-new
+// @Description 查询某个时间之后第一次出现的校验问题, 包括已经解决的
+// @Tags validations
+// @Param since query integer false "unix 时间, 默认一天前"
+// @Param namespaces query string false "逗号分隔的命名空间, 默认所有命名空间"
+// @Param severity query string false "error 或者 warning"
+// @Success 200 {array} models.ValidationFinding
+// @Failure 400 {object} responseError
+// @Failure 503 {object} responseError
+// @Router /validations/history/new [get]
+func (g *GraphController) ValidationHistoryNewController(w http.ResponseWriter, r *http.Request) {
+	validationHistoryFindings(w, r, g.getBusiness, readNewFindings)
+}
+
+//ValidationHistoryResolvedController
+// validations/history/resolved?since=1600000000
+// @ID ValidationHistoryResolved
+// @Summary validation-history-resolved
+// @Description 查询某个时间之后解决的校验问题
+// @Tags validations
+// @Param since query integer false "unix 时间, 默认一天前"
+// @Param namespaces query string false "逗号分隔的命名空间, 默认所有命名空间"
+// @Param severity query string false "error 或者 warning"
+// @Success 200 {array} models.ValidationFinding
+// @Failure 400 {object} responseError
+// @Failure 503 {object} responseError
+// @Router /validations/history/resolved [get]
+func (g *GraphController) ValidationHistoryResolvedController(w http.ResponseWriter, r *http.Request) {
+	validationHistoryFindings(w, r, g.getBusiness, readResolvedFindings)
+}
+
+//NamespaceValidationTrendController
+// namespaces/bookinfo/validations/trend?since=1600000000
+// @ID NamespaceValidationTrend
+// @Summary namespace-validation-trend
+// @Description 查询命名空间定时校验记录的错误和警告数量
+// @Tags validations
+// @Param namespace path string true "命名空间"
+// @Param since query integer false "unix 时间, 默认一天前"
+// @Success 200 {object} models.ValidationTrend
+// @Failure 400 {object} responseError
+// @Failure 503 {object} responseError
+// @Router /namespaces/{namespace}/validations/trend [get]
+func (g *GraphController) NamespaceValidationTrendController(w http.ResponseWriter, r *http.Request) {
+	namespaceValidationTrend(w, r, chi.URLParam(r, "namespace"), g.getBusiness)
+}
+
+func readNewFindings(layer *business.Layer, namespaces []string, since time.Time, severity models.SeverityLevel) ([]models.ValidationFinding, error) {
+	return layer.ValidationHistory.GetNewFindings(namespaces, since, severity)
+}
+
+func readResolvedFindings(layer *business.Layer, namespaces []string, since time.Time, severity models.SeverityLevel) ([]models.ValidationFinding, error) {
+	return layer.ValidationHistory.GetResolvedFindings(namespaces, since, severity)
+}
+
+func namespaceValidationTrend(w http.ResponseWriter, r *http.Request, namespace string, getLayer func() (*business.Layer, error)) {
+	since, err := extractSince(r.URL.Query())
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	business, err := getLayer()
+	if err != nil {
+		log.Error(err)
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	trend, err := business.ValidationHistory.GetTrend(namespace, since)
+	if err != nil {
+		handleValidationHistoryError(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, trend)
+}
+
+func validationHistoryFindings(w http.ResponseWriter, r *http.Request, getLayer func() (*business.Layer, error),
+	read func(*business.Layer, []string, time.Time, models.SeverityLevel) ([]models.ValidationFinding, error)) {
+	queryParams := r.URL.Query()
+
+	since, err := extractSince(queryParams)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	severity := models.SeverityLevel(queryParams.Get("severity"))
+	if severity != "" && severity != models.ErrorSeverity && severity != models.WarningSeverity {
+		RespondWithError(w, http.StatusBadRequest, "bad request, query parameter 'severity' must be either 'error' or 'warning'")
+		return
+	}
+	namespaces := []string{}
+	if nss := queryParams.Get("namespaces"); nss != "" {
+		namespaces = strings.Split(nss, ",")
+	}
+
+	business, err := getLayer()
+	if err != nil {
+		log.Error(err)
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	findings, err := read(business, namespaces, since, severity)
+	if err != nil {
+		handleValidationHistoryError(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, findings)
+}
+
+// extractSince reads the since query parameter, a unix time in seconds. It defaults to one day ago.
+func extractSince(queryParams url.Values) (time.Time, error) {
+	since := queryParams.Get("since")
+	if since == "" {
+		return time.Now().Add(-24 * time.Hour), nil
+	}
+	num, err := strconv.ParseInt(since, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.New("bad request, cannot parse query parameter 'since'")
+	}
+	return time.Unix(num, 0), nil
+}
+
+func handleValidationHistoryError(w http.ResponseWriter, err error) {
+	if err == business.ErrValidationHistoryDisabled {
+		RespondWithError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	handleErrorResponse(w, err)
+}
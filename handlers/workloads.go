@@ -59,6 +59,25 @@ func WorkloadDetails(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, workloadDetails)
 }
 
+// WorkloadSidecarScope is the API handler to fetch the effective Sidecar of a workload
+func WorkloadSidecarScope(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Workloads initialization error: "+err.Error())
+		return
+	}
+
+	scope, err := business.SidecarScope.GetSidecarScope(params["namespace"], params["workload"])
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, scope)
+}
+
 // WorkloadMetrics is the API handler to fetch metrics to be displayed, related to a single workload
 func WorkloadMetrics(w http.ResponseWriter, r *http.Request) {
 	getWorkloadMetrics(w, r, defaultPromClientSupplier)
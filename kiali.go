@@ -28,8 +28,11 @@ import (
 
 	"github.com/golang/glog"
 
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/business/audit"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/config/security"
+	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/prometheus/internalmetrics"
 	"github.com/kiali/kiali/server"
@@ -89,6 +92,23 @@ func main1() {
 	// prepare our internal metrics so Prometheus can scrape them
 	internalmetrics.RegisterInternalMetrics()
 
+	// validate the namespaces periodically with the Kiali Service Account, when the history is enabled
+	if err := business.StartValidationHistory(func() (*business.Layer, error) {
+		token, err := kubernetes.GetKialiToken()
+		if err != nil {
+			return nil, err
+		}
+		return business.Get(token)
+	}); err != nil {
+		log.Errorf("Validation history could not be started: %v", err)
+	}
+
+	if config.Get().Server.AuditLog {
+		if err := audit.Start(config.Get().Server.Audit); err != nil {
+			log.Errorf("Audit sinks could not be started: %v", err)
+		}
+	}
+
 	// Start listening to requests
 	// 开始请求监听内容
 	server := server.NewServer()
@@ -105,6 +125,8 @@ func main1() {
 	// Shutdown internal components
 	log.Info("Shutting down internal components")
 	server.Stop()
+	business.StopValidationHistory()
+	audit.Stop()
 }
 
 // CheckLDAPConfiguration is to check if the required configuration is there in the LDAP configuration
@@ -37,6 +37,9 @@ func (c *kialiCacheImpl) createIstioInformers(namespace string, informer *typeCa
 	if c.CheckIstioResource(kubernetes.ServiceentryType) {
 		(*informer)[kubernetes.ServiceentryType] = createIstioIndexInformer(c.istioNetworkingGetter, kubernetes.Serviceentries, c.refreshDuration, namespace)
 	}
+	if c.CheckIstioResource(kubernetes.EnvoyFilterType) {
+		(*informer)[kubernetes.EnvoyFilterType] = createIstioIndexInformer(c.istioNetworkingGetter, kubernetes.EnvoyFilters, c.refreshDuration, namespace)
+	}
 }
 
 func (c *kialiCacheImpl) isIstioSynced(namespace string) bool {
@@ -46,6 +49,10 @@ func (c *kialiCacheImpl) isIstioSynced(namespace string) bool {
 			nsCache[kubernetes.DestinationRuleType].HasSynced() &&
 			nsCache[kubernetes.GatewayType].HasSynced() &&
 			nsCache[kubernetes.ServiceentryType].HasSynced()
+		// EnvoyFilters are cached only when configured
+		if informer, ok := nsCache[kubernetes.EnvoyFilterType]; ok {
+			isSynced = isSynced && informer.HasSynced()
+		}
 	} else {
 		isSynced = false
 	}
@@ -69,6 +69,8 @@ type IstioClientInterface interface {
 	GetReplicationControllers(namespace string) ([]core_v1.ReplicationController, error)
 	GetReplicaSets(namespace string) ([]apps_v1.ReplicaSet, error)
 	GetRoute(namespace string, name string) (*osroutes_v1.Route, error)
+	GetEnvoyFilter(namespace string, name string) (IstioObject, error)
+	GetEnvoyFilters(namespace string) ([]IstioObject, error)
 	GetSidecar(namespace string, sidecar string) (IstioObject, error)
 	GetSidecars(namespace string) ([]IstioObject, error)
 	GetSelfSubjectAccessReview(namespace, api, resourceType string, verbs []string) ([]*auth_v1.SelfSubjectAccessReview, error)
@@ -10,6 +10,7 @@ import (
 	"k8s.io/client-go/rest"
 
 	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -351,6 +352,53 @@ func (in *IstioClient) GetSidecar(namespace string, sidecar string) (IstioObject
 	return sc, nil
 }
 
+// GetEnvoyFilters return all EnvoyFilters for a given namespace.
+// It returns an error on any problem
+func (in *IstioClient) GetEnvoyFilters(namespace string) ([]IstioObject, error) {
+	// In case EnvoyFilters aren't present on Istio, return empty array.
+	if !in.hasNetworkingResource(EnvoyFilters) {
+		return []IstioObject{}, nil
+	}
+
+	result, err := in.istioNetworkingApi.Get().Namespace(namespace).Resource(EnvoyFilters).Do().Get()
+	if err != nil {
+		return nil, err
+	}
+	envoyFilterList, ok := result.(*GenericIstioObjectList)
+	if !ok {
+		return nil, fmt.Errorf("%s doesn't return an EnvoyFilter list", namespace)
+	}
+	typeMeta := meta_v1.TypeMeta{
+		Kind:       PluralType[EnvoyFilters],
+		APIVersion: ApiNetworkingVersion,
+	}
+	envoyFilters := make([]IstioObject, 0)
+	for _, envoyFilter := range envoyFilterList.GetItems() {
+		ef := envoyFilter.DeepCopyIstioObject()
+		ef.SetTypeMeta(typeMeta)
+		envoyFilters = append(envoyFilters, ef)
+	}
+	return envoyFilters, nil
+}
+
+func (in *IstioClient) GetEnvoyFilter(namespace string, name string) (IstioObject, error) {
+	result, err := in.istioNetworkingApi.Get().Namespace(namespace).Resource(EnvoyFilters).SubResource(name).Do().Get()
+	if err != nil {
+		return nil, err
+	}
+	typeMeta := meta_v1.TypeMeta{
+		Kind:       PluralType[EnvoyFilters],
+		APIVersion: ApiNetworkingVersion,
+	}
+	envoyFilterObject, ok := result.(*GenericIstioObject)
+	if !ok {
+		return nil, fmt.Errorf("%s/%s doesn't return an EnvoyFilter object", namespace, name)
+	}
+	ef := envoyFilterObject.DeepCopyIstioObject()
+	ef.SetTypeMeta(typeMeta)
+	return ef, nil
+}
+
 // GetWorkloadEntries return all WorkloadEntries for a given namespace.
 // It returns an error on any problem
 func (in *IstioClient) GetWorkloadEntries(namespace string) ([]IstioObject, error) {
@@ -1187,9 +1235,9 @@ func (in *IstioClient) GetAuthorizationPolicy(namespace string, name string) (Is
 func (in *IstioClient) GetAuthorizationDetails(namespace string) (*RBACDetails, error) {
 	rb := &RBACDetails{}
 
-	errChan := make(chan error, 1)
+	errChan := make(chan error, 5)
 	var wg sync.WaitGroup
-	wg.Add(4)
+	wg.Add(5)
 
 	go func(errChan chan error) {
 		defer wg.Done()
@@ -1200,6 +1248,28 @@ func (in *IstioClient) GetAuthorizationDetails(namespace string) (*RBACDetails,
 		}
 	}(errChan)
 
+	go func(errChan chan error) {
+		defer wg.Done()
+		if ras, err := in.GetRequestAuthentications(namespace); err == nil {
+			rb.RequestAuthentications = ras
+		} else if errors.IsNotFound(err) || errors.IsForbidden(err) {
+			// Istio versions before 1.5 don't define the RequestAuthentication CRD
+			log.Debugf("RequestAuthentications of %s namespace are not fetched: %s", namespace, err)
+			return
+		} else {
+			errChan <- err
+			return
+		}
+		if istioNamespace := config.Get().IstioNamespace; namespace != istioNamespace {
+			// The user might not access to the control plane namespace, then the mesh-wide objects are not known
+			if ras, err := in.GetRequestAuthentications(istioNamespace); err == nil {
+				rb.MeshRequestAuthentications = ras
+			} else {
+				log.Debugf("RequestAuthentications of %s namespace are not fetched: %s", istioNamespace, err)
+			}
+		}
+	}(errChan)
+
 	go func(errChan chan error) {
 		defer wg.Done()
 		if srb, err := in.GetServiceRoleBindings(namespace); err == nil {
@@ -0,0 +1,51 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+)
+
+// IstiodDebugClient queries a debug endpoint of every istiod pod, i.e. syncz. Each istiod only reports the
+// proxies connected to it.
+type IstiodDebugClient interface {
+	GetIstiodDebug(path string) (map[string][]byte, error)
+}
+
+// GetIstiodDebug returns the response of a debug endpoint by istiod pod, reached through the pods proxy of the
+// API server. It fails when no istiod responds.
+func (in *IstioClient) GetIstiodDebug(path string) (map[string][]byte, error) {
+	conf := config.Get()
+	pods, err := in.GetPods(conf.IstioNamespace, conf.ExternalServices.Istio.IstiodLabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string][]byte{}
+	err = fmt.Errorf("no running istiod pod found in namespace %s", conf.IstioNamespace)
+	for _, pod := range pods {
+		if pod.Status.Phase != core_v1.PodRunning {
+			continue
+		}
+		response, podErr := in.k8s.CoreV1().RESTClient().Get().
+			Namespace(conf.IstioNamespace).
+			Resource("pods").
+			SubResource("proxy").
+			Name(fmt.Sprintf("%s:%d", pod.Name, conf.ExternalServices.Istio.IstiodDebugPort)).
+			Suffix(path).
+			DoRaw()
+		if podErr != nil {
+			log.Warningf("Debug endpoint %s of istiod pod %s could not be read: %v", path, pod.Name, podErr)
+			err = podErr
+			continue
+		}
+		result[pod.Name] = response
+	}
+	if len(result) == 0 {
+		return nil, err
+	}
+	return result, nil
+}
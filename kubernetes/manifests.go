@@ -0,0 +1,275 @@
+package kubernetes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Manifests are the objects read from manifest files instead of fetched from a cluster.
+// Objects without namespace are left as is until SetDefaultNamespace is called.
+type Manifests struct {
+	IstioObjects []IstioObject
+	Services     []core_v1.Service
+	Deployments  []apps_v1.Deployment
+	Sources      []*ManifestSource // where each object was read from
+}
+
+// ManifestSource is the location of an object in a manifest file
+type ManifestSource struct {
+	File      string // empty when not read from a file
+	Line      int    // line of the object, 1-based
+	Kind      string
+	Namespace string
+	Name      string
+	node      *yaml.Node
+}
+
+// manifestExtensions are the file extensions read when loading a directory
+var manifestExtensions = map[string]bool{
+	".json": true,
+	".yaml": true,
+	".yml":  true,
+}
+
+// manifestIstioKinds are the Istio kinds read from manifests
+var manifestIstioKinds = map[string]bool{
+	AuthorizationPoliciesType:  true,
+	DestinationRuleType:        true,
+	EnvoyFilterType:            true,
+	GatewayType:                true,
+	PeerAuthenticationsType:    true,
+	RequestAuthenticationsType: true,
+	ServiceentryType:           true,
+	SidecarType:                true,
+	VirtualServiceType:         true,
+	WorkloadEntryType:          true,
+	clusterrbacconfigType:      true,
+	serviceMeshPolicyType:      true,
+	serviceMeshRbacConfigType:  true,
+	serviceroleType:            true,
+	servicerolebindingType:     true,
+}
+
+// pathSegmentRegexp matches a segment of a validation path, i.e. http[0]
+var pathSegmentRegexp = regexp.MustCompile(`^([^\[]*)((?:\[\d+\])*)$`)
+
+// LoadManifests reads the manifests of a file, or of every yaml and json file under a directory.
+// Kinds not used by the validations are skipped.
+func LoadManifests(path string) (*Manifests, error) {
+	manifests := &Manifests{}
+	err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || (file != path && !manifestExtensions[strings.ToLower(filepath.Ext(file))]) {
+			return nil
+		}
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if err := manifests.ParseFile(file, bytes.NewReader(content)); err != nil {
+			return fmt.Errorf("%s: %v", file, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return manifests, nil
+}
+
+// Parse reads a stream of yaml documents, lists are flattened
+func (m *Manifests) Parse(r io.Reader) error {
+	return m.ParseFile("", r)
+}
+
+// ParseFile reads a stream of yaml documents, recording the file name as the source of its objects
+func (m *Manifests) ParseFile(file string, r io.Reader) error {
+	decoder := yaml.NewDecoder(r)
+	for {
+		doc := &yaml.Node{}
+		if err := decoder.Decode(doc); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if len(doc.Content) == 0 {
+			continue
+		}
+		if err := m.add(file, doc.Content[0]); err != nil {
+			return err
+		}
+	}
+}
+
+// Append adds the objects of other manifests
+func (m *Manifests) Append(other *Manifests) {
+	m.IstioObjects = append(m.IstioObjects, other.IstioObjects...)
+	m.Services = append(m.Services, other.Services...)
+	m.Deployments = append(m.Deployments, other.Deployments...)
+	m.Sources = append(m.Sources, other.Sources...)
+}
+
+// SetDefaultNamespace places the objects without namespace in the given namespace
+func (m *Manifests) SetDefaultNamespace(namespace string) {
+	for _, o := range m.IstioObjects {
+		if meta := o.GetObjectMeta(); meta.Namespace == "" {
+			meta.Namespace = namespace
+			o.SetObjectMeta(meta)
+		}
+	}
+	for i := range m.Services {
+		if m.Services[i].Namespace == "" {
+			m.Services[i].Namespace = namespace
+		}
+	}
+	for i := range m.Deployments {
+		if m.Deployments[i].Namespace == "" {
+			m.Deployments[i].Namespace = namespace
+		}
+	}
+	for _, s := range m.Sources {
+		if s.Namespace == "" {
+			s.Namespace = namespace
+		}
+	}
+}
+
+// FindSource returns the source of an object, objectType is the lowercase kind used by validations
+// (i.e. virtualservice). Nil when the object was not read from these manifests.
+func (m *Manifests) FindSource(objectType, namespace, name string) *ManifestSource {
+	for _, s := range m.Sources {
+		if strings.ToLower(s.Kind) == objectType && s.Namespace == namespace && s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// PathLine returns the line of a validation path (i.e. spec/http[0]/route) in the object. When the path
+// can not be fully resolved the line of its deepest resolved segment is returned.
+func (s *ManifestSource) PathLine(path string) int {
+	line := s.Line
+	node := s.node
+	if node == nil || path == "" {
+		return line
+	}
+	for _, segment := range strings.Split(path, "/") {
+		match := pathSegmentRegexp.FindStringSubmatch(segment)
+		if match == nil {
+			return line
+		}
+		if match[1] != "" {
+			if node = mappingValue(node, match[1]); node == nil {
+				return line
+			}
+			line = node.Line
+		}
+		for _, index := range strings.Split(strings.Trim(match[2], "[]"), "][") {
+			if index == "" {
+				continue
+			}
+			i, _ := strconv.Atoi(index)
+			if node.Kind != yaml.SequenceNode || i >= len(node.Content) {
+				return line
+			}
+			node = node.Content[i]
+			line = node.Line
+		}
+	}
+	return line
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func (m *Manifests) add(file string, node *yaml.Node) error {
+	raw := map[string]interface{}{}
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+	if len(raw) == 0 {
+		return nil
+	}
+	content, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	typeMeta := meta_v1.TypeMeta{}
+	if err := json.Unmarshal(content, &typeMeta); err != nil {
+		return err
+	}
+
+	var objectMeta meta_v1.ObjectMeta
+	switch {
+	case strings.HasSuffix(typeMeta.Kind, "List"):
+		if items := mappingValue(node, "items"); items != nil && items.Kind == yaml.SequenceNode {
+			for _, item := range items.Content {
+				if err := m.add(file, item); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case typeMeta.Kind == ServiceType:
+		service := core_v1.Service{}
+		if err := json.Unmarshal(content, &service); err != nil {
+			return err
+		}
+		m.Services = append(m.Services, service)
+		objectMeta = service.ObjectMeta
+	case typeMeta.Kind == DeploymentType:
+		deployment := apps_v1.Deployment{}
+		if err := json.Unmarshal(content, &deployment); err != nil {
+			return err
+		}
+		m.Deployments = append(m.Deployments, deployment)
+		objectMeta = deployment.ObjectMeta
+	case manifestIstioKinds[typeMeta.Kind]:
+		object := GenericIstioObject{}
+		if err := json.Unmarshal(content, &object); err != nil {
+			return err
+		}
+		if object.Spec == nil {
+			object.Spec = map[string]interface{}{}
+		}
+		m.IstioObjects = append(m.IstioObjects, &object)
+		objectMeta = object.ObjectMeta
+	default:
+		return nil
+	}
+
+	m.Sources = append(m.Sources, &ManifestSource{
+		File:      file,
+		Line:      node.Line,
+		Kind:      typeMeta.Kind,
+		Namespace: objectMeta.Namespace,
+		Name:      objectMeta.Name,
+		node:      node,
+	})
+	return nil
+}
@@ -0,0 +1,99 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testManifests = `
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: reviews
+  namespace: bookinfo
+spec:
+  hosts:
+  - reviews
+  http:
+  - route:
+    - destination:
+        host: reviews
+        subset: v1
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: reviews
+  spec:
+    selector:
+      app: reviews
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: ignored
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: reviews-v1
+spec:
+  template:
+    metadata:
+      labels:
+        app: reviews
+        version: v1
+`
+
+func TestParseManifests(t *testing.T) {
+	assert := assert.New(t)
+
+	manifests := &Manifests{}
+	assert.NoError(manifests.Parse(strings.NewReader(testManifests)))
+
+	assert.Len(manifests.IstioObjects, 1)
+	vs := manifests.IstioObjects[0]
+	assert.Equal(VirtualServiceType, vs.GetTypeMeta().Kind)
+	assert.Equal("bookinfo", vs.GetObjectMeta().Namespace)
+	assert.Equal([]interface{}{"reviews"}, vs.GetSpec()["hosts"])
+
+	assert.Len(manifests.Services, 1)
+	assert.Equal("reviews", manifests.Services[0].Name)
+	assert.Equal("", manifests.Services[0].Namespace)
+
+	assert.Len(manifests.Deployments, 1)
+	assert.Equal("v1", manifests.Deployments[0].Spec.Template.Labels["version"])
+}
+
+func TestParseManifestsError(t *testing.T) {
+	manifests := &Manifests{}
+	assert.Error(t, manifests.Parse(strings.NewReader("kind: [VirtualService")))
+}
+
+func TestManifestSourcePathLine(t *testing.T) {
+	assert := assert.New(t)
+
+	manifests := &Manifests{}
+	assert.NoError(manifests.ParseFile("bookinfo.yaml", strings.NewReader(testManifests)))
+	manifests.SetDefaultNamespace("default")
+
+	assert.Len(manifests.Sources, 3)
+	source := manifests.FindSource("virtualservice", "bookinfo", "reviews")
+	assert.NotNil(source)
+	assert.Equal("bookinfo.yaml", source.File)
+	assert.Equal(2, source.Line)
+	assert.Equal(11, source.PathLine("spec/http[0]"))
+	assert.Equal(13, source.PathLine("spec/http[0]/route[0]/destination"))
+	// unresolved segments return the line of the deepest resolved one
+	assert.Equal(11, source.PathLine("spec/http[0]/match[0]"))
+	assert.Equal(2, source.PathLine(""))
+
+	service := manifests.FindSource("service", "default", "reviews")
+	assert.NotNil(service)
+	assert.Equal(19, service.Line)
+	assert.Nil(manifests.FindSource("configmap", "default", "ignored"))
+}
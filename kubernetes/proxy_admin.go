@@ -0,0 +1,28 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/kiali/kiali/config"
+)
+
+// ProxyAdminClient queries the admin interface of the Envoy proxy of a pod, i.e. the config_dump endpoint.
+// The IstioClient reaches it through the pods proxy of the API server, which requires the admin interface to
+// listen on the pod IP. A client based on a port-forward can be plugged in its place.
+type ProxyAdminClient interface {
+	GetProxyAdmin(namespace, pod, path string, params map[string]string) ([]byte, error)
+}
+
+// GetProxyAdmin returns the response of an endpoint of the admin interface of the proxy of a pod
+func (in *IstioClient) GetProxyAdmin(namespace, pod, path string, params map[string]string) ([]byte, error) {
+	request := in.k8s.CoreV1().RESTClient().Get().
+		Namespace(namespace).
+		Resource("pods").
+		SubResource("proxy").
+		Name(fmt.Sprintf("%s:%d", pod, config.Get().ExternalServices.Istio.EnvoyAdminPort)).
+		Suffix(path)
+	for k, v := range params {
+		request = request.Param(k, v)
+	}
+	return request.DoRaw()
+}
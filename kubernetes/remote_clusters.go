@@ -0,0 +1,45 @@
+package kubernetes
+
+import (
+	"sync"
+
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/kiali/kiali/config"
+)
+
+// remoteClients keeps the clients of the remote clusters, they are created on first use
+var remoteClients = struct {
+	sync.Mutex
+	clients map[string]IstioClientInterface
+}{clients: map[string]IstioClientInterface{}}
+
+// GetRemoteClusterClient returns a client to the API of a remote cluster, built from its kubeconfig.
+// It returns nil when the cluster has no kubeconfig configured.
+func GetRemoteClusterClient(cluster config.RemoteCluster) (IstioClientInterface, error) {
+	if cluster.Kubeconfig == "" {
+		return nil, nil
+	}
+
+	remoteClients.Lock()
+	defer remoteClients.Unlock()
+
+	if client, ok := remoteClients.clients[cluster.Name]; ok {
+		return client, nil
+	}
+
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: cluster.Kubeconfig},
+		&clientcmd.ConfigOverrides{CurrentContext: cluster.Context},
+	).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClientFromConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	remoteClients.clients[cluster.Name] = client
+	return client, nil
+}
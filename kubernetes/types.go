@@ -27,6 +27,10 @@ const (
 	DestinationRuleType     = "DestinationRule"
 	DestinationRuleTypeList = "DestinationRuleList"
 
+	EnvoyFilters        = "envoyfilters"
+	EnvoyFilterType     = "EnvoyFilter"
+	EnvoyFilterTypeList = "EnvoyFilterList"
+
 	Gateways        = "gateways"
 	GatewayType     = "Gateway"
 	GatewayTypeList = "GatewayList"
@@ -223,6 +227,10 @@ var (
 			objectKind:     WorkloadEntryType,
 			collectionKind: WorkloadEntryTypeList,
 		},
+		{
+			objectKind:     EnvoyFilterType,
+			collectionKind: EnvoyFilterTypeList,
+		},
 	}
 
 	configTypes = []struct {
@@ -379,6 +387,7 @@ var (
 		Serviceentries:   ServiceentryType,
 		Sidecars:         SidecarType,
 		WorkloadEntries:  WorkloadEntryType,
+		EnvoyFilters:     EnvoyFilterType,
 
 		// Main Config files
 		rules:             ruleType,
@@ -467,6 +476,7 @@ type IstioDetails struct {
 	ServiceEntries   []IstioObject `json:"serviceentries"`
 	Gateways         []IstioObject `json:"gateways"`
 	Sidecars         []IstioObject `json:"sidecars"`
+	EnvoyFilters     []IstioObject `json:"envoyfilters"`
 }
 
 // MTLSDetails is a wrapper to group all Istio objects related to non-local mTLS configurations
@@ -485,6 +495,9 @@ type RBACDetails struct {
 	ServiceRoles           []IstioObject `json:"serviceroles"`
 	ServiceRoleBindings    []IstioObject `json:"servicerolebindings"`
 	AuthorizationPolicies  []IstioObject `json:"authorizationpolicies"`
+	RequestAuthentications []IstioObject `json:"requestauthentications"`
+	// RequestAuthentications of the control plane namespace, applying to the whole mesh
+	MeshRequestAuthentications []IstioObject `json:"meshrequestauthentications"`
 }
 
 type istioResponse struct {
@@ -4,14 +4,21 @@ import (
 	"flag"
 	"github.com/go-chi/chi"
 	"github.com/golang/glog"
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/business/audit"
 	"github.com/kiali/kiali/config"
 	_ "github.com/kiali/kiali/docs"
+	"github.com/kiali/kiali/handlers"
+	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
 	"github.com/kiali/kiali/routers"
 	"github.com/kiali/kiali/util"
 	"github.com/spf13/cobra"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 )
 
 func init() {
@@ -26,6 +33,8 @@ func init() {
 		"http://prometheus.istio-system:9090", "[prometheus api 接口 地址]")
 	rootCmd.PersistentFlags().StringVar(&kiali.JaegerURL, "jaeger",
 		"jaeger.jaeger-infra:6831", "[prometheus api 接口 地址]")
+	rootCmd.PersistentFlags().StringVar(&kiali.ConfigFile, "config",
+		"", "kiali 配置文件路径, 为空时使用默认配置")
 
 }
 
@@ -34,6 +43,7 @@ type Kiali struct {
 	JaegerURL     string `json:"jaeger_url"`
 	PrometheusURL string `json:"prometheus_url"`
 	Context       string `json:"context"`
+	ConfigFile    string `json:"config_file"`
 }
 
 var (
@@ -55,13 +65,28 @@ var (
 		RunE: func(c *cobra.Command, args []string) (err error) {
 			defer glog.Flush()
 			util.Clock = util.RealClock{}
-			config.Set(config.NewConfig())
+			if err = kiali.LoadConfig(); err != nil {
+				log.Errorf("load config error:%v", err)
+				return
+			}
 			log.Tracef("Kiali Configuration:\n%+v", config.Get().Server.Address)
 			r, err := kiali.NewServer()
 			if err != nil {
 				log.Errorf("new server error:%v", err)
 				return
 			}
+			// prepare our internal metrics so Prometheus can scrape them
+			internalmetrics.RegisterInternalMetrics()
+			if err := kiali.StartValidationHistory(); err != nil {
+				log.Errorf("Validation history could not be started: %v", err)
+			}
+			defer business.StopValidationHistory()
+			if config.Get().Server.AuditLog {
+				if err := audit.Start(config.Get().Server.Audit); err != nil {
+					log.Errorf("Audit sinks could not be started: %v", err)
+				}
+				defer audit.Stop()
+			}
 			return kiali.Start(r)
 		},
 	}
@@ -90,6 +115,32 @@ func main() {
 	}
 }
 
+// LoadConfig loads the configuration file if specified, otherwise the default configuration is used
+func (k *Kiali) LoadConfig() error {
+	if k.ConfigFile == "" {
+		config.Set(config.NewConfig())
+		return nil
+	}
+	c, err := config.LoadFromFile(k.ConfigFile)
+	if err != nil {
+		return err
+	}
+	config.Set(c)
+	return nil
+}
+
+// StartValidationHistory validates the namespaces periodically with the client configuration, when the history
+// is enabled
+func (k *Kiali) StartValidationHistory() error {
+	configClient, err := kubernetes.ConfigClient()
+	if err != nil {
+		return err
+	}
+	return business.StartValidationHistory(func() (*business.Layer, error) {
+		return handlers.GetBusinessNoAuth(configClient, k.PrometheusURL, nil)
+	})
+}
+
 func (k *Kiali) NewServer() (*chi.Mux, error) {
 	log.Infof("cluster name: %s", k.Context)
 	err := routers.InitOpentracing(k.JaegerURL)
@@ -100,7 +151,22 @@ func (k *Kiali) NewServer() (*chi.Mux, error) {
 	return routers.NewRouter(k.PrometheusURL, k.Context)
 }
 
+// Start serves the router until the server fails or a termination signal is received
 func (k *Kiali) Start(r *chi.Mux) error {
 	log.Infof("server start http://localhost%s", k.Port)
-	return http.ListenAndServe(k.Port, r)
+	srv := &http.Server{Addr: k.Port, Handler: r}
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- srv.ListenAndServe()
+	}()
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	select {
+	case err := <-errChan:
+		return err
+	case <-signalChan:
+		log.Info("Termination Signal Received")
+		return srv.Close()
+	}
 }
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditDelete = "delete"
+
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+)
+
+// AuditEvent is the record of a write operation on an Istio, 3scale or iter8 object
+// swagger:model AuditEvent
+type AuditEvent struct {
+	Time time.Time `json:"time"`
+	// User performing the operation, empty with the anonymous strategy
+	User string `json:"user"`
+	// Authentication strategy of Kiali
+	Strategy string `json:"strategy"`
+	// Address of the client connected to Kiali, a proxy when Kiali is behind one
+	SourceIP string `json:"sourceIP"`
+	// X-Forwarded-For header of the request, as sent by the client or the proxies, so it can't be trusted
+	ForwardedFor string `json:"forwardedFor,omitempty"`
+	// One of create, update or delete
+	Operation     string `json:"operation"`
+	Namespace     string `json:"namespace"`
+	ObjectType    string `json:"objectType"`
+	ObjectSubtype string `json:"objectSubtype,omitempty"`
+	Name          string `json:"name,omitempty"`
+	// Change of the object: the created object, the JSON merge patch of an update, empty for a delete
+	Diff json.RawMessage `json:"diff,omitempty"`
+	// One of success or failure
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
@@ -0,0 +1,94 @@
+package models
+
+// AuthorizationSimulation is a request to a workload, evaluated against the AuthorizationPolicies applying to it
+// swagger:model AuthorizationSimulation
+type AuthorizationSimulation struct {
+	// required: true
+	Source AuthorizationSimulationSource `json:"source"`
+
+	// required: true
+	Request AuthorizationSimulationRequest `json:"request"`
+}
+
+// AuthorizationSimulationSource is the source of a simulated request
+type AuthorizationSimulationSource struct {
+	// Principal of the source workload. Empty for plain text traffic.
+	// example: cluster.local/ns/bookinfo/sa/bookinfo-productpage
+	Principal string `json:"principal"`
+
+	// Namespace of the source workload, taken from the principal when empty
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+
+	// example: 10.1.0.12
+	IP string `json:"ip"`
+
+	// Principal of the request JWT, as <iss>/<sub>
+	// example: issuer.example.com/subject
+	RequestPrincipal string `json:"requestPrincipal"`
+
+	// Claims of the request JWT
+	Claims map[string][]string `json:"claims"`
+}
+
+// AuthorizationSimulationRequest are the attributes of a simulated request
+type AuthorizationSimulationRequest struct {
+	// example: reviews.bookinfo.svc.cluster.local
+	Host string `json:"host"`
+
+	// example: GET
+	Method string `json:"method"`
+
+	// example: /reviews/1
+	Path string `json:"path"`
+
+	// Port of the destination workload
+	// example: 9080
+	Port uint32 `json:"port"`
+
+	Headers map[string]string `json:"headers"`
+}
+
+// AuthorizationSimulationResult is the decision of the AuthorizationPolicies for a simulated request
+// swagger:model AuthorizationSimulationResult
+type AuthorizationSimulationResult struct {
+	// ALLOW or DENY
+	// required: true
+	// example: DENY
+	Decision string `json:"decision"`
+
+	// required: true
+	// example: Denied by the rule 0 of the DENY policy bookinfo/deny-get
+	Reason string `json:"reason"`
+
+	// Rule deciding the request, nil when no policy decides it
+	Rule *AuthorizationPolicyRule `json:"rule"`
+
+	// CUSTOM policies delegating the request to an external authorizer. The decision assumes it allows the request.
+	// required: true
+	Custom []AuthorizationPolicyRule `json:"custom"`
+
+	// AuthorizationPolicies applying to the destination workload, as namespace/name
+	// required: true
+	Policies []string `json:"policies"`
+}
+
+// AuthorizationPolicyRule is a rule of an AuthorizationPolicy matching a request
+type AuthorizationPolicyRule struct {
+	// required: true
+	Namespace string `json:"namespace"`
+
+	// required: true
+	Name string `json:"name"`
+
+	// ALLOW, DENY or CUSTOM
+	// required: true
+	Action string `json:"action"`
+
+	// Provider of a CUSTOM policy
+	Provider string `json:"provider,omitempty"`
+
+	// Index of the rule in the policy
+	// required: true
+	Rule int `json:"rule"`
+}
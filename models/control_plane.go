@@ -0,0 +1,89 @@
+package models
+
+// ControlPlaneStatus is the health of the revisions of the Istio control plane
+// swagger:model ControlPlaneStatus
+type ControlPlaneStatus struct {
+	// Worst status of the revisions: Healthy, Degraded, Unhealthy or NotFound
+	// required: true
+	// example: Healthy
+	Status string `json:"status"`
+
+	// False when the istiod metrics could not be read from Prometheus, the status then only reflects the deployments
+	// required: true
+	MetricsAvailable bool `json:"metricsAvailable"`
+
+	// required: true
+	Revisions []ControlPlaneRevision `json:"revisions"`
+}
+
+// ControlPlaneRevision is the health of the istiod instances of a revision, several revisions run side by side
+// during a canary upgrade
+type ControlPlaneRevision struct {
+	// Value of the istio.io/rev label, default when the control plane is not revisioned
+	// required: true
+	// example: 1-7-0
+	Revision string `json:"revision"`
+
+	// required: true
+	// example: Healthy
+	Status string `json:"status"`
+
+	// Reasons of a Degraded or Unhealthy status
+	Issues []string `json:"issues,omitempty"`
+
+	// Number of proxies connected to the istiod instances of the revision
+	// required: true
+	// example: 42
+	Proxies int `json:"proxies"`
+
+	// Namespaces whose sidecars are injected by the revision
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// required: true
+	Istiods []IstiodStatus `json:"istiods"`
+}
+
+// IstiodStatus is the health of an istiod pod
+type IstiodStatus struct {
+	// required: true
+	// example: istiod-1-7-0-5c8c5b8c8-7xkzp
+	Name string `json:"name"`
+
+	// Healthy when the pod is running and ready, Unhealthy otherwise
+	// required: true
+	// example: Healthy
+	Status string `json:"status"`
+
+	// Tag of the istiod image
+	// example: 1.7.0
+	Version string `json:"version,omitempty"`
+
+	Metrics *ControlPlaneMetrics `json:"metrics,omitempty"`
+}
+
+// ControlPlaneMetrics are the health metrics reported by an istiod instance, rates are per second
+type ControlPlaneMetrics struct {
+	// Number of connected proxies
+	// example: 21
+	Proxies int `json:"proxies"`
+
+	// Rate of xDS pushes
+	// example: 0.5
+	Pushes float64 `json:"pushes"`
+
+	// Rate of xDS pushes failing to be sent
+	// example: 0
+	PushErrors float64 `json:"pushErrors"`
+
+	// Rate of configurations rejected by the proxies
+	// example: 0
+	RejectedConfigs float64 `json:"rejectedConfigs"`
+
+	// Number of listeners conflicting on the same port
+	// example: 0
+	ConflictingListeners int `json:"conflictingListeners"`
+
+	// Rate of failed sidecar injections
+	// example: 0
+	InjectionFailures float64 `json:"injectionFailures"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+type EnvoyFilters []EnvoyFilter
+type EnvoyFilter struct {
+	meta_v1.TypeMeta
+	Metadata meta_v1.ObjectMeta `json:"metadata"`
+	Spec     struct {
+		WorkloadSelector interface{} `json:"workloadSelector"`
+		ConfigPatches    interface{} `json:"configPatches"`
+		Priority         interface{} `json:"priority"`
+	} `json:"spec"`
+}
+
+func (efs *EnvoyFilters) Parse(envoyFilters []kubernetes.IstioObject) {
+	for _, ef := range envoyFilters {
+		envoyFilter := EnvoyFilter{}
+		envoyFilter.Parse(ef)
+		*efs = append(*efs, envoyFilter)
+	}
+}
+
+func (ef *EnvoyFilter) Parse(envoyFilter kubernetes.IstioObject) {
+	ef.TypeMeta = envoyFilter.GetTypeMeta()
+	ef.Metadata = envoyFilter.GetObjectMeta()
+	ef.Spec.WorkloadSelector = envoyFilter.GetSpec()["workloadSelector"]
+	ef.Spec.ConfigPatches = envoyFilter.GetSpec()["configPatches"]
+	ef.Spec.Priority = envoyFilter.GetSpec()["priority"]
+}
@@ -0,0 +1,61 @@
+package models
+
+// GatewayRoutes gatewayRoutes
+//
+// This is used for returning the routing topology declared for a Gateway: the workloads
+// selected by the Gateway and every route defined by the VirtualServices bound to it.
+//
+// swagger:model gatewayRoutes
+type GatewayRoutes struct {
+	// Gateway object
+	Gateway Gateway `json:"gateway"`
+	// Workloads selected by the Gateway selector
+	Workloads []GatewayWorkload `json:"workloads"`
+	// Hosts declared by the VirtualServices bound to the Gateway
+	Hosts []string `json:"hosts"`
+	// Routes declared by the VirtualServices bound to the Gateway
+	Routes []GatewayRoute `json:"routes"`
+}
+
+// GatewayWorkload identifies a workload running a Gateway
+type GatewayWorkload struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	App       string `json:"app,omitempty"`
+	Version   string `json:"version,omitempty"`
+}
+
+// GatewayRoute is a single http, tcp or tls route of a VirtualService bound to a Gateway
+type GatewayRoute struct {
+	// VirtualService name
+	VirtualService string `json:"virtualService"`
+	// VirtualService namespace
+	Namespace string `json:"namespace"`
+	// Hosts declared by the VirtualService
+	Hosts []string `json:"hosts"`
+	// Protocol section of the route: http, tcp or tls
+	Protocol string `json:"protocol"`
+	// Path of the route in the VirtualService spec, i.e. spec/http[0]
+	Path string `json:"path"`
+	// Destinations of the route
+	Destinations []GatewayRouteDestination `json:"destinations"`
+}
+
+// GatewayRouteDestination is a route destination resolved against the services of the mesh
+type GatewayRouteDestination struct {
+	// Host as declared in the route destination
+	Host string `json:"host"`
+	// Subset as declared in the route destination
+	Subset string `json:"subset,omitempty"`
+	// Service name the host resolves to
+	Service string `json:"service"`
+	// Service namespace the host resolves to
+	Namespace string `json:"namespace"`
+	// HasService is false when no Service or ServiceEntry backs the host
+	HasService bool `json:"hasService"`
+}
+
+// Name returns the namespace/name key used to reference the route's VirtualService
+func (r GatewayRoute) Name() string {
+	return r.Namespace + "/" + r.VirtualService
+}
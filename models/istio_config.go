@@ -13,6 +13,7 @@ type IstioConfigList struct {
 	Gateways               Gateways               `json:"gateways"`
 	VirtualServices        VirtualServices        `json:"virtualServices"`
 	DestinationRules       DestinationRules       `json:"destinationRules"`
+	EnvoyFilters           EnvoyFilters           `json:"envoyFilters"`
 	ServiceEntries         ServiceEntries         `json:"serviceEntries"`
 	WorkloadEntries        WorkloadEntries        `json:"workloadEntries"`
 	Rules                  IstioRules             `json:"rules"`
@@ -41,6 +42,7 @@ type IstioConfigDetails struct {
 	Gateway               *Gateway               `json:"gateway"`
 	VirtualService        *VirtualService        `json:"virtualService"`
 	DestinationRule       *DestinationRule       `json:"destinationRule"`
+	EnvoyFilter           *EnvoyFilter           `json:"envoyFilter"`
 	ServiceEntry          *ServiceEntry          `json:"serviceEntry"`
 	WorkloadEntry         *WorkloadEntry         `json:"workloadEntry"`
 	Rule                  *IstioRule             `json:"rule"`
@@ -0,0 +1,30 @@
+package models
+
+const (
+	BundleCreate = "create"
+	BundleUpdate = "update"
+	BundleNone   = "none"
+)
+
+// IstioConfigImport is the result of the import of a bundle of Istio objects
+// swagger:model IstioConfigImport
+type IstioConfigImport struct {
+	// Objects of the bundle, in the order of the bundle
+	Objects []IstioConfigImportObject `json:"objects"`
+	// Validations of the objects of the bundle against the state of their namespaces
+	Validations IstioValidations `json:"validations"`
+	// True when the bundle has been applied, it is not applied when it is invalid
+	Applied bool `json:"applied"`
+}
+
+// IstioConfigImportObject is the import of an object of a bundle
+type IstioConfigImportObject struct {
+	Namespace  string `json:"namespace"`
+	ObjectType string `json:"objectType"`
+	Name       string `json:"name"`
+	// One of create, update, or none when the object is unchanged
+	Action string `json:"action"`
+	// True when the action has been applied
+	Applied bool   `json:"applied"`
+	Error   string `json:"error,omitempty"`
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"sort"
+)
+
+// IstioConfigDryRun is the result of validating a create or an update of an Istio object without applying it
+// swagger:model IstioConfigDryRun
+type IstioConfigDryRun struct {
+	// Validation of the proposed object
+	Validation *IstioValidation `json:"validation"`
+	// Other objects of the namespace whose checks would change
+	Impacts []IstioValidationImpact `json:"impacts"`
+}
+
+// IstioValidationImpact is the change of the checks of an object caused by the change of another object
+type IstioValidationImpact struct {
+	IstioValidationKey
+	// Validity of the object after the change
+	Valid bool `json:"valid"`
+	// Checks the change adds to the object
+	Added []*IstioCheck `json:"added"`
+	// Checks of the object the change resolves
+	Resolved []*IstioCheck `json:"resolved"`
+}
+
+// ValidationImpacts compares the validations before and after a change, and returns the objects whose checks
+// change, except the changed object itself
+func ValidationImpacts(before, after IstioValidations, changed IstioValidationKey) []IstioValidationImpact {
+	keys := map[IstioValidationKey]bool{}
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+	delete(keys, changed)
+
+	impacts := []IstioValidationImpact{}
+	for k := range keys {
+		var beforeChecks, afterChecks []*IstioCheck
+		valid := true
+		if v, ok := before[k]; ok {
+			beforeChecks = v.Checks
+		}
+		if v, ok := after[k]; ok {
+			afterChecks = v.Checks
+			valid = v.Valid
+		} else if _, ok := before[k]; ok {
+			// the object is not validated anymore, nothing changed on it
+			continue
+		}
+		added, resolved := diffChecks(beforeChecks, afterChecks), diffChecks(afterChecks, beforeChecks)
+		if len(added) == 0 && len(resolved) == 0 {
+			continue
+		}
+		impacts = append(impacts, IstioValidationImpact{IstioValidationKey: k, Valid: valid, Added: added, Resolved: resolved})
+	}
+	sort.Slice(impacts, func(i, j int) bool {
+		a, b := impacts[i].IstioValidationKey, impacts[j].IstioValidationKey
+		if a.ObjectType != b.ObjectType {
+			return a.ObjectType < b.ObjectType
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return impacts
+}
+
+// diffChecks returns the checks of to missing in from
+func diffChecks(from, to []*IstioCheck) []*IstioCheck {
+	diff := []*IstioCheck{}
+	for _, t := range to {
+		found := false
+		for _, f := range from {
+			if f.Code == t.Code && f.Message == t.Message && f.Severity == t.Severity && f.Path == t.Path {
+				found = true
+				break
+			}
+		}
+		if !found {
+			diff = append(diff, t)
+		}
+	}
+	return diff
+}
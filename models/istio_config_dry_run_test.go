@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationImpacts(t *testing.T) {
+	assert := assert.New(t)
+
+	changed := IstioValidationKey{ObjectType: "destinationrule", Name: "reviews", Namespace: "bookinfo"}
+	vsKey := IstioValidationKey{ObjectType: "virtualservice", Name: "reviews", Namespace: "bookinfo"}
+	gwKey := IstioValidationKey{ObjectType: "gateway", Name: "bookinfo-gateway", Namespace: "bookinfo"}
+	seKey := IstioValidationKey{ObjectType: "serviceentry", Name: "external", Namespace: "bookinfo"}
+
+	subsetNotFound := &IstioCheck{Code: "KIA1107", Message: "Subset not found", Severity: WarningSeverity, Path: "spec/http[0]/route[0]/destination"}
+	multiMatch := &IstioCheck{Code: "KIA0301", Message: "More than one Gateway for the same host port combination", Severity: WarningSeverity}
+	drCheck := &IstioCheck{Code: "KIA0203", Message: "This subset's labels are not found in any matching host", Severity: ErrorSeverity}
+
+	before := IstioValidations{
+		changed: {Name: "reviews", ObjectType: "destinationrule", Valid: true, Checks: []*IstioCheck{}},
+		vsKey:   {Name: "reviews", ObjectType: "virtualservice", Valid: true, Checks: []*IstioCheck{subsetNotFound}},
+		gwKey:   {Name: "bookinfo-gateway", ObjectType: "gateway", Valid: true, Checks: []*IstioCheck{multiMatch}},
+	}
+	after := IstioValidations{
+		changed: {Name: "reviews", ObjectType: "destinationrule", Valid: false, Checks: []*IstioCheck{drCheck}},
+		vsKey:   {Name: "reviews", ObjectType: "virtualservice", Valid: true, Checks: []*IstioCheck{}},
+		gwKey:   {Name: "bookinfo-gateway", ObjectType: "gateway", Valid: true, Checks: []*IstioCheck{multiMatch}},
+		seKey:   {Name: "external", ObjectType: "serviceentry", Valid: false, Checks: []*IstioCheck{drCheck}},
+	}
+
+	impacts := ValidationImpacts(before, after, changed)
+	// the changed object and the unchanged gateway are not impacts
+	assert.Len(impacts, 2)
+	assert.Equal(seKey, impacts[0].IstioValidationKey)
+	assert.False(impacts[0].Valid)
+	assert.Equal([]*IstioCheck{drCheck}, impacts[0].Added)
+	assert.Empty(impacts[0].Resolved)
+	assert.Equal(vsKey, impacts[1].IstioValidationKey)
+	assert.True(impacts[1].Valid)
+	assert.Empty(impacts[1].Added)
+	assert.Equal([]*IstioCheck{subsetNotFound}, impacts[1].Resolved)
+}
@@ -54,6 +54,10 @@ type IstioValidation struct {
 	// Array of checks. It might be empty.
 	Checks []*IstioCheck `json:"checks"`
 
+	// Array of checks suppressed by configuration or by the kiali.io/ignore-checks annotation.
+	// They do not affect the validity of the object.
+	SuppressedChecks []*IstioCheck `json:"suppressedChecks,omitempty"`
+
 	// Related objects (only validation errors)
 	References []IstioValidationKey `json:"references"`
 }
@@ -61,6 +65,10 @@ type IstioValidation struct {
 // IstioCheck represents an individual check.
 // swagger:model
 type IstioCheck struct {
+	// Stable identifier of the check, which prefixes the message as well
+	// example: KIA1107
+	Code string `json:"code"`
+
 	// Description of the check
 	// required: true
 	// example: Weight sum should be 100
@@ -85,184 +93,316 @@ const (
 )
 
 var ObjectTypeSingular = map[string]string{
-	"gateways":              "gateway",
-	"virtualservices":       "virtualservice",
-	"destinationrules":      "destinationrule",
-	"serviceentries":        "serviceentry",
-	"rules":                 "rule",
-	"quotaspecs":            "quotaspec",
-	"quotaspecbindings":     "quotaspecbinding",
-	"servicemeshpolicies":   "servicemeshpolicy",
-	"policies":              "policy",
-	"serviceroles":          "servicerole",
-	"servicerolebindings":   "servicerolebinding",
-	"clusterrbacconfigs":    "clusterrbacconfig",
-	"authorizationpolicies": "authorizationpolicy",
-	"sidecars":              "sidecar",
-	"peerauthentications":   "peerauthentication",
+	"gateways":               "gateway",
+	"virtualservices":        "virtualservice",
+	"destinationrules":       "destinationrule",
+	"envoyfilters":           "envoyfilter",
+	"serviceentries":         "serviceentry",
+	"rules":                  "rule",
+	"quotaspecs":             "quotaspec",
+	"quotaspecbindings":      "quotaspecbinding",
+	"servicemeshpolicies":    "servicemeshpolicy",
+	"policies":               "policy",
+	"serviceroles":           "servicerole",
+	"servicerolebindings":    "servicerolebinding",
+	"clusterrbacconfigs":     "clusterrbacconfig",
+	"authorizationpolicies":  "authorizationpolicy",
+	"sidecars":               "sidecar",
+	"peerauthentications":    "peerauthentication",
+	"requestauthentications": "requestauthentication",
 }
 
 var checkDescriptors = map[string]IstioCheck{
 	"authorizationpolicy.source.namespacenotfound": {
-		Message:  "KIA0101 Namespace not found for this rule",
+		Code:     "KIA0101",
+		Message:  "Namespace not found for this rule",
 		Severity: WarningSeverity,
 	},
 	"authorizationpolicy.to.wrongmethod": {
-		Message:  "KIA0102 Only HTTP methods and fully-qualified gRPC names are allowed",
+		Code:     "KIA0102",
+		Message:  "Only HTTP methods and fully-qualified gRPC names are allowed",
 		Severity: WarningSeverity,
 	},
 	"authorizationpolicy.selector.workloadnotfound": {
-		Message:  "KIA0103 No matching workload found for authorization policy selector in this namespace",
+		Code:     "KIA0103",
+		Message:  "No matching workload found for authorization policy selector in this namespace",
 		Severity: WarningSeverity,
 	},
 	"authorizationpolicy.nodest.matchingregistry": {
-		Message:  "KIA0104 This host has no matching entry in the service registry",
+		Code:     "KIA0104",
+		Message:  "This host has no matching entry in the service registry",
 		Severity: ErrorSeverity,
 	},
+	"authorizationpolicy.requestauth.notfound": {
+		Code:     "KIA0105",
+		Message:  "This field requires a JWT but no RequestAuthentication applies to the selected workloads",
+		Severity: WarningSeverity,
+	},
 	"destinationrules.multimatch": {
-		Message:  "KIA0201 More than one DestinationRules for the same host subset combination",
+		Code:     "KIA0201",
+		Message:  "More than one DestinationRules for the same host subset combination",
 		Severity: WarningSeverity,
 	},
 	"destinationrules.nodest.matchingregistry": {
-		Message:  "KIA0202 This host has no matching entry in the service registry (service, workload or service entries)",
+		Code:     "KIA0202",
+		Message:  "This host has no matching entry in the service registry (service, workload or service entries)",
 		Severity: ErrorSeverity,
 	},
 	"destinationrules.nodest.subsetlabels": {
-		Message:  "KIA0203 This subset's labels are not found in any matching host",
+		Code:     "KIA0203",
+		Message:  "This subset's labels are not found in any matching host",
 		Severity: ErrorSeverity,
 	},
 	"destinationrules.trafficpolicy.notlssettings": {
-		Message:  "KIA0204 mTLS settings of a non-local Destination Rule are overridden",
+		Code:     "KIA0204",
+		Message:  "mTLS settings of a non-local Destination Rule are overridden",
 		Severity: WarningSeverity,
 	},
 	"destinationrules.mtls.meshpolicymissing": {
-		Message:  "KIA0205 PeerAuthentication enabling mTLS at mesh level is missing",
+		Code:     "KIA0205",
+		Message:  "PeerAuthentication enabling mTLS at mesh level is missing",
 		Severity: ErrorSeverity,
 	},
 	"destinationrules.mtls.nspolicymissing": {
-		Message:  "KIA0206 PeerAuthentication enabling namespace-wide mTLS is missing",
+		Code:     "KIA0206",
+		Message:  "PeerAuthentication enabling namespace-wide mTLS is missing",
 		Severity: ErrorSeverity,
 	},
 	"destinationrules.mtls.policymtlsenabled": {
-		Message:  "KIA0207 PeerAuthentication with TLS strict mode found, it should be permissive",
+		Code:     "KIA0207",
+		Message:  "PeerAuthentication with TLS strict mode found, it should be permissive",
 		Severity: ErrorSeverity,
 	},
 	"destinationrules.mtls.meshpolicymtlsenabled": {
-		Message:  "KIA0208 PeerAuthentication enabling mTLS found, permissive policy is needed",
+		Code:     "KIA0208",
+		Message:  "PeerAuthentication enabling mTLS found, permissive policy is needed",
 		Severity: ErrorSeverity,
 	},
 	"destinationrules.mtls.servicemeshpolicymissing": {
-		Message:  "KIA0209 ServiceMeshPolicy enabling mTLS is missing",
+		Code:     "KIA0209",
+		Message:  "ServiceMeshPolicy enabling mTLS is missing",
 		Severity: ErrorSeverity,
 	},
 	"destinationrules.mtls.servicemeshpolicymtlsenabled": {
-		Message:  "KIA0210 ServiceMeshPolicy enabling mTLS found, permissive policy is needed",
+		Code:     "KIA0210",
+		Message:  "ServiceMeshPolicy enabling mTLS found, permissive policy is needed",
 		Severity: ErrorSeverity,
 	},
+	"envoyfilter.selector.workloadnotfound": {
+		Code:     "KIA1201",
+		Message:  "No matching workload found for envoy filter selector in this namespace",
+		Severity: WarningSeverity,
+	},
+	"envoyfilter.patch.listenernotfound": {
+		Code:     "KIA1202",
+		Message:  "No Service, ServiceEntry or Gateway exposes the port of this listener",
+		Severity: WarningSeverity,
+	},
+	"envoyfilter.patch.clusternotfound": {
+		Code:     "KIA1203",
+		Message:  "This cluster has no matching entry in the service registry",
+		Severity: WarningSeverity,
+	},
+	"envoyfilter.multimatch.priority": {
+		Code:     "KIA1204",
+		Message:  "More than one EnvoyFilter patches the same config of the same workloads with the same priority",
+		Severity: WarningSeverity,
+	},
+	"serviceentry.global.invalidhost": {
+		Code:     "KIA1301",
+		Message:  ".global hosts must follow the format service.namespace.global",
+		Severity: ErrorSeverity,
+	},
+	"serviceentry.global.unknowngateway": {
+		Code:     "KIA1302",
+		Message:  "Endpoint address is not the gateway of any known cluster",
+		Severity: WarningSeverity,
+	},
+	"serviceentry.global.servicenotfound": {
+		Code:     "KIA1303",
+		Message:  "Service not found in the target cluster",
+		Severity: ErrorSeverity,
+	},
+	"serviceentry.global.portnotfound": {
+		Code:     "KIA1304",
+		Message:  "Port not exposed by the Service of the target cluster",
+		Severity: WarningSeverity,
+	},
 	"gateways.multimatch": {
-		Message:  "KIA0301 More than one Gateway for the same host port combination",
+		Code:     "KIA0301",
+		Message:  "More than one Gateway for the same host port combination",
 		Severity: WarningSeverity,
 	},
 	"gateways.selector": {
-		Message:  "KIA0302 No matching workload found for gateway selector in this namespace",
+		Code:     "KIA0302",
+		Message:  "No matching workload found for gateway selector in this namespace",
 		Severity: WarningSeverity,
 	},
 	"peerauthentication.mtls.destinationrulemissing": {
-		Message:  "KIA0401 Mesh-wide Destination Rule enabling mTLS is missing",
+		Code:     "KIA0401",
+		Message:  "Mesh-wide Destination Rule enabling mTLS is missing",
 		Severity: ErrorSeverity,
 	},
 	"peerauthentications.mtls.destinationrulemissing": {
-		Message:  "KIA0501 Destination Rule enabling namespace-wide mTLS is missing",
+		Code:     "KIA0501",
+		Message:  "Destination Rule enabling namespace-wide mTLS is missing",
 		Severity: ErrorSeverity,
 	},
 	"port.name.mismatch": {
-		Message:  "KIA0601 Port name must follow <protocol>[-suffix] form",
+		Code:     "KIA0601",
+		Message:  "Port name must follow <protocol>[-suffix] form",
 		Severity: ErrorSeverity,
 	},
 	"service.deployment.port.mismatch": {
-		Message:  "KIA0701 Deployment exposing same port as Service not found",
+		Code:     "KIA0701",
+		Message:  "Deployment exposing same port as Service not found",
 		Severity: WarningSeverity,
 	},
 	"servicemeshpolicies.mtls.destinationrulemissing": {
-		Message:  "KIA0801 Mesh-wide Destination Rule enabling mTLS is missing",
+		Code:     "KIA0801",
+		Message:  "Mesh-wide Destination Rule enabling mTLS is missing",
 		Severity: ErrorSeverity,
 	},
 	"servicerole.invalid.services": {
-		Message:  "KIA0901 Unable to find all the defined services",
+		Code:     "KIA0901",
+		Message:  "Unable to find all the defined services",
 		Severity: ErrorSeverity,
 	},
 	"servicerole.invalid.namespace": {
-		Message:  "KIA0902 ServiceRole can only point to current namespace",
+		Code:     "KIA0902",
+		Message:  "ServiceRole can only point to current namespace",
 		Severity: ErrorSeverity,
 	},
 	"servicerolebinding.invalid.role": {
-		Message:  "KIA0903 ServiceRole does not exists in this namespace",
+		Code:     "KIA0903",
+		Message:  "ServiceRole does not exists in this namespace",
 		Severity: ErrorSeverity,
 	},
 	"sidecar.selector.workloadnotfound": {
-		Message:  "KIA1001 No matching workload found for authorization policy selector in this namespace",
+		Code:     "KIA1001",
+		Message:  "No matching workload found for authorization policy selector in this namespace",
 		Severity: WarningSeverity,
 	},
 	"sidecar.multimatch.selectorless": {
-		Message:  "KIA1002 More than one selector-less Sidecar in the same namespace",
+		Code:     "KIA1002",
+		Message:  "More than one selector-less Sidecar in the same namespace",
 		Severity: ErrorSeverity,
 	},
 	"sidecar.egress.invalidhostformat": {
-		Message:  "KIA1003 Invalid host format. 'namespace/dnsName' format expected",
+		Code:     "KIA1003",
+		Message:  "Invalid host format. 'namespace/dnsName' format expected",
 		Severity: ErrorSeverity,
 	},
 	"sidecar.egress.servicenotfound": {
-		Message:  "KIA1004 This host has no matching entry in the service registry",
+		Code:     "KIA1004",
+		Message:  "This host has no matching entry in the service registry",
 		Severity: WarningSeverity,
 	},
 	"sidecar.multimatch.selector": {
-		Message:  "KIA1005 More than one Sidecar applied to the same workload",
+		Code:     "KIA1005",
+		Message:  "More than one Sidecar applied to the same workload",
 		Severity: ErrorSeverity,
 	},
 	"sidecar.global.selector": {
-		Message:  "KIA1006 Global default sidecar should not have workloadSelector",
+		Code:     "KIA1006",
+		Message:  "Global default sidecar should not have workloadSelector",
+		Severity: WarningSeverity,
+	},
+	"requestauthentication.selector.workloadnotfound": {
+		Code:     "KIA1401",
+		Message:  "No matching workload found for request authentication selector in this namespace",
+		Severity: WarningSeverity,
+	},
+	"requestauthentication.multimatch": {
+		Code:     "KIA1402",
+		Message:  "More than one RequestAuthentication applies to the same workload",
+		Severity: WarningSeverity,
+	},
+	"requestauthentication.jwks.invaliduri": {
+		Code:     "KIA1403",
+		Message:  "jwksUri is not a valid URL",
+		Severity: ErrorSeverity,
+	},
+	"requestauthentication.jwks.nothttps": {
+		Code:     "KIA1404",
+		Message:  "jwksUri should use HTTPS, the keys fetched over plain HTTP can be tampered with",
+		Severity: WarningSeverity,
+	},
+	"requestauthentication.jwks.unreachable": {
+		Code:     "KIA1405",
+		Message:  "No JSON Web Key Set could be fetched from jwksUri",
 		Severity: WarningSeverity,
 	},
 	"virtualservices.nohost.hostnotfound": {
-		Message:  "KIA1101 DestinationWeight on route doesn't have a valid service (host not found)",
+		Code:     "KIA1101",
+		Message:  "DestinationWeight on route doesn't have a valid service (host not found)",
 		Severity: ErrorSeverity,
 	},
 	"virtualservices.nogateway": {
-		Message:  "KIA1102 VirtualService is pointing to a non-existent gateway",
+		Code:     "KIA1102",
+		Message:  "VirtualService is pointing to a non-existent gateway",
 		Severity: ErrorSeverity,
 	},
 	"virtualservices.nohost.invalidprotocol": {
-		Message:  "KIA1103 VirtualService doesn't define any valid route protocol",
+		Code:     "KIA1103",
+		Message:  "VirtualService doesn't define any valid route protocol",
 		Severity: ErrorSeverity,
 	},
 	"virtualservices.route.singleweight": {
-		Message:  "KIA1104 The weight is assumed to be 100 because there is only one route destination",
+		Code:     "KIA1104",
+		Message:  "The weight is assumed to be 100 because there is only one route destination",
 		Severity: WarningSeverity,
 	},
 	"virtualservices.route.repeatedsubset": {
-		Message:  "KIA1105 This subset is already referenced in another route destination",
+		Code:     "KIA1105",
+		Message:  "This subset is already referenced in another route destination",
 		Severity: WarningSeverity,
 	},
 	"virtualservices.singlehost": {
-		Message:  "KIA1106 More than one Virtual Service for same host",
+		Code:     "KIA1106",
+		Message:  "More than one Virtual Service for same host",
 		Severity: WarningSeverity,
 	},
 	"virtualservices.subsetpresent.subsetnotfound": {
-		Message:  "KIA1107 Subset not found",
+		Code:     "KIA1107",
+		Message:  "Subset not found",
 		Severity: WarningSeverity,
 	},
 	"virtualservices.subsetpresent.destinationmandatory": {
-		Message:  "KIA1108 Destination field is mandatory",
+		Code:     "KIA1108",
+		Message:  "Destination field is mandatory",
 		Severity: ErrorSeverity,
 	},
+	"virtualservices.match.catchallnotlast": {
+		Code:     "KIA1109",
+		Message:  "Route without match conditions is not the last one, the routes after it are unreachable",
+		Severity: WarningSeverity,
+	},
+	"virtualservices.match.invalidregex": {
+		Code:     "KIA1110",
+		Message:  "Regular expression does not compile",
+		Severity: ErrorSeverity,
+	},
+	"virtualservices.match.unreachable": {
+		Code:     "KIA1111",
+		Message:  "Route is unreachable, the requests it matches are all matched by earlier routes",
+		Severity: WarningSeverity,
+	},
+	"virtualservices.match.shadowed": {
+		Code:     "KIA1112",
+		Message:  "Match condition is shadowed, the requests it matches are all matched by earlier routes",
+		Severity: WarningSeverity,
+	},
 	"validation.unable.cross-namespace": {
-		Message:  "KIA0001 Unable to verify the validity, cross-namespace validation is not supported for this field",
+		Code:     "KIA0001",
+		Message:  "Unable to verify the validity, cross-namespace validation is not supported for this field",
 		Severity: Unknown,
 	},
 }
 
 func Build(checkId string, path string) IstioCheck {
 	check := checkDescriptors[checkId]
+	check.Message = CheckMessage(checkId)
 	check.Path = path
 	return check
 }
@@ -271,8 +411,15 @@ func BuildKey(objectType, name, namespace string) IstioValidationKey {
 	return IstioValidationKey{ObjectType: objectType, Namespace: namespace, Name: name}
 }
 
+// CheckMessage returns the message of a check prefixed by its code, i.e. KIA1107 Subset not found
 func CheckMessage(checkId string) string {
-	return checkDescriptors[checkId].Message
+	check := checkDescriptors[checkId]
+	return check.Code + " " + check.Message
+}
+
+// CheckCode returns the code of a check, i.e. KIA1107
+func CheckCode(checkId string) string {
+	return checkDescriptors[checkId].Code
 }
 
 func (iv IstioValidations) FilterBySingleType(objectType, name string) IstioValidations {
@@ -337,6 +484,7 @@ func (iv IstioValidations) MergeValidations(validations IstioValidations) IstioV
 				v.Checks = append(v.Checks, toAdd)
 			}
 			v.Valid = v.Valid && validation.Valid
+			v.SuppressedChecks = append(v.SuppressedChecks, validation.SuppressedChecks...)
 		AddUniqueReference:
 			for _, toAdd := range validation.References {
 				for _, existing := range v.References {
@@ -351,6 +499,32 @@ func (iv IstioValidations) MergeValidations(validations IstioValidations) IstioV
 	return iv
 }
 
+// SuppressChecks moves the checks for which ignored returns true from Checks to SuppressedChecks,
+// the validity of the objects is updated accordingly
+func (iv IstioValidations) SuppressChecks(ignored func(key IstioValidationKey, check *IstioCheck) bool) IstioValidations {
+	for key, validation := range iv {
+		checks := make([]*IstioCheck, 0, len(validation.Checks))
+		for _, check := range validation.Checks {
+			if ignored(key, check) {
+				validation.SuppressedChecks = append(validation.SuppressedChecks, check)
+			} else {
+				checks = append(checks, check)
+			}
+		}
+		if len(checks) == len(validation.Checks) {
+			continue
+		}
+		validation.Checks = checks
+		validation.Valid = true
+		for _, check := range checks {
+			if check.Severity == ErrorSeverity {
+				validation.Valid = false
+			}
+		}
+	}
+	return iv
+}
+
 func (iv IstioValidations) MergeReferences(validations IstioValidations) IstioValidations {
 	for _, currentValidations := range iv {
 		if currentValidations.References == nil {
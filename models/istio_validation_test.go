@@ -69,3 +69,36 @@ func TestSummarizeValidations(t *testing.T) {
 	assert.Equal(2, summary.Errors)
 	assert.Equal(2, summary.Errors)
 }
+
+func TestBuildCheckCode(t *testing.T) {
+	assert := assert.New(t)
+
+	check := Build("virtualservices.subsetpresent.subsetnotfound", "spec/http[0]")
+	assert.Equal("KIA1107", check.Code)
+	assert.Equal("KIA1107 Subset not found", check.Message)
+	assert.Equal("KIA1107", CheckCode("virtualservices.subsetpresent.subsetnotfound"))
+	assert.Equal(check.Message, CheckMessage("virtualservices.subsetpresent.subsetnotfound"))
+}
+
+func TestSuppressChecks(t *testing.T) {
+	assert := assert.New(t)
+
+	hostNotFound := Build("virtualservices.nohost.hostnotfound", "spec/http[0]")
+	singleHost := Build("virtualservices.singlehost", "spec/hosts")
+	key := IstioValidationKey{ObjectType: "virtualservice", Namespace: "bookinfo", Name: "reviews"}
+	validations := IstioValidations{
+		key: &IstioValidation{
+			Name:       "reviews",
+			ObjectType: "virtualservice",
+			Valid:      false,
+			Checks:     []*IstioCheck{&hostNotFound, &singleHost},
+		},
+	}
+
+	validations.SuppressChecks(func(k IstioValidationKey, check *IstioCheck) bool {
+		return k == key && check.Code == "KIA1101"
+	})
+	assert.True(validations[key].Valid)
+	assert.Equal([]*IstioCheck{&singleHost}, validations[key].Checks)
+	assert.Equal([]*IstioCheck{&hostNotFound}, validations[key].SuppressedChecks)
+}
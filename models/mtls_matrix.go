@@ -0,0 +1,93 @@
+package models
+
+// MTLSMatrix is the effective mTLS status of the traffic between the workloads of a set of namespaces
+// swagger:model MTLSMatrix
+type MTLSMatrix struct {
+	// Whether the auto mTLS is enabled in the mesh
+	// required: true
+	AutoMTLS bool `json:"autoMtls"`
+
+	// Effective mTLS modes accepted by the workloads
+	// required: true
+	Workloads []WorkloadMTLS `json:"workloads"`
+
+	// Status of the traffic from each source workload to each port of the destination workloads
+	// required: true
+	Cells []MTLSMatrixCell `json:"cells"`
+}
+
+// WorkloadMTLS is the effective mTLS mode accepted by a workload, resolved from the PeerAuthentications
+// selecting the workload, then of its namespace, then of the mesh
+// swagger:model WorkloadMTLS
+type WorkloadMTLS struct {
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+
+	// required: true
+	// example: reviews-v1
+	Workload string `json:"workload"`
+
+	// Whether the workload has a sidecar. A workload without sidecar only accepts plain text.
+	// required: true
+	IstioSidecar bool `json:"istioSidecar"`
+
+	// Mode accepted by the workload: STRICT, PERMISSIVE or DISABLE
+	// required: true
+	// example: STRICT
+	Mode string `json:"mode"`
+
+	// PeerAuthentication defining the mode, as namespace/name. Empty when the default PERMISSIVE mode applies.
+	// example: bookinfo/default
+	PeerAuthentication string `json:"peerAuthentication,omitempty"`
+
+	// Modes overridden by the workload PeerAuthentication, by port
+	PortModes map[uint32]string `json:"portModes,omitempty"`
+}
+
+// MTLSMatrixCell is the mTLS status of the traffic from a workload to a port of a service backed by a workload
+// swagger:model MTLSMatrixCell
+type MTLSMatrixCell struct {
+	// required: true
+	SourceNamespace string `json:"sourceNamespace"`
+
+	// required: true
+	SourceWorkload string `json:"sourceWorkload"`
+
+	// required: true
+	DestinationNamespace string `json:"destinationNamespace"`
+
+	// required: true
+	DestinationWorkload string `json:"destinationWorkload"`
+
+	// required: true
+	DestinationService string `json:"destinationService"`
+
+	// Port of the destination service
+	// required: true
+	Port uint32 `json:"port"`
+
+	// Mode accepted by the destination on the port: STRICT, PERMISSIVE or DISABLE
+	// required: true
+	ServerMode string `json:"serverMode"`
+
+	// TLS mode used by the source: ISTIO_MUTUAL, MUTUAL, SIMPLE or DISABLE
+	// required: true
+	ClientMode string `json:"clientMode"`
+
+	// DestinationRule defining the TLS mode of the source, as namespace/name. Empty when the mode comes from
+	// the auto mTLS or the source has no sidecar.
+	DestinationRule string `json:"destinationRule,omitempty"`
+
+	// Expected status: MTLS_ENABLED, MTLS_DISABLED or MTLS_CONFLICT when the destination rejects the traffic
+	// required: true
+	// example: MTLS_ENABLED
+	Status string `json:"status"`
+
+	// Security policies observed in the telemetry for the traffic: mutual_tls or none
+	Observed []string `json:"observed,omitempty"`
+
+	// Whether the observed policies differ from the expected status
+	// required: true
+	Mismatch bool `json:"mismatch"`
+}
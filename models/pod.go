@@ -24,6 +24,8 @@ type Pod struct {
 	AppLabel            bool              `json:"appLabel"`
 	VersionLabel        bool              `json:"versionLabel"`
 	Annotations         map[string]string `json:"annotations"`
+	// Sync status of the proxy with istiod, nil when unknown
+	ProxyStatus *ProxyStatus `json:"proxyStatus,omitempty"`
 }
 
 // Reference holds some information on the pod creator
@@ -0,0 +1,119 @@
+package models
+
+import "time"
+
+// ProxyCertificates are the certificates loaded by the proxy of a pod
+// swagger:model ProxyCertificates
+type ProxyCertificates struct {
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+
+	// required: true
+	// example: reviews-v1-545db77b95-2bqfl
+	Pod string `json:"pod"`
+
+	// SPIFFE identity of the workload certificate
+	// example: spiffe://cluster.local/ns/bookinfo/sa/bookinfo-reviews
+	SpiffeID string `json:"spiffeId"`
+
+	// Trust domain of the SPIFFE identity
+	// example: cluster.local
+	TrustDomain string `json:"trustDomain"`
+
+	// Issuer of the workload certificate
+	// example: O=cluster.local
+	Issuer string `json:"issuer"`
+
+	// Start of the validity of the workload certificate
+	ValidFrom time.Time `json:"validFrom"`
+
+	// End of the validity of the workload certificate
+	ValidTo time.Time `json:"validTo"`
+
+	// Seconds until the workload certificate expires, negative when expired
+	// example: 86120
+	ExpiresIn int64 `json:"expiresIn"`
+
+	// SHA-256 fingerprint of the root certificate trusted by the proxy
+	// example: 5A:0C:...:9E
+	RootCAFingerprint string `json:"rootCaFingerprint"`
+
+	// Workload certificate chain, leaf first
+	// required: true
+	Chain []CertificateInfo `json:"chain"`
+
+	// Root certificates trusted by the proxy
+	// required: true
+	RootCAs []CertificateInfo `json:"rootCas"`
+}
+
+// CertificateInfo is a certificate of a chain
+type CertificateInfo struct {
+	// required: true
+	Subject string `json:"subject"`
+
+	// required: true
+	Issuer string `json:"issuer"`
+
+	// required: true
+	SerialNumber string `json:"serialNumber"`
+
+	// URI subject alternative names, i.e. the SPIFFE identities
+	URIs []string `json:"uris,omitempty"`
+
+	// required: true
+	ValidFrom time.Time `json:"validFrom"`
+
+	// required: true
+	ValidTo time.Time `json:"validTo"`
+
+	// required: true
+	IsCA bool `json:"isCa"`
+
+	// SHA-256 fingerprint of the certificate
+	// required: true
+	Fingerprint string `json:"fingerprint"`
+}
+
+// NamespaceCertificates is the certificate status of the proxies of a namespace
+// swagger:model NamespaceCertificates
+type NamespaceCertificates struct {
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+
+	// Trust domain expected in the SPIFFE identities, from the Istio identity domain
+	// required: true
+	// example: cluster.local
+	TrustDomain string `json:"trustDomain"`
+
+	// Seconds before expiry from which a certificate is flagged as expiring soon
+	// required: true
+	// example: 3600
+	ExpiringWithin int64 `json:"expiringWithin"`
+
+	// required: true
+	Pods []PodCertificatesStatus `json:"pods"`
+}
+
+// PodCertificatesStatus is the certificate status of the proxy of a pod
+type PodCertificatesStatus struct {
+	// required: true
+	// example: reviews-v1-545db77b95-2bqfl
+	Pod string `json:"pod"`
+
+	// Certificates of the proxy, nil when they could not be retrieved
+	Certificates *ProxyCertificates `json:"certificates"`
+
+	// Error retrieving the certificates
+	Error string `json:"error,omitempty"`
+
+	// Whether the workload certificate expires within the expiring window
+	// required: true
+	ExpiringSoon bool `json:"expiringSoon"`
+
+	// Whether the trust domain of the SPIFFE identity differs from the expected one
+	// required: true
+	TrustDomainMismatch bool `json:"trustDomainMismatch"`
+}
@@ -0,0 +1,178 @@
+package models
+
+// ProxyListener is a listener of the proxy of a pod
+// swagger:model ProxyListener
+type ProxyListener struct {
+	// required: true
+	// example: virtualInbound
+	Name string `json:"name"`
+
+	// required: true
+	// example: 0.0.0.0
+	Address string `json:"address"`
+
+	// required: true
+	// example: 15006
+	Port uint32 `json:"port"`
+
+	// INBOUND, OUTBOUND or empty when not set
+	// example: INBOUND
+	TrafficDirection string `json:"trafficDirection,omitempty"`
+
+	// required: true
+	FilterChains []ProxyFilterChain `json:"filterChains"`
+}
+
+// ProxyFilterChain is a filter chain of a listener, with the destination of the traffic it matches
+type ProxyFilterChain struct {
+	// Destination port matched by the chain, 0 when any
+	// example: 9080
+	DestinationPort uint32 `json:"destinationPort,omitempty"`
+
+	// Server names (SNI) matched by the chain
+	ServerNames []string `json:"serverNames,omitempty"`
+
+	// example: tls
+	TransportProtocol string `json:"transportProtocol,omitempty"`
+
+	// Route configuration of the HTTP traffic
+	// example: 9080
+	RouteConfig string `json:"routeConfig,omitempty"`
+
+	// Clusters of the TCP traffic
+	Clusters []string `json:"clusters,omitempty"`
+}
+
+// ProxyRoute is a route of a route configuration of the proxy of a pod
+// swagger:model ProxyRoute
+type ProxyRoute struct {
+	// required: true
+	// example: 9080
+	RouteConfig string `json:"routeConfig"`
+
+	// required: true
+	// example: reviews.bookinfo.svc.cluster.local:9080
+	VirtualHost string `json:"virtualHost"`
+
+	// required: true
+	Domains []string `json:"domains"`
+
+	// example: reviews-v2
+	Name string `json:"name,omitempty"`
+
+	// Match of the route, as <type> <value>
+	// required: true
+	// example: prefix /
+	Match string `json:"match"`
+
+	// Weighted clusters the requests are routed to. Empty for redirects and direct responses.
+	// required: true
+	Destinations []ProxyRouteDestination `json:"destinations"`
+
+	// Action of the routes without destinations: redirect or direct_response
+	Action string `json:"action,omitempty"`
+
+	// VirtualService defining the route, as namespace/name. Empty for the default routes.
+	// example: bookinfo/reviews
+	VirtualService string `json:"virtualService,omitempty"`
+}
+
+// ProxyRouteDestination is a cluster a route sends requests to
+type ProxyRouteDestination struct {
+	// required: true
+	// example: outbound|9080|v2|reviews.bookinfo.svc.cluster.local
+	Cluster string `json:"cluster"`
+
+	// required: true
+	// example: 100
+	Weight uint32 `json:"weight"`
+}
+
+// ProxyCluster is a cluster of the proxy of a pod
+// swagger:model ProxyCluster
+type ProxyCluster struct {
+	// required: true
+	// example: outbound|9080|v2|reviews.bookinfo.svc.cluster.local
+	Name string `json:"name"`
+
+	// inbound or outbound, empty for the clusters not named by Istio
+	// example: outbound
+	Direction string `json:"direction,omitempty"`
+
+	// example: reviews.bookinfo.svc.cluster.local
+	FQDN string `json:"fqdn,omitempty"`
+
+	// example: 9080
+	Port uint32 `json:"port,omitempty"`
+
+	// example: v2
+	Subset string `json:"subset,omitempty"`
+
+	// Discovery type: EDS, STATIC, STRICT_DNS, ORIGINAL_DST...
+	// example: EDS
+	Type string `json:"type,omitempty"`
+
+	// example: 10s
+	ConnectTimeout string `json:"connectTimeout,omitempty"`
+
+	// Circuit breaker thresholds, by priority
+	CircuitBreakers []CircuitBreakerThresholds `json:"circuitBreakers,omitempty"`
+
+	OutlierDetection *OutlierDetection `json:"outlierDetection,omitempty"`
+
+	// DestinationRule defining the cluster settings, as namespace/name
+	// example: bookinfo/reviews
+	DestinationRule string `json:"destinationRule,omitempty"`
+}
+
+// CircuitBreakerThresholds are the circuit breaker thresholds of a cluster for a priority
+type CircuitBreakerThresholds struct {
+	// DEFAULT or HIGH
+	// example: DEFAULT
+	Priority string `json:"priority"`
+
+	MaxConnections     *uint32 `json:"maxConnections,omitempty"`
+	MaxPendingRequests *uint32 `json:"maxPendingRequests,omitempty"`
+	MaxRequests        *uint32 `json:"maxRequests,omitempty"`
+	MaxRetries         *uint32 `json:"maxRetries,omitempty"`
+}
+
+// OutlierDetection is the outlier detection of a cluster
+type OutlierDetection struct {
+	Consecutive5xx     *uint32 `json:"consecutive5xx,omitempty"`
+	Interval           string  `json:"interval,omitempty"`
+	BaseEjectionTime   string  `json:"baseEjectionTime,omitempty"`
+	MaxEjectionPercent *uint32 `json:"maxEjectionPercent,omitempty"`
+}
+
+// ProxyEndpoint is an endpoint of a cluster of the proxy of a pod, with its health
+// swagger:model ProxyEndpoint
+type ProxyEndpoint struct {
+	// required: true
+	// example: outbound|9080|v2|reviews.bookinfo.svc.cluster.local
+	Cluster string `json:"cluster"`
+
+	// required: true
+	// example: 10.1.0.12
+	Address string `json:"address"`
+
+	// required: true
+	// example: 9080
+	Port uint32 `json:"port"`
+
+	// HEALTHY or UNHEALTHY, from the service discovery, the active health checks and the outlier detection
+	// required: true
+	// example: HEALTHY
+	Health string `json:"health"`
+
+	// Health reported by the service discovery
+	// example: HEALTHY
+	EdsHealthStatus string `json:"edsHealthStatus,omitempty"`
+
+	// Whether the endpoint is ejected by the outlier detection
+	// required: true
+	OutlierEjected bool `json:"outlierEjected"`
+
+	// example: 1
+	Weight uint32 `json:"weight,omitempty"`
+}
@@ -0,0 +1,73 @@
+package models
+
+// Sync status of a xDS type of a proxy
+const (
+	ProxySynced  = "SYNCED"
+	ProxyStale   = "STALE"
+	ProxyNotSent = "NOT SENT"
+)
+
+// ProxyStatus is the sync status of the configuration of a proxy with istiod, by xDS type
+// swagger:model ProxyStatus
+type ProxyStatus struct {
+	// istiod pod the proxy is connected to
+	// required: true
+	// example: istiod-5c8c5b8c8-7xkzp
+	Istiod string `json:"istiod"`
+
+	// example: 1.7.0
+	IstioVersion string `json:"istioVersion,omitempty"`
+
+	// Clusters: SYNCED, STALE or NOT SENT
+	// required: true
+	// example: SYNCED
+	CDS string `json:"cds"`
+
+	// Listeners: SYNCED, STALE or NOT SENT
+	// required: true
+	// example: SYNCED
+	LDS string `json:"lds"`
+
+	// Endpoints: SYNCED, STALE or NOT SENT
+	// required: true
+	// example: SYNCED
+	EDS string `json:"eds"`
+
+	// Routes: SYNCED, STALE or NOT SENT
+	// required: true
+	// example: STALE
+	RDS string `json:"rds"`
+}
+
+// IsStale returns true when istiod sent a configuration not acknowledged by the proxy
+func (ps ProxyStatus) IsStale() bool {
+	return ps.CDS == ProxyStale || ps.LDS == ProxyStale || ps.EDS == ProxyStale || ps.RDS == ProxyStale
+}
+
+// NamespaceProxyStatus is the sync status of the proxies of a namespace
+// swagger:model NamespaceProxyStatus
+type NamespaceProxyStatus struct {
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+
+	// required: true
+	Proxies []PodProxyStatus `json:"proxies"`
+
+	// Workloads with a proxy not acknowledging its configuration
+	// required: true
+	StaleWorkloads []string `json:"staleWorkloads"`
+}
+
+// PodProxyStatus is the sync status of the proxy of a pod
+type PodProxyStatus struct {
+	ProxyStatus
+
+	// required: true
+	// example: reviews-v1-545db77b95-2bqfl
+	Pod string `json:"pod"`
+
+	// Workload of the pod, empty when the pod has no known controller
+	// example: reviews-v1
+	Workload string `json:"workload,omitempty"`
+}
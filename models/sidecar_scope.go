@@ -0,0 +1,101 @@
+package models
+
+import (
+	"strings"
+)
+
+// Levels of the Sidecar applied to a workload, by precedence
+const (
+	SidecarScopeWorkload  = "workload"  // Sidecar of the workload namespace selecting the workload
+	SidecarScopeNamespace = "namespace" // Sidecar of the workload namespace without workloadSelector
+	SidecarScopeRoot      = "root"      // Sidecar of the root namespace without workloadSelector, the mesh default
+)
+
+// SidecarScope is the effective Sidecar of a workload, the one configuring its proxy, and what it makes reachable
+// 工作负载实际生效的 Sidecar 以及它可以访问的服务
+// swagger:model SidecarScope
+type SidecarScope struct {
+	Namespace string `json:"namespace"`
+	Workload  string `json:"workload"`
+	// Sidecar applied to the workload, nil when no Sidecar applies and every service of the mesh is reachable
+	Sidecar *SidecarScopeSidecar `json:"sidecar"`
+	// Other Sidecars selecting the workload at the same level, ignored by Istio
+	Conflicts []string `json:"conflicts,omitempty"`
+	// REGISTRY_ONLY or ALLOW_ANY, when set by the Sidecar
+	OutboundTrafficPolicy string `json:"outboundTrafficPolicy,omitempty"`
+	// Egress hosts of the Sidecar, namespace/dnsName
+	Hosts          []SidecarScopeHost         `json:"hosts"`
+	Services       []SidecarScopeService      `json:"services"`
+	ServiceEntries []SidecarScopeServiceEntry `json:"serviceEntries"`
+}
+
+type SidecarScopeSidecar struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Level     string `json:"level"` // workload, namespace or root
+}
+
+// SidecarScopeHost is an egress host of the Sidecar, it is not valid when it has a wrong format or matches nothing
+type SidecarScopeHost struct {
+	Host    string `json:"host"`
+	Valid   bool   `json:"valid"`
+	Message string `json:"message,omitempty"`
+}
+
+type SidecarScopeService struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Host      string `json:"host"`
+}
+
+type SidecarScopeServiceEntry struct {
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace"`
+	Hosts     []string `json:"hosts"`
+}
+
+// IsReachable returns true when the host of a service, or of a ServiceEntry, of the namespace is in the scope
+func (s SidecarScope) IsReachable(namespace, host string) bool {
+	if s.Sidecar == nil {
+		return true
+	}
+	for _, h := range s.Hosts {
+		if SidecarHostMatches(h.Host, s.Namespace, namespace, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// SidecarHostMatches returns true when the Sidecar egress host, namespace/dnsName, of a workload of workloadNamespace
+// matches the host of a service, or of a ServiceEntry, of namespace. As in Istio, "." is the namespace of the
+// workload, "*" any namespace and "~" none; the dnsName is matched literally, or as a suffix for "*." wildcards.
+func SidecarHostMatches(egressHost, workloadNamespace, namespace, host string) bool {
+	parts := strings.Split(egressHost, "/")
+	if len(parts) != 2 {
+		return false
+	}
+	hostNs, dnsName := parts[0], parts[1]
+
+	switch hostNs {
+	case "*":
+	case "~":
+		return false
+	case ".":
+		if namespace != workloadNamespace {
+			return false
+		}
+	default:
+		if namespace != hostNs {
+			return false
+		}
+	}
+
+	if dnsName == "*" {
+		return true
+	}
+	if strings.HasPrefix(dnsName, "*.") {
+		return strings.HasSuffix(host, dnsName[1:])
+	}
+	return host == dnsName
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSidecarHostMatches(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(SidecarHostMatches("*/*", "bookinfo", "other", "reviews.other.svc.cluster.local"))
+	assert.True(SidecarHostMatches("./*", "bookinfo", "bookinfo", "reviews.bookinfo.svc.cluster.local"))
+	assert.False(SidecarHostMatches("./*", "bookinfo", "other", "reviews.other.svc.cluster.local"))
+	assert.True(SidecarHostMatches("other/reviews.other.svc.cluster.local", "bookinfo", "other", "reviews.other.svc.cluster.local"))
+	assert.False(SidecarHostMatches("other/reviews.other.svc.cluster.local", "bookinfo", "other", "ratings.other.svc.cluster.local"))
+	assert.True(SidecarHostMatches("*/*.example.com", "bookinfo", "external", "api.example.com"))
+	assert.False(SidecarHostMatches("*/*.example.com", "bookinfo", "external", "example.com"))
+	assert.False(SidecarHostMatches("~/*", "bookinfo", "bookinfo", "reviews.bookinfo.svc.cluster.local"))
+	assert.False(SidecarHostMatches("reviews", "bookinfo", "bookinfo", "reviews"))
+}
@@ -0,0 +1,92 @@
+package models
+
+// TrafficRoutingRequest describes the routing of the traffic of a service to its versions. The Istio config
+// generated from it is a DestinationRule with a subset per version and a VirtualService.
+// swagger:model TrafficRoutingRequest
+type TrafficRoutingRequest struct {
+	// Routes matching the requests by headers or a cookie, evaluated in order before the weighted routes
+	MatchRoutes []MatchRoute `json:"matchRoutes,omitempty"`
+	// Percentage of the remaining traffic sent to each version, the weights must sum 100
+	WeightedRoutes []WeightedRoute `json:"weightedRoutes,omitempty"`
+	// Fault injected in all the routes
+	FaultInjection *FaultInjection `json:"faultInjection,omitempty"`
+	// Timeout of the requests of all the routes, as a duration like 2s
+	Timeout string `json:"timeout,omitempty"`
+	// Retries of the requests of all the routes
+	Retries *RetryPolicy `json:"retries,omitempty"`
+}
+
+// WeightedRoute is the percentage of the traffic sent to a version
+type WeightedRoute struct {
+	Version string `json:"version"`
+	Weight  int    `json:"weight"`
+}
+
+// MatchRoute sends the requests matching all the headers and the cookie to a version
+type MatchRoute struct {
+	Version string        `json:"version"`
+	Headers []HeaderMatch `json:"headers,omitempty"`
+	Cookie  *CookieMatch  `json:"cookie,omitempty"`
+}
+
+// HeaderMatch matches a request header, only one of exact, prefix or regex is set
+type HeaderMatch struct {
+	Name   string `json:"name"`
+	Exact  string `json:"exact,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
+	Regex  string `json:"regex,omitempty"`
+}
+
+// CookieMatch matches a cookie of a request
+type CookieMatch struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// FaultInjection delays or aborts a percentage of the requests
+type FaultInjection struct {
+	Delay *DelayFault `json:"delay,omitempty"`
+	Abort *AbortFault `json:"abort,omitempty"`
+}
+
+// DelayFault delays a percentage of the requests by a fixed duration, like 5s
+type DelayFault struct {
+	Percentage float64 `json:"percentage"`
+	FixedDelay string  `json:"fixedDelay"`
+}
+
+// AbortFault responds to a percentage of the requests with an HTTP status
+type AbortFault struct {
+	Percentage float64 `json:"percentage"`
+	HttpStatus int     `json:"httpStatus"`
+}
+
+// RetryPolicy retries the failed requests
+type RetryPolicy struct {
+	Attempts      int    `json:"attempts"`
+	PerTryTimeout string `json:"perTryTimeout,omitempty"`
+	RetryOn       string `json:"retryOn,omitempty"`
+}
+
+// TrafficRouting is the Istio config generated for a TrafficRoutingRequest, with its validations
+// swagger:model TrafficRouting
+type TrafficRouting struct {
+	DestinationRule *DestinationRule `json:"destinationRule"`
+	VirtualService  *VirtualService  `json:"virtualService"`
+	// Validations of the generated objects against the current state of the namespace
+	Validations IstioValidations `json:"validations"`
+	// True when the objects have been created
+	Applied bool `json:"applied"`
+	// Changes attempted on the cluster while applying the routing
+	Changes []TrafficRoutingChange `json:"-"`
+}
+
+// TrafficRoutingChange is a create or a delete of an Istio object attempted while applying a TrafficRouting
+type TrafficRoutingChange struct {
+	Operation  string
+	ObjectType string
+	Name       string
+	// Object is the JSON of the created object, empty for a delete
+	Object []byte
+	Error  error
+}
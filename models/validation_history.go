@@ -0,0 +1,62 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// ValidationFinding is a check reported on an Istio object, tracked from the run it first appeared in
+// until the run it is no longer reported.
+// 校验历史中的一条问题记录: 首次出现时间与解决时间
+// swagger:model ValidationFinding
+type ValidationFinding struct {
+	Namespace  string        `json:"namespace"`
+	ObjectType string        `json:"objectType"`
+	Name       string        `json:"name"`
+	Code       string        `json:"code"`
+	Message    string        `json:"message"`
+	Severity   SeverityLevel `json:"severity"`
+	Path       string        `json:"path"`
+	FirstSeen  time.Time     `json:"firstSeen"`
+	LastSeen   time.Time     `json:"lastSeen"`
+	// Time of the first run not reporting the check, nil while it is still reported
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+}
+
+// NewValidationFinding returns the finding of a check of an object, first seen at the given time
+func NewValidationFinding(key IstioValidationKey, check *IstioCheck, at time.Time) ValidationFinding {
+	return ValidationFinding{
+		Namespace:  key.Namespace,
+		ObjectType: key.ObjectType,
+		Name:       key.Name,
+		Code:       check.Code,
+		Message:    check.Message,
+		Severity:   check.Severity,
+		Path:       check.Path,
+		FirstSeen:  at,
+		LastSeen:   at,
+	}
+}
+
+// Id identifies the finding across runs: the object, the check and its path. Checks without
+// code are identified by their message.
+func (f ValidationFinding) Id() string {
+	check := f.Code
+	if check == "" {
+		check = f.Message
+	}
+	return strings.Join([]string{f.ObjectType, f.Name, check, f.Path}, "/")
+}
+
+// ValidationSummarySample is the validation summary of a namespace at a given run
+type ValidationSummarySample struct {
+	Time time.Time `json:"time"`
+	IstioValidationSummary
+}
+
+// ValidationTrend is the validation summary of a namespace over time, oldest sample first
+// swagger:model ValidationTrend
+type ValidationTrend struct {
+	Namespace string                    `json:"namespace"`
+	Samples   []ValidationSummarySample `json:"samples"`
+}
@@ -70,3 +70,26 @@ func (vService *VirtualService) IsValidHost(namespace string, serviceName string
 
 	return kubernetes.FilterByRoute(protocols, protocolNames, serviceName, namespace, nil)
 }
+
+// HasFaultInjection returns true if any http route of the VirtualService injects delays or aborts
+func (vService *VirtualService) HasFaultInjection() bool {
+	return vService.hasHttpRouteField("fault")
+}
+
+// HasRetries returns true if any http route of the VirtualService sets a retry policy
+func (vService *VirtualService) HasRetries() bool {
+	return vService.hasHttpRouteField("retries")
+}
+
+func (vService *VirtualService) hasHttpRouteField(field string) bool {
+	routes, ok := vService.Spec.Http.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, r := range routes {
+		if route, ok := r.(map[string]interface{}); ok && route[field] != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -23,6 +23,7 @@ type ClientInterface interface {
 	GetAllRequestRates(namespace, ratesInterval string, queryTime time.Time) (model.Vector, error)
 	GetAppRequestRates(namespace, app, ratesInterval string, queryTime time.Time) (model.Vector, model.Vector, error)
 	GetConfiguration() (prom_v1.ConfigResult, error)
+	GetControlPlaneMetrics(ratesInterval string, queryTime time.Time) (map[string]model.Vector, error)
 	GetFlags() (prom_v1.FlagsResult, error)
 	GetMetrics(query *IstioMetricsQuery) Metrics
 	GetNamespaceServicesRequestRates(namespace, ratesInterval string, queryTime time.Time) (model.Vector, error)
@@ -137,6 +138,13 @@ func (in *Client) GetWorkloadRequestRates(namespace, workload, ratesInterval str
 	return getItemRequestRates(in.api, namespace, workload, "workload", queryTime, ratesInterval)
 }
 
+// GetControlPlaneMetrics queries Prometheus to fetch the health metrics of the istiod instances, over a time
+// interval for the counters.
+// Returns (vectors by control plane metric, error)
+func (in *Client) GetControlPlaneMetrics(ratesInterval string, queryTime time.Time) (map[string]model.Vector, error) {
+	return getControlPlaneMetrics(in.api, queryTime, ratesInterval)
+}
+
 // FetchRange fetches a simple metric (gauge or counter) in given range
 func (in *Client) FetchRange(metricName, labels, grouping, aggregator string, q *BaseMetricsQuery) *Metric {
 	query := fmt.Sprintf("%s(%s%s)", aggregator, metricName, labels)
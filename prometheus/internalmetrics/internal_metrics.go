@@ -21,6 +21,7 @@ const (
 	labelPackage          = "package"
 	labelType             = "type"
 	labelFunction         = "function"
+	labelNamespace        = "namespace"
 )
 
 // MetricsType defines all of Kiali's own internal metrics.
@@ -34,6 +35,8 @@ type MetricsType struct {
 	GoFunctionProcessingTime *prometheus.HistogramVec
 	GoFunctionFailures       *prometheus.CounterVec
 	KubernetesClients        *prometheus.GaugeVec
+	ValidationErrors         *prometheus.GaugeVec
+	ValidationWarnings       *prometheus.GaugeVec
 }
 
 // Metrics contains all of Kiali's own internal metrics.
@@ -103,6 +106,20 @@ var Metrics = MetricsType{
 		},
 		[]string{},
 	),
+	ValidationErrors: prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kiali_validation_errors",
+			Help: "The number of Istio config validation errors of a namespace, as of the last periodic validation.",
+		},
+		[]string{labelNamespace},
+	),
+	ValidationWarnings: prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kiali_validation_warnings",
+			Help: "The number of Istio config validation warnings of a namespace, as of the last periodic validation.",
+		},
+		[]string{labelNamespace},
+	),
 }
 
 // SuccessOrFailureMetricType let's you capture metrics for both successes and failures,
@@ -160,6 +177,8 @@ func RegisterInternalMetrics() {
 		Metrics.GoFunctionProcessingTime,
 		Metrics.GoFunctionFailures,
 		Metrics.KubernetesClients,
+		Metrics.ValidationErrors,
+		Metrics.ValidationWarnings,
 	)
 }
 
@@ -281,3 +300,9 @@ func GetGoFunctionMetric(goPkg string, goType string, goFunc string) SuccessOrFa
 func SetKubernetesClients(clientCount int) {
 	Metrics.KubernetesClients.With(prometheus.Labels{}).Set(float64(clientCount))
 }
+
+// SetValidationSummary sets the validation error and warning counts of a namespace
+func SetValidationSummary(namespace string, errors int, warnings int) {
+	Metrics.ValidationErrors.With(prometheus.Labels{labelNamespace: namespace}).Set(float64(errors))
+	Metrics.ValidationWarnings.With(prometheus.Labels{labelNamespace: namespace}).Set(float64(warnings))
+}
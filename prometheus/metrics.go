@@ -269,6 +269,47 @@ func getRequestRatesForLabel(api prom_v1.API, time time.Time, labels, ratesInter
 	return result.(model.Vector), nil
 }
 
+// Health metrics of the control plane, reported by each istiod instance
+const (
+	ControlPlaneProxies              = "proxies"
+	ControlPlanePushes               = "pushes"
+	ControlPlanePushErrors           = "pushErrors"
+	ControlPlaneRejectedConfigs      = "rejectedConfigs"
+	ControlPlaneConflictingListeners = "conflictingListeners"
+	ControlPlaneInjectionFailures    = "injectionFailures"
+)
+
+// controlPlaneQueries are the queries of the control plane metrics, grouped by scraped instance as the istiod pods
+// may not be labelled with their name depending on the scrape configuration
+var controlPlaneQueries = map[string]string{
+	ControlPlaneProxies:              `sum(pilot_xds) by (instance)`,
+	ControlPlanePushes:               `sum(rate(pilot_xds_pushes[%s])) by (instance)`,
+	ControlPlanePushErrors:           `sum(rate(pilot_xds_pushes{type=~".+_senderr"}[%s])) by (instance)`,
+	ControlPlaneRejectedConfigs:      `sum(rate(pilot_total_xds_rejects[%s])) by (instance)`,
+	ControlPlaneConflictingListeners: `sum({__name__=~"pilot_conflict_(inbound|outbound)_listener.*"}) by (instance)`,
+	ControlPlaneInjectionFailures:    `sum(rate(sidecar_injection_failure_total[%s])) by (instance)`,
+}
+
+func getControlPlaneMetrics(api prom_v1.API, queryTime time.Time, ratesInterval string) (map[string]model.Vector, error) {
+	if _, err := model.ParseDuration(ratesInterval); err != nil {
+		return nil, fmt.Errorf("invalid rate interval [%s]: %v", ratesInterval, err)
+	}
+	result := make(map[string]model.Vector, len(controlPlaneQueries))
+	for name, query := range controlPlaneQueries {
+		if strings.Contains(query, "%s") {
+			query = fmt.Sprintf(query, ratesInterval)
+		}
+		promtimer := internalmetrics.GetPrometheusProcessingTimePrometheusTimer("Metrics-GetControlPlaneMetrics")
+		value, err := api.Query(context.Background(), query, queryTime)
+		if err != nil {
+			return nil, err
+		}
+		promtimer.ObserveDuration() // notice we only collect metrics for successful prom queries
+		result[name] = value.(model.Vector)
+	}
+	return result, nil
+}
+
 // roundSignificant will output promQL that performs rounding only if the resulting value is significant, that is, higher than the requested precision
 func roundSignificant(innerQuery string, precision float64) string {
 	return fmt.Sprintf("round(%s, %f) > %f or %s", innerQuery, precision, precision, innerQuery)
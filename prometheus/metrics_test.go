@@ -0,0 +1,87 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/api"
+	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeQueryAPI answers the instant queries with the vectors of the expected queries, the other methods of the API
+// are not implemented
+type fakeQueryAPI struct {
+	prom_v1.API
+	vectors map[string]model.Vector
+	times   []time.Time
+}
+
+func (f *fakeQueryAPI) Query(ctx context.Context, query string, ts time.Time) (model.Value, api.Error) {
+	f.times = append(f.times, ts)
+	vector, found := f.vectors[query]
+	if !found {
+		return nil, api.NewErrorAPI(fmt.Errorf("unexpected query: %s", query), nil)
+	}
+	return vector, nil
+}
+
+func TestGetControlPlaneMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	queryTime := time.Date(2017, 01, 15, 0, 0, 0, 0, time.UTC)
+	vector := func(value float64) model.Vector {
+		return model.Vector{
+			&model.Sample{
+				Timestamp: model.Now(),
+				Value:     model.SampleValue(value),
+				Metric:    model.Metric{"instance": "10.1.0.5:15014"},
+			},
+		}
+	}
+	fake := &fakeQueryAPI{vectors: map[string]model.Vector{
+		`sum(pilot_xds) by (instance)`:                                                  vector(21),
+		`sum(rate(pilot_xds_pushes[5m])) by (instance)`:                                 vector(0.5),
+		`sum(rate(pilot_xds_pushes{type=~".+_senderr"}[5m])) by (instance)`:             vector(0.1),
+		`sum(rate(pilot_total_xds_rejects[5m])) by (instance)`:                          vector(0),
+		`sum({__name__=~"pilot_conflict_(inbound|outbound)_listener.*"}) by (instance)`: vector(2),
+		`sum(rate(sidecar_injection_failure_total[5m])) by (instance)`:                  vector(0),
+	}}
+	client := Client{}
+	client.Inject(fake)
+
+	metrics, err := client.GetControlPlaneMetrics("5m", queryTime)
+	assert.NoError(err)
+	assert.Len(metrics, 6)
+	assert.Equal(21.0, float64(metrics[ControlPlaneProxies][0].Value))
+	assert.Equal(0.5, float64(metrics[ControlPlanePushes][0].Value))
+	assert.Equal(0.1, float64(metrics[ControlPlanePushErrors][0].Value))
+	assert.Equal(0.0, float64(metrics[ControlPlaneRejectedConfigs][0].Value))
+	assert.Equal(2.0, float64(metrics[ControlPlaneConflictingListeners][0].Value))
+	assert.Equal(0.0, float64(metrics[ControlPlaneInjectionFailures][0].Value))
+	for _, ts := range fake.times {
+		assert.Equal(queryTime, ts)
+	}
+}
+
+func TestGetControlPlaneMetricsError(t *testing.T) {
+	client := Client{}
+	client.Inject(&fakeQueryAPI{vectors: map[string]model.Vector{}})
+
+	_, err := client.GetControlPlaneMetrics("5m", time.Now())
+	assert.Error(t, err)
+}
+
+func TestGetControlPlaneMetricsBadRateInterval(t *testing.T) {
+	fake := &fakeQueryAPI{vectors: map[string]model.Vector{}}
+	client := Client{}
+	client.Inject(fake)
+
+	_, err := client.GetControlPlaneMetrics("5m])) or vector(1) #", time.Now())
+	assert.Error(t, err)
+	// no query is sent to Prometheus
+	assert.Empty(t, fake.times)
+}
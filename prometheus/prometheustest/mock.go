@@ -164,6 +164,11 @@ func (o *PromClientMock) GetConfiguration() (prom_v1.ConfigResult, error) {
 	return args.Get(0).(prom_v1.ConfigResult), args.Error(1)
 }
 
+func (o *PromClientMock) GetControlPlaneMetrics(ratesInterval string, queryTime time.Time) (map[string]model.Vector, error) {
+	args := o.Called(ratesInterval, queryTime)
+	return args.Get(0).(map[string]model.Vector), args.Error(1)
+}
+
 func (o *PromClientMock) GetFlags() (prom_v1.FlagsResult, error) {
 	args := o.Called()
 	return args.Get(0).(prom_v1.FlagsResult), args.Error(1)
@@ -6,6 +6,7 @@ import (
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/log"
 	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	httpSwagger "github.com/swaggo/http-swagger"
 	"github.com/uber/jaeger-client-go"
 	jaegercfg "github.com/uber/jaeger-client-go/config"
@@ -47,6 +48,13 @@ func NewRoutes(graphController *handlers.GraphController) (r *Routes) {
 			graphController.GetNamespacesController,
 			false,
 		},
+		{
+			"Graph-Namespace-Egress",
+			http.MethodPost,
+			"/graph/namespace/{namespace}/duration/{duration}/deadEdges/{deadEdges}/passThrough/{passThrough}/graphType/{graphType}/egress/{egress}",
+			graphController.GetNamespacesController,
+			false,
+		},
 		{
 			"Graph-test",
 			http.MethodGet,
@@ -61,6 +69,76 @@ func NewRoutes(graphController *handlers.GraphController) (r *Routes) {
 			graphController.GetNodeController,
 			false,
 		},
+		{
+			"Graph-Node-Egress",
+			http.MethodPost,
+			"/graph/namespace/{namespace}/service/{service}/duration/{duration}/deadEdges/{deadEdges}/passThrough/{passThrough}/egress/{egress}",
+			graphController.GetNodeController,
+			false,
+		},
+		{
+			"Graph-Gateway",
+			http.MethodGet,
+			"/graph/namespace/{namespace}/gateway/{gateway}/duration/{duration}/deadEdges/{deadEdges}",
+			graphController.GetGatewayController,
+			false,
+		},
+		{
+			"Graph-Detail-Service",
+			http.MethodGet,
+			"/graph/detail/namespace/{namespace}/service/{service}/duration/{duration}",
+			graphController.GetNodeDetailController,
+			false,
+		},
+		{
+			"Graph-Detail-Workload",
+			http.MethodGet,
+			"/graph/detail/namespace/{namespace}/workload/{workload}/duration/{duration}",
+			graphController.GetNodeDetailController,
+			false,
+		},
+		{
+			"Graph-Detail-App",
+			http.MethodGet,
+			"/graph/detail/namespace/{namespace}/app/{app}/duration/{duration}",
+			graphController.GetNodeDetailController,
+			false,
+		},
+		{
+			"Graph-Detail-App-Version",
+			http.MethodGet,
+			"/graph/detail/namespace/{namespace}/app/{app}/version/{version}/duration/{duration}",
+			graphController.GetNodeDetailController,
+			false,
+		},
+		{
+			"Validation-History-New",
+			http.MethodGet,
+			"/validations/history/new",
+			graphController.ValidationHistoryNewController,
+			false,
+		},
+		{
+			"Validation-History-Resolved",
+			http.MethodGet,
+			"/validations/history/resolved",
+			graphController.ValidationHistoryResolvedController,
+			false,
+		},
+		{
+			"Namespace-Validation-Trend",
+			http.MethodGet,
+			"/namespaces/{namespace}/validations/trend",
+			graphController.NamespaceValidationTrendController,
+			false,
+		},
+		{
+			"Audit-Events",
+			http.MethodGet,
+			"/audit",
+			graphController.AuditEventsController,
+			false,
+		},
 	}
 	return
 }
@@ -81,6 +159,8 @@ func NewRouter(prometheusUrl, context string) (*chi.Mux, error) {
 	r.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("http://localhost:8080/swagger/doc.json"), //The url pointing to API definition"
 	))
+	// kiali internal metrics, as the validation history gauges
+	r.Handle("/metrics", promhttp.Handler())
 	for _, api := range apiRoutes.Routes {
 		r.MethodFunc(api.Method, api.Pattern, api.HandlerFunc)
 	}
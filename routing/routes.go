@@ -198,6 +198,51 @@ func NewRoutes() (r *Routes) {
 			handlers.IstioConfigPermissions,
 			true,
 		},
+		// swagger:route GET /istio/export config istioConfigExport
+		// ---
+		// Endpoint to export the Istio objects of namespaces as a multi-document YAML bundle, ready to be applied
+		//
+		//     Produces:
+		//     - application/x-yaml
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      200
+		{
+			"IstioConfigExport",
+			"GET",
+			"/api/istio/export",
+			handlers.IstioConfigExport,
+			true,
+		},
+		// swagger:route POST /istio/import config istioConfigImport
+		// ---
+		// Endpoint to import a multi-document YAML bundle of Istio objects. The objects are validated, then created
+		// or updated when they exist. Nothing is applied when an object is invalid. With dryRun=true the bundle is
+		// only validated. A bundle is imported into another cluster by the Kiali of that cluster.
+		//
+		//     Consumes:
+		//     - application/x-yaml
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      200: istioConfigImportResponse
+		{
+			"IstioConfigImport",
+			"POST",
+			"/api/istio/import",
+			handlers.IstioConfigImport,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/istio config istioConfigList
 		// ---
 		// Endpoint to get the list of Istio Config of a namespace
@@ -286,6 +331,7 @@ func NewRoutes() (r *Routes) {
 		// swagger:route PATCH /namespaces/{namespace}/istio/{object_type}/{object_subtype}/{object} config istioConfigUpdateSubtype
 		// ---
 		// Endpoint to update the Istio Config of an Istio object used for templates and adapters using Json Merge Patch strategy.
+		// With dryRun=true the patch is not applied, the validations that would result are returned (istioConfigDryRunResponse).
 		//
 		//     Consumes:
 		//	   - application/json
@@ -311,6 +357,7 @@ func NewRoutes() (r *Routes) {
 		// swagger:route POST /namespaces/{namespace}/istio/{object_type}/{object_subtype} config istioConfigCreateSubtype
 		// ---
 		// Endpoint to create an Istio object by using an Istio Config item
+		// With dryRun=true the object is not created, the validations that would result are returned (istioConfigDryRunResponse).
 		//
 		//     Produces:
 		//     - application/json
@@ -355,6 +402,7 @@ func NewRoutes() (r *Routes) {
 		// swagger:route PATCH /namespaces/{namespace}/istio/{object_type}/{object} config istioConfigUpdate
 		// ---
 		// Endpoint to update the Istio Config of an Istio object used for templates and adapters using Json Merge Patch strategy.
+		// With dryRun=true the patch is not applied, the validations that would result are returned (istioConfigDryRunResponse).
 		//
 		//     Consumes:
 		//	   - application/json
@@ -380,6 +428,7 @@ func NewRoutes() (r *Routes) {
 		// swagger:route POST /namespaces/{namespace}/istio/{object_type} config istioConfigCreate
 		// ---
 		// Endpoint to create an Istio object by using an Istio Config item
+		// With dryRun=true the object is not created, the validations that would result are returned (istioConfigDryRunResponse).
 		//
 		//     Produces:
 		//     - application/json
@@ -441,6 +490,33 @@ func NewRoutes() (r *Routes) {
 			handlers.ServiceDetails,
 			true,
 		},
+		// swagger:route POST /namespaces/{namespace}/services/{service}/traffic_routing services serviceTrafficRouting
+		// ---
+		// Endpoint to route the traffic of a service to its versions, by weights, request headers or cookies, with
+		// fault injection, timeouts and retries. The DestinationRule and the VirtualService are generated, validated
+		// and created. With dryRun=true they are only generated and validated.
+		//
+		//     Consumes:
+		//     - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: trafficRoutingResponse
+		//
+		{
+			"ServiceTrafficRouting",
+			"POST",
+			"/api/namespaces/{namespace}/services/{service}/traffic_routing",
+			handlers.ServiceTrafficRouting,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/services/{service}/spans traces spansList
 		// ---
 		// Endpoint to get Jaeger spans for a given service
@@ -835,6 +911,53 @@ func NewRoutes() (r *Routes) {
 			handlers.WorkloadHealth,
 			true,
 		},
+		// swagger:route POST /namespaces/{namespace}/workloads/{workload}/authorization_simulation workloads workloadAuthorizationSimulation
+		// ---
+		// Endpoint to evaluate a request to a workload against the ALLOW, DENY and CUSTOM AuthorizationPolicies
+		// applying to it, in the Istio order. Returns the decision and the rule deciding it.
+		//
+		//     Consumes:
+		//     - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: authorizationSimulationResponse
+		//
+		{
+			"WorkloadAuthorizationSimulation",
+			"POST",
+			"/api/namespaces/{namespace}/workloads/{workload}/authorization_simulation",
+			handlers.WorkloadAuthorizationSimulation,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/workloads/{workload}/sidecar_scope workloads workloadSidecarScope
+		// ---
+		// Get the effective Sidecar of the given workload, the services it makes reachable and its invalid hosts
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: workloadSidecarScopeResponse
+		//      404: notFoundError
+		//      500: internalError
+		//
+		{
+			"WorkloadSidecarScope",
+			"GET",
+			"/api/namespaces/{namespace}/workloads/{workload}/sidecar_scope",
+			handlers.WorkloadSidecarScope,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/metrics namespaces namespaceMetrics
 		// ---
 		// Endpoint to fetch metrics to be displayed, related to a namespace
@@ -898,6 +1021,95 @@ func NewRoutes() (r *Routes) {
 			handlers.NamespaceValidationSummary,
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/validations/trend namespaces namespaceValidationTrend
+		// ---
+		// Get the validation summaries of the namespace recorded by the periodic validation
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: namespaceValidationTrendResponse
+		//      400: badRequestError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//
+		{
+			"NamespaceValidationTrend",
+			"GET",
+			"/api/namespaces/{namespace}/validations/trend",
+			handlers.NamespaceValidationTrend,
+			true,
+		},
+		// swagger:route GET /validations/history/new validations validationHistoryNew
+		// ---
+		// Get the validation findings first seen since a time, resolved or not
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: validationFindingsResponse
+		//      400: badRequestError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//
+		{
+			"ValidationHistoryNew",
+			"GET",
+			"/api/validations/history/new",
+			handlers.ValidationHistoryNew,
+			true,
+		},
+		// swagger:route GET /validations/history/resolved validations validationHistoryResolved
+		// ---
+		// Get the validation findings resolved since a time
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: validationFindingsResponse
+		//      400: badRequestError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//
+		{
+			"ValidationHistoryResolved",
+			"GET",
+			"/api/validations/history/resolved",
+			handlers.ValidationHistoryResolved,
+			true,
+		},
+		// swagger:route GET /audit audit auditEvents
+		// ---
+		// Get the audit events of the create, update and delete operations on the Istio, 3scale and iter8 objects,
+		// the most recent first
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: auditEventsResponse
+		//      400: badRequestError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//
+		{
+			"AuditEvents",
+			"GET",
+			"/api/audit",
+			handlers.AuditEvents,
+			true,
+		},
 		// swagger:route GET /mesh/tls tls meshTls
 		// ---
 		// Get TLS status for the whole mesh
@@ -940,6 +1152,49 @@ func NewRoutes() (r *Routes) {
 			handlers.NamespaceTls,
 			true,
 		},
+		// swagger:route GET /mesh/tls/matrix tls meshTlsMatrix
+		// ---
+		// Get the mTLS status of the traffic from each workload to each service port of the namespaces, compared
+		// with the connection security policy observed in the telemetry
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: meshTlsMatrixResponse
+		//      400: badRequestError
+		//      500: internalError
+		//
+		{
+			"MeshTlsMatrix",
+			"GET",
+			"/api/mesh/tls/matrix",
+			handlers.MeshTlsMatrix,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/workloads/{workload}/tls tls workloadTls
+		// ---
+		// Get the effective mTLS mode accepted by the given workload
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: workloadTlsResponse
+		//      404: notFoundError
+		//      500: internalError
+		//
+		{
+			"WorkloadTls",
+			"GET",
+			"/api/namespaces/{namespace}/workloads/{workload}/tls",
+			handlers.WorkloadTls,
+			true,
+		},
 		// swagger:route GET /istio/status status istioStatus
 		// ---
 		// Get the status of each components needed in the control plane
@@ -961,6 +1216,27 @@ func NewRoutes() (r *Routes) {
 			handlers.IstioStatus,
 			true,
 		},
+		// swagger:route GET /istio/status/controlplane status istioControlPlaneStatus
+		// ---
+		// Get the health of each revision of the control plane, from the istiod deployments and metrics
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: istioControlPlaneStatusResponse
+		//      400: badRequestError
+		//      500: internalError
+		//
+		{
+			"IstioControlPlaneStatus",
+			"GET",
+			"/api/istio/status/controlplane",
+			handlers.IstioControlPlaneStatus,
+			true,
+		},
 		// swagger:route GET /namespaces/graph graphs graphNamespaces
 		// ---
 		// The backing JSON for a namespaces graph.
@@ -1152,6 +1428,115 @@ func NewRoutes() (r *Routes) {
 			handlers.PodLogs,
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/pods/{pod}/certs pods podProxyCertificates
+		// ---
+		// Endpoint to get the certificate chain and the root certificates loaded by the proxy of a pod
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      404: notFoundError
+		//      200: podProxyCertificatesResponse
+		//
+		{
+			"PodProxyCertificates",
+			"GET",
+			"/api/namespaces/{namespace}/pods/{pod}/certs",
+			handlers.PodProxyCertificates,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/pods/{pod}/config_dump pods podProxyConfigDump
+		// ---
+		// Endpoint to get the raw config_dump of the proxy of a pod
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      404: notFoundError
+		//      200: podProxyConfigDumpResponse
+		//
+		{
+			"PodProxyConfigDump",
+			"GET",
+			"/api/namespaces/{namespace}/pods/{pod}/config_dump",
+			handlers.PodProxyConfigDump,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/pods/{pod}/config_dump/{resource} pods podProxyConfig
+		// ---
+		// Endpoint to get a summary of the listeners, routes, clusters or endpoints of the proxy of a pod
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      404: notFoundError
+		//      400: badRequestError
+		//      200: podProxyConfigResponse
+		//
+		{
+			"PodProxyConfig",
+			"GET",
+			"/api/namespaces/{namespace}/pods/{pod}/config_dump/{resource}",
+			handlers.PodProxyConfig,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/certs pods namespaceProxyCertificates
+		// ---
+		// Endpoint to get the certificate status of the proxies of a namespace, flagging the certificates
+		// expiring soon and the identities of another trust domain
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      400: badRequestError
+		//      200: namespaceProxyCertificatesResponse
+		//
+		{
+			"NamespaceProxyCertificates",
+			"GET",
+			"/api/namespaces/{namespace}/certs",
+			handlers.NamespaceProxyCertificates,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/proxy_status pods namespaceProxyStatus
+		// ---
+		// Endpoint to get the sync status of the proxies of a namespace with istiod, listing the workloads
+		// with a stale configuration
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      503: serviceUnavailableError
+		//      500: internalError
+		//      404: notFoundError
+		//      200: namespaceProxyStatusResponse
+		//
+		{
+			"NamespaceProxyStatus",
+			"GET",
+			"/api/namespaces/{namespace}/proxy_status",
+			handlers.NamespaceProxyStatus,
+			true,
+		},
 		// swagger:route GET /threescale threescale getThreeScaleInfo
 		// ---
 		// Endpoint to check if threescale adapter is present in the cluster and if user can write adapter config
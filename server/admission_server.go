@@ -0,0 +1,60 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/handlers"
+	"github.com/kiali/kiali/log"
+)
+
+var admissionServer *http.Server
+
+// StartAdmissionServer starts a new HTTPS server serving the validating admission webhook. Only the clients with
+// a certificate signed by the configured client CA are accepted.
+func StartAdmissionServer() error {
+	conf := config.Get()
+	admission := conf.Validations.Admission
+	if admission.CertFile == "" || admission.PrivateKeyFile == "" || admission.ClientCAFile == "" {
+		return errors.New("admission webhook requires a certificate, a private key and a client CA")
+	}
+	ca, err := ioutil.ReadFile(admission.ClientCAFile)
+	if err != nil {
+		return err
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(ca) {
+		return fmt.Errorf("no certificate found in [%s]", admission.ClientCAFile)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/validate", handlers.AdmissionValidate)
+	log.Infof("Starting Admission Server on [%v:%v]", conf.Server.Address, admission.Port)
+	admissionServer = &http.Server{
+		Addr:    fmt.Sprintf("%v:%v", conf.Server.Address, admission.Port),
+		Handler: mux,
+		TLSConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ClientAuth: tls.RequireAndVerifyClientCert,
+			ClientCAs:  clientCAs,
+		},
+	}
+	go func(server *http.Server) {
+		log.Warning(server.ListenAndServeTLS(admission.CertFile, admission.PrivateKeyFile))
+	}(admissionServer)
+	return nil
+}
+
+// StopAdmissionServer stops the admission server
+func StopAdmissionServer() {
+	if admissionServer != nil {
+		log.Info("Stopping Admission Server")
+		admissionServer.Close()
+		admissionServer = nil
+	}
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/config/security"
+)
+
+func TestAdmissionServerRequiresClientCertificate(t *testing.T) {
+	assert := assert.New(t)
+
+	testPort, err := getFreePort(testHostname)
+	if err != nil {
+		t.Fatalf("Cannot get a free port to run tests on host [%v]", testHostname)
+	}
+	testServerHostPort := fmt.Sprintf("%v:%v", testHostname, testPort)
+
+	testServerCertFile := tmpDir + "/admission-test-server.cert"
+	testServerKeyFile := tmpDir + "/admission-test-server.key"
+	if err := generateCertificate(t, testServerCertFile, testServerKeyFile, testHostname); err != nil {
+		t.Fatalf("Failed to create server cert/key files: %v", err)
+	}
+	defer os.Remove(testServerCertFile)
+	defer os.Remove(testServerKeyFile)
+
+	// the client certificate is self-signed, it is its own CA
+	testClientCertFile := tmpDir + "/admission-test-client.cert"
+	testClientKeyFile := tmpDir + "/admission-test-client.key"
+	if err := generateCertificate(t, testClientCertFile, testClientKeyFile, testHostname); err != nil {
+		t.Fatalf("Failed to create client cert/key files: %v", err)
+	}
+	defer os.Remove(testClientCertFile)
+	defer os.Remove(testClientKeyFile)
+
+	conf := new(config.Config)
+	conf.Server.Address = testHostname
+	conf.Validations.Admission.Port = testPort
+	conf.Validations.Admission.CertFile = testServerCertFile
+	conf.Validations.Admission.PrivateKeyFile = testServerKeyFile
+	config.Set(conf)
+
+	// a client CA is required
+	assert.Error(StartAdmissionServer())
+
+	conf.Validations.Admission.ClientCAFile = testClientCertFile
+	config.Set(conf)
+	assert.NoError(StartAdmissionServer())
+	defer StopAdmissionServer()
+
+	url := fmt.Sprintf("https://%v/validate", testServerHostPort)
+	authenticated, err := (&httpClientConfig{
+		Identity: &security.Identity{CertFile: testClientCertFile, PrivateKeyFile: testClientKeyFile},
+	}).buildHTTPClient()
+	if err != nil {
+		t.Fatalf("Failed to create http client")
+	}
+	authenticated.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify = true
+	checkHTTPReady(authenticated, url)
+
+	// an empty review is rejected by the webhook, once the client is authenticated
+	resp, err := authenticated.Post(url, "application/json", nil)
+	if assert.NoError(err) {
+		resp.Body.Close()
+		assert.Equal(http.StatusBadRequest, resp.StatusCode)
+	}
+
+	anonymous, err := (&httpClientConfig{}).buildHTTPClient()
+	if err != nil {
+		t.Fatalf("Failed to create http client")
+	}
+	anonymous.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify = true
+	_, err = anonymous.Post(url, "application/json", nil)
+	assert.Error(err)
+}
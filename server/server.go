@@ -79,11 +79,19 @@ func (s *Server) Start() {
 	if conf.Server.MetricsEnabled {
 		StartMetricsServer()
 	}
+
+	// Start the Admission Server
+	if conf.Validations.Admission.Enabled {
+		if err := StartAdmissionServer(); err != nil {
+			log.Errorf("Admission Server could not be started: %v", err)
+		}
+	}
 }
 
 // Stop the HTTP server
 func (s *Server) Stop() {
 	StopMetricsServer()
+	StopAdmissionServer()
 	business.Stop()
 	log.Infof("Server endpoint will stop at [%v]", s.httpServer.Addr)
 	s.httpServer.Close()
@@ -0,0 +1,33 @@
+package data
+
+import (
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+func CreateEnvoyFilter(name string, namespace string) kubernetes.IstioObject {
+	return (&kubernetes.GenericIstioObject{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			ClusterName: "svc.cluster.local",
+		},
+		Spec: map[string]interface{}{},
+	}).DeepCopyIstioObject()
+}
+
+func AddSelectorToEnvoyFilter(selector map[string]interface{}, ef kubernetes.IstioObject) kubernetes.IstioObject {
+	ef.GetSpec()["workloadSelector"] = map[string]interface{}{"labels": selector}
+	return ef
+}
+
+func AddPatchToEnvoyFilter(applyTo string, match, patch map[string]interface{}, ef kubernetes.IstioObject) kubernetes.IstioObject {
+	patches, _ := ef.GetSpec()["configPatches"].([]interface{})
+	ef.GetSpec()["configPatches"] = append(patches, map[string]interface{}{
+		"applyTo": applyTo,
+		"match":   match,
+		"patch":   patch,
+	})
+	return ef
+}
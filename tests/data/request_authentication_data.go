@@ -0,0 +1,32 @@
+package data
+
+import (
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+func CreateRequestAuthentication(name string, namespace string) kubernetes.IstioObject {
+	return (&kubernetes.GenericIstioObject{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			ClusterName: "svc.cluster.local",
+		},
+		Spec: map[string]interface{}{},
+	}).DeepCopyIstioObject()
+}
+
+func AddSelectorToRequestAuthentication(selector map[string]interface{}, ra kubernetes.IstioObject) kubernetes.IstioObject {
+	ra.GetSpec()["selector"] = map[string]interface{}{"matchLabels": selector}
+	return ra
+}
+
+func AddJwtRuleToRequestAuthentication(issuer, jwksUri string, ra kubernetes.IstioObject) kubernetes.IstioObject {
+	rules, _ := ra.GetSpec()["jwtRules"].([]interface{})
+	ra.GetSpec()["jwtRules"] = append(rules, map[string]interface{}{
+		"issuer":  issuer,
+		"jwksUri": jwksUri,
+	})
+	return ra
+}
@@ -66,3 +66,15 @@ func CreateEmptyPortDefinition(port uint32, portName, protocolName string) map[s
 		"protocol": protocolName,
 	}
 }
+
+func AddEndpointsToServiceEntry(addresses []string, se kubernetes.IstioObject) kubernetes.IstioObject {
+	endpoints := make([]interface{}, 0, len(addresses))
+	for _, a := range addresses {
+		endpoints = append(endpoints, map[string]interface{}{
+			"address": a,
+			"ports":   map[string]interface{}{"http": 15443},
+		})
+	}
+	se.GetSpec()["endpoints"] = endpoints
+	return se
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/business/checkers/custom"
+	"github.com/kiali/kiali/business/export"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// ValidateOptions are the flags of the validate command
+type ValidateOptions struct {
+	Filenames []string `json:"filenames"`
+	Namespace string   `json:"namespace"`
+	Output    string   `json:"output"`
+	Rules     []string `json:"rules"`
+}
+
+var (
+	validateOptions = &ValidateOptions{
+		Namespace: "default",
+		Output:    "text",
+	}
+
+	// kiali validate -f dir/ 不依赖集群, 直接校验 yaml 文件中的 istio 配置
+	validateCmd = &cobra.Command{
+		Use:   "validate",
+		Short: "Validate Istio config from manifest files, without a cluster",
+		Long: "Run the Istio config validations against the manifests of files or directories. " +
+			"Exits with an error when any validation has error severity.",
+		Args: cobra.ExactArgs(0),
+		RunE: func(c *cobra.Command, args []string) error {
+			config.Set(config.NewConfig())
+			return validateOptions.Run(os.Stdout)
+		},
+	}
+)
+
+func init() {
+	validateCmd.Flags().StringSliceVarP(&validateOptions.Filenames, "filename", "f",
+		nil, "manifest file or directory to validate, can be repeated")
+	validateCmd.Flags().StringVarP(&validateOptions.Namespace, "namespace", "n",
+		validateOptions.Namespace, "namespace of the objects without namespace")
+	validateCmd.Flags().StringVarP(&validateOptions.Output, "output", "o",
+		validateOptions.Output, "output format: text, json, jsonl, junit or sarif")
+	validateCmd.Flags().StringSliceVar(&validateOptions.Rules, "rules",
+		nil, "file with custom validation rules, can be repeated")
+	_ = validateCmd.MarkFlagRequired("filename")
+	rootCmd.AddCommand(validateCmd)
+}
+
+// Run validates the manifests and prints the validations to out
+func (o *ValidateOptions) Run(out io.Writer) error {
+	manifests := &kubernetes.Manifests{}
+	for _, filename := range o.Filenames {
+		m, err := kubernetes.LoadManifests(filename)
+		if err != nil {
+			return err
+		}
+		manifests.Append(m)
+	}
+	customRules := []*custom.Rule{}
+	for _, filename := range o.Rules {
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		rules, err := custom.ParseRules(content)
+		if err != nil {
+			return fmt.Errorf("%s: %v", filename, err)
+		}
+		customRules = append(customRules, rules...)
+	}
+	validations := business.GetOfflineValidations(manifests, o.Namespace, customRules)
+
+	switch o.Output {
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(validationList(validations)); err != nil {
+			return err
+		}
+	case "text":
+		printValidations(out, validations)
+	case export.FormatJSONLines, export.FormatJUnit, export.FormatSARIF:
+		if err := export.Write(out, o.Output, validations, export.ManifestsLocator(manifests)); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid output [%s], supported values are text, json, %s", o.Output, strings.Join(export.Formats, ", "))
+	}
+
+	for _, v := range validations {
+		for _, check := range v.Checks {
+			if check.Severity == models.ErrorSeverity {
+				return fmt.Errorf("validation failed")
+			}
+		}
+	}
+	return nil
+}
+
+// validatedObject is a validation together with its namespace, which the validation itself does not carry
+type validatedObject struct {
+	Namespace string `json:"namespace"`
+	*models.IstioValidation
+}
+
+// validationList returns the validations sorted by namespace, type and name
+func validationList(validations models.IstioValidations) []validatedObject {
+	list := make([]validatedObject, 0, len(validations))
+	for k, v := range validations {
+		list = append(list, validatedObject{Namespace: k.Namespace, IstioValidation: v})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Namespace != list[j].Namespace {
+			return list[i].Namespace < list[j].Namespace
+		}
+		if list[i].ObjectType != list[j].ObjectType {
+			return list[i].ObjectType < list[j].ObjectType
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+func printValidations(out io.Writer, validations models.IstioValidations) {
+	errors, warnings, suppressed := 0, 0, 0
+	for _, v := range validationList(validations) {
+		for _, check := range v.Checks {
+			switch check.Severity {
+			case models.ErrorSeverity:
+				errors++
+			case models.WarningSeverity:
+				warnings++
+			}
+			fmt.Fprintf(out, "%s\t%s/%s\t%s\t%s\t%s\n", v.Namespace, v.ObjectType, v.Name, check.Severity, check.Message, check.Path)
+		}
+		for _, check := range v.SuppressedChecks {
+			suppressed++
+			fmt.Fprintf(out, "%s\t%s/%s\tsuppressed %s\t%s\t%s\n", v.Namespace, v.ObjectType, v.Name, check.Severity, check.Message, check.Path)
+		}
+	}
+	fmt.Fprintf(out, "%d objects validated, %d errors, %d warnings, %d suppressed\n", len(validations), errors, warnings, suppressed)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+)
+
+func TestValidateRun(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	dir, err := ioutil.TempDir("", "validate")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	manifest := `
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: ratings
+  namespace: bookinfo
+spec:
+  host: ratings
+`
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, "ratings.yaml"), []byte(manifest), 0644))
+	// files with other extensions are skipped
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("# manifests"), 0644))
+
+	out := &bytes.Buffer{}
+	o := &ValidateOptions{Filenames: []string{dir}, Namespace: "default", Output: "text"}
+	// no Service registers the host of the DestinationRule
+	assert.Error(o.Run(out))
+	assert.True(strings.HasPrefix(out.String(), "bookinfo\tdestinationrule/ratings\terror\tKIA0202"))
+	assert.Contains(out.String(), "1 objects validated, 1 errors, 0 warnings")
+
+	o.Output = "yaml"
+	assert.Error(o.Run(out))
+}
+
+func TestValidateRunSARIF(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	dir, err := ioutil.TempDir("", "validate")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	manifest := `apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: ratings
+spec:
+  host: ratings
+`
+	file := filepath.Join(dir, "ratings.yaml")
+	assert.NoError(ioutil.WriteFile(file, []byte(manifest), 0644))
+
+	out := &bytes.Buffer{}
+	o := &ValidateOptions{Filenames: []string{file}, Namespace: "bookinfo", Output: "sarif"}
+	assert.Error(o.Run(out))
+	assert.Contains(out.String(), `"uri": "`+filepath.ToSlash(file)+`"`)
+	assert.Contains(out.String(), `"startLine": 6`)
+}
+
+func TestValidateRunCustomRules(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	dir, err := ioutil.TempDir("", "validate")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	manifest := `apiVersion: networking.istio.io/v1alpha3
+kind: Gateway
+metadata:
+  name: bookinfo-gateway
+spec:
+  servers: []
+`
+	rules := `rules:
+- id: HOUSE0001
+  object_type: gateway
+  message: Gateways must declare a selector
+  expression: "has(object.spec.selector)"
+`
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, "gateway.yaml"), []byte(manifest), 0644))
+	rulesFile := filepath.Join(dir, "rules.txt")
+	assert.NoError(ioutil.WriteFile(rulesFile, []byte(rules), 0644))
+
+	out := &bytes.Buffer{}
+	o := &ValidateOptions{Filenames: []string{dir}, Namespace: "bookinfo", Output: "text", Rules: []string{rulesFile}}
+	assert.NoError(o.Run(out))
+	assert.Contains(out.String(), "bookinfo\tgateway/bookinfo-gateway\twarning\tHOUSE0001 Gateways must declare a selector")
+}